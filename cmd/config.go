@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/config"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/sweep"
+)
+
+// Define function to register the flags shared by every command that takes a run configuration.
+// Defaults come from config.Default so the file, the flags and the help text agree.
+func addConfigFlags(fs *flag.FlagSet) *string {
+	def := config.Default()
+	configPath := fs.String("config", "", "run configuration file (YAML), explicit flags override it")
+
+	// Rise Time Budget options
+	fs.Bool("rtb", def.Runner.RTB, "enable RTB")
+	fs.Float64("bitrate-gbps", def.Runner.BitrateGbps, "bitrate (Gbps) for RTB, penalties and PMD (0 uses 2.5 with --rtb, none otherwise)")
+	fs.Float64("tx-rt-ns", def.Runner.TxRiseTimeNs, "Tx rise time (ns)")
+	fs.Float64("rx-rt-ns", def.Runner.RxRiseTimeNs, "Rx rise time (ns)")
+	fs.Float64("disp-ns-km", def.Runner.DispersionNsPerKm, "dispersion (ns/km)")
+	fs.Float64("pmd-fraction", def.Runner.PMDBitFraction, "PMD limit as a share of the bit period (0 uses 0.1)")
+
+	// BER defaults for links with a sensitivity_ber column
+	fs.String("fec", "", "FEC code for links without a fec column ("+strings.Join(calc.FECNames(), ", ")+")")
+	fs.Float64("target-ber", def.Runner.TargetBER, "post-FEC BER target for links without a target_ber column (0 uses 1e-12)")
+
+	// CSV format options
+	fs.String("delimiter", "", "input delimiter: a character or tab, comma, semicolon, pipe (default ',')")
+	fs.Bool("decimal-comma", def.CSV.DecimalComma, "input numbers use a decimal comma")
+	fs.String("out-delimiter", "", "output delimiter (default ';' for decimal-comma locales, ',' otherwise)")
+	fs.Int("precision", def.CSV.Precision, "digits after the decimal point in output, -1 for shortest")
+	fs.String("locale", "", "output number format locale, e.g. id-ID or de (default decimal point)")
+
+	// Validation options
+	fs.String("rules", "", "validation rules file (YAML)")
+	fs.String("scenarios", "", "declared scenario names (comma separated)")
+	return configPath
+}
+
+// Define function to build the effective configuration: defaults, then the
+// config file, then every flag set explicitly on the command line
+func resolveConfig(fs *flag.FlagSet, configPath string) config.Config {
+	cfg := config.Default()
+	if configPath != "" {
+		loaded, err := config.Load(configPath)
+		if err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+		cfg = loaded
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		if flagErr == nil {
+			flagErr = applyFlag(&cfg, f)
+		}
+	})
+	if flagErr != nil {
+		fmt.Println("An error has occurred: ", flagErr.Error())
+		os.Exit(2)
+	}
+
+	// Fall back to the command's default output path
+	if out := fs.Lookup("out"); out != nil && cfg.Output == "" {
+		cfg.Output = out.DefValue
+	}
+	return cfg
+}
+
+// Define function to copy one explicitly set flag into the configuration
+func applyFlag(cfg *config.Config, f *flag.Flag) error {
+	value := f.Value.(flag.Getter).Get()
+	switch f.Name {
+	case "in":
+		cfg.Input = value.(string)
+	case "out":
+		cfg.Output = value.(string)
+	case "rtb":
+		cfg.Runner.RTB = value.(bool)
+	case "bitrate-gbps":
+		cfg.Runner.BitrateGbps = value.(float64)
+	case "tx-rt-ns":
+		cfg.Runner.TxRiseTimeNs = value.(float64)
+	case "rx-rt-ns":
+		cfg.Runner.RxRiseTimeNs = value.(float64)
+	case "disp-ns-km":
+		cfg.Runner.DispersionNsPerKm = value.(float64)
+	case "pmd-fraction":
+		cfg.Runner.PMDBitFraction = value.(float64)
+	case "delimiter":
+		cfg.CSV.Delimiter = value.(string)
+	case "decimal-comma":
+		cfg.CSV.DecimalComma = value.(bool)
+	case "out-delimiter":
+		cfg.CSV.OutDelimiter = value.(string)
+	case "precision":
+		cfg.CSV.Precision = value.(int)
+	case "locale":
+		cfg.CSV.Locale = value.(string)
+	case "fec":
+		if _, ok := calc.LookupFEC(value.(string)); !ok {
+			return errors.New("Unknown --fec " + value.(string) + " (available: " + strings.Join(calc.FECNames(), ", ") + ")")
+		}
+		cfg.Runner.FEC = value.(string)
+	case "target-ber":
+		cfg.Runner.TargetBER = value.(float64)
+	case "rules":
+		cfg.Validation.Rules = value.(string)
+	case "report":
+		cfg.Validation.Report = value.(string)
+	case "scenarios":
+		cfg.Validation.Scenarios = splitList(value.(string))
+	case "profile":
+		cfg.Profile = value.(string)
+	case "vary":
+		v, err := sweep.ParseVariations(value.(string))
+		if err != nil {
+			return err
+		}
+		cfg.Sweep.Variations = []config.VariationConfig{{Field: v.Field, Values: v.Values}}
+	}
+	return nil
+}
+
+// Define function to write the resolved configuration next to an output file
+func echoConfig(output, command string, cfg config.Config) {
+	path, err := config.WriteMetadata(output, command, cfg)
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	fmt.Printf("Run configuration written to %s\n", path)
+}
+
+// Define helper to split a comma separated list
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
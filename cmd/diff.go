@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/analysis"
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define exit code used when the diff finds regressions
+const exitRegression = 3
+
+// Define function to handle diff command
+func cmdDiff(args []string) {
+	flagDiff := flag.NewFlagSet("diff", flag.ExitOnError)
+	flagDiff.String("out", "", "output file (default stdout)")
+	format := flagDiff.String("format", "", "csv, json or md (default from --out extension, md for stdout)")
+	tolerance := flagDiff.Float64("tolerance-db", 0.001, "margin moves within this many dB count as unchanged")
+	maxDrop := flagDiff.Float64("max-drop-db", 0, "margin drops larger than this are regressions (0 disables)")
+	failRemoved := flagDiff.Bool("fail-on-removed", false, "treat links missing from the new run as regressions")
+	lpbOnly := flagDiff.Bool("lpb-only", false, "compare the power budget status only, not RTB, OSNR, BER, PMD, ORL, nonlinear, PHY or compliance")
+	oldScenario := flagDiff.String("old-scenario", "", "compare only this scenario of the old file")
+	newScenario := flagDiff.String("new-scenario", "", "compare only this scenario of the new file (joins on link_id)")
+	flagDiff.String("delimiter", "", "input delimiter: a character or tab, comma, semicolon, pipe (default ',')")
+	flagDiff.Bool("decimal-comma", false, "input numbers use a decimal comma")
+	flagDiff.String("out-delimiter", "", "output delimiter (default ';' for decimal-comma locales, ',' otherwise)")
+	flagDiff.Int("precision", 6, "digits after the decimal point in output, -1 for shortest")
+	flagDiff.String("locale", "", "output number format locale, e.g. id-ID or de (default decimal point)")
+
+	// Accept flags before and after the two file arguments
+	var files []string
+	for {
+		_ = flagDiff.Parse(args)
+		if flagDiff.NArg() == 0 {
+			break
+		}
+		files = append(files, flagDiff.Arg(0))
+		args = flagDiff.Args()[1:]
+	}
+	cfg := resolveConfig(flagDiff, "")
+
+	// A single file is enough when comparing two of its scenarios
+	if len(files) == 1 && *oldScenario != "" && *newScenario != "" {
+		files = append(files, files[0])
+	}
+	if len(files) != 2 {
+		fmt.Println("usage: fo diff [flags] old.csv new.csv")
+		os.Exit(2)
+	}
+	old := readResultsOrExit(files[0], cfg.CSV.Delimiter, cfg.CSV.DecimalComma)
+	new := readResultsOrExit(files[1], cfg.CSV.Delimiter, cfg.CSV.DecimalComma)
+	if *oldScenario != "" || *newScenario != "" {
+		if *oldScenario == "" || *newScenario == "" {
+			fmt.Println("--old-scenario and --new-scenario must be used together")
+			os.Exit(2)
+		}
+		label := *oldScenario + " → " + *newScenario
+		old = analysis.SelectScenario(old, *oldScenario, label)
+		new = analysis.SelectScenario(new, *newScenario, label)
+	}
+
+	d := analysis.Compare(old, new, analysis.DiffOptions{
+		ToleranceDb:         *tolerance,
+		MaxDropDb:           *maxDrop,
+		RemovedIsRegression: *failRemoved,
+		LPBOnly:             *lpbOnly,
+	})
+	if *format == "" {
+		*format = analysis.FormatFromPath(cfg.Output)
+	}
+
+	// Write to stdout unless an output file is given
+	var w io.Writer = os.Stdout
+	if cfg.Output != "" {
+		file, err := os.Create(cfg.Output)
+		if err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := analysis.WriteDiff(w, d, *format, csvWriteOptions(cfg)); err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	if cfg.Output != "" {
+		fmt.Printf("Diff written to %s\n", cfg.Output)
+	}
+	if d.Regressions > 0 {
+		fmt.Fprintf(os.Stderr, "%d regression(s): %d PASS → FAIL, %d other check(s) to FAIL\n", d.Regressions, d.PassToFail, d.CheckFails)
+		os.Exit(exitRegression)
+	}
+}
+
+// Define function to read a results file and stop on any error
+func readResultsOrExit(path, delimiter string, decimalComma bool) []model.LinkOutput {
+	d, err := foio.ParseDelimiter(delimiter)
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(2)
+	}
+	results, rowErrs, err := foio.ReadResultsCSV(path, foio.CSVReadOptions{Delimiter: d, DecimalComma: decimalComma})
+	if err != nil || len(rowErrs) > 0 {
+		for _, e := range rowErrs {
+			fmt.Println(path + ": " + e.Error())
+		}
+		if err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+		}
+		os.Exit(1)
+	}
+	return results
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/explain"
+)
+
+// Define function to handle explain command
+func cmdExplain(args []string) {
+	flagExplain := flag.NewFlagSet("explain", flag.ExitOnError)
+	configPath := addConfigFlags(flagExplain)
+	flagExplain.String("in", "", "input CSV")
+	linkID := flagExplain.String("link", "", "link_id to explain")
+	scenario := flagExplain.String("scenario", "", "only explain this scenario (default every scenario of the link)")
+	asJSON := flagExplain.Bool("json", false, "print the trace as JSON")
+	_ = flagExplain.Parse(args)
+	cfg := resolveConfig(flagExplain, *configPath)
+
+	// Check if input and link are provided
+	if cfg.Input == "" || *linkID == "" {
+		fmt.Println("missing --in or --link")
+		os.Exit(1)
+	}
+	links := readLinksOrExit(cfg)
+
+	var explanations []explain.Explanation
+	for _, link := range links {
+		if link.LinkID != *linkID || (*scenario != "" && link.Scenario != *scenario) {
+			continue
+		}
+		e, err := explain.Explain(link, cfg.RunnerOptions())
+		if err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+		explanations = append(explanations, e)
+	}
+	if len(explanations) == 0 {
+		fmt.Printf("link %q not found in %s\n", *linkID, cfg.Input)
+		os.Exit(1)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(explanations)
+		return
+	}
+	for i, e := range explanations {
+		if i > 0 {
+			fmt.Println()
+		}
+		_ = explain.Write(os.Stdout, e)
+	}
+}
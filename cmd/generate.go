@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/generate"
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+)
+
+// Define function to handle generate command
+func cmdGenerate(args []string) {
+	flagGen := flag.NewFlagSet("generate", flag.ExitOnError)
+	n := flagGen.Int("n", 1000, "number of links to generate")
+	seed := flagGen.Uint64("seed", 42, "random seed for reproducibility")
+	scenario := flagGen.String("scenario", "base", "scenario label for the generated links")
+	distPath := flagGen.String("dist", "", "distribution file (YAML), merged over the built-in distributions")
+	spliceEvery := flagGen.Float64("splice-every-km", generate.DefaultSpliceEveryKm, "one splice per this many km, 0 samples n_splice instead")
+	attempts := flagGen.Int("max-attempts", generate.DefaultMaxAttempts, "resample attempts per link before giving up")
+	flagGen.String("out", "examples/links_generated.csv", "output CSV")
+	flagGen.String("rules", "", "validation rules file (YAML) generated links must pass")
+	flagGen.String("out-delimiter", "", "output delimiter (default ';' for decimal-comma locales, ',' otherwise)")
+	flagGen.String("locale", "", "output number format locale, e.g. id-ID or de (default decimal point)")
+	_ = flagGen.Parse(args)
+	cfg := resolveConfig(flagGen, "")
+
+	opt := generate.Options{
+		N:             *n,
+		Seed:          *seed,
+		Scenario:      *scenario,
+		SpliceEveryKm: *spliceEvery,
+		MaxAttempts:   *attempts,
+		Validation:    loadValidationOptions(cfg),
+	}
+	if *distPath != "" {
+		// Flags set explicitly still win over the file
+		explicit := false
+		flagGen.Visit(func(f *flag.Flag) { explicit = explicit || f.Name == "splice-every-km" })
+		every := opt.SpliceEveryKm
+		if err := generate.LoadFile(*distPath, &opt); err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+		if explicit {
+			opt.SpliceEveryKm = every
+		}
+	}
+
+	links, err := generate.Generate(opt)
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+
+	// Generated values are already rounded, so write them in their shortest form
+	writeOpt := csvWriteOptions(cfg)
+	writeOpt.Precision = -1
+	if err := os.MkdirAll(filepath.Dir(cfg.Output), 0o755); err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	if err := foio.WriteLinksCSV(cfg.Output, links, writeOpt); err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	fmt.Printf("DONE — %d links written to %s\n", len(links), cfg.Output)
+}
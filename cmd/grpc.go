@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/rpc"
+)
+
+// Define function to run the gRPC service
+func cmdGRPC(args []string) {
+	flagGRPC := flag.NewFlagSet("grpc", flag.ExitOnError)
+	addr := flagGRPC.String("addr", ":9090", "listen address")
+
+	// Runner options are the defaults for requests that send none
+	configPath := addConfigFlags(flagGRPC)
+	_ = flagGRPC.Parse(args)
+	cfg := resolveConfig(flagGRPC, *configPath)
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+
+	// Stop on Ctrl+C or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("gRPC listening on %s\n", lis.Addr())
+	err = rpc.Serve(ctx, lis, rpc.Options{
+		Runner:     cfg.RawRunnerOptions(),
+		Validation: loadValidationOptions(cfg),
+	})
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	fmt.Println("gRPC server stopped")
+}
@@ -6,8 +6,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/compliance"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/config"
 	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
 	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
 	"github.com/fadeldnswr/fo-performance-engine.git/internal/sweep"
@@ -29,6 +32,20 @@ func main(){
 		cmdRun(os.Args[2:])
 	case "sweep":
 		cmdSweep(os.Args[2:])
+	case "summarize":
+		cmdSummarize(os.Args[2:])
+	case "explain":
+		cmdExplain(os.Args[2:])
+	case "diff":
+		cmdDiff(os.Args[2:])
+	case "report":
+		cmdReport(os.Args[2:])
+	case "generate":
+		cmdGenerate(os.Args[2:])
+	case "serve":
+		cmdServe(os.Args[2:])
+	case "grpc":
+		cmdGRPC(os.Args[2:])
 	default:
 		usage()
 		os.Exit(2)
@@ -40,103 +57,199 @@ func usage(){
 	fmt.Println("FTTH / Fiber Optic Performance Engine")
 	fmt.Println()
 	fmt.Println("Usage:")
-	fmt.Println("  fo validate --in links.csv")
-	fmt.Println("  fo run      --in links.csv --out results.csv [--rtb]")
-	fmt.Println("  fo sweep    --in links.csv --out results.csv --vary engineering_margin_db=3,6")
+	fmt.Println("  fo validate --in links.csv [--config run.yaml] [--report errors.json] [--rules rules.yaml] [--scenarios base,worst] [--profile gpon-b+]")
+	fmt.Println("  fo run      --in links.csv --out results.csv [--config run.yaml] [--rtb] [--bitrate-gbps 2.5] [--rules rules.yaml] [--profile gpon-b+] [--channels-out channels.csv] [--services-out services.csv]")
+	fmt.Println("  fo sweep    --in links.csv --out results.csv [--config run.yaml] --vary system_margin_db=3,6")
+	fmt.Println("  fo summarize --in results.csv [--out summary.md|.csv|.json] [--worst-n 10] [--worst-out worst.csv]")
+	fmt.Println("  fo explain  --in links.csv --link L07 [--config run.yaml] [--scenario base] [--rtb] [--json]")
+	fmt.Println("  fo diff     old.csv new.csv [--max-drop-db 1] [--fail-on-removed] [--lpb-only] [--out diff.csv]  (exit 3 on regressions)")
+	fmt.Println("  fo report   --in results.csv --out report.html [--links links.csv]")
+	fmt.Println("  fo generate --n 2000 --seed 42 --out links.csv [--dist dist.yaml] [--rules rules.yaml]")
+	fmt.Println("  fo serve    --addr :8080 [--config run.yaml]")
+	fmt.Println("  fo grpc     --addr :9090 [--config run.yaml]")
+	fmt.Println()
+	fmt.Println("RTB options: --rtb [--bitrate-gbps 2.5] [--tx-rt-ns 0.2] [--rx-rt-ns 0.2] [--disp-ns-km 0]; --bitrate-gbps also")
+	fmt.Println("sets the penalty and PMD bitrate, which stay unevaluated without it unless --rtb is given.")
+	fmt.Println("validate, run, sweep, explain, serve and grpc accept --config run.yaml; explicit flags override the file.")
 }
 
 // Define function to handle validate command
 func cmdValidate(args []string){
 	flagVal := flag.NewFlagSet("validate", flag.ExitOnError)
-	input := flagVal.String("in", "", "input CSV")
+	configPath := addConfigFlags(flagVal)
+	flagVal.String("in", "", "input CSV")
+	flagVal.String("report", "", "write all errors to a report file (.json or .csv)")
+	flagVal.String("profile", "", "standards compliance profile (e.g. gpon-b+)")
 	_ = flagVal.Parse(args)
+	cfg := resolveConfig(flagVal, *configPath)
 
 	// Check if input is provided
-	if *input == "" {
+	if cfg.Input == "" {
 		fmt.Println("missing --in")
 		os.Exit(1)
 	}
+	valOpt := loadValidationOptions(cfg)
+	profile := lookupProfile(cfg.Profile)
 
 	// Define slice to hold links
-	links, rowErrs, err := foio.ReadLinksCSV(*input, foio.CSVReadOptions{})
-	if err != nil {
+	links, rowErrs, err := foio.ReadLinksCSV(cfg.Input, csvReadOptions(cfg))
+	if err != nil && len(rowErrs) == 0 {
 		fmt.Println("An error has occurred: ", err)
 		os.Exit(1)
 	}
 
-	// Check if row errors exist
-	if len(rowErrs) > 0 {
-		for _, e := range rowErrs {
-			fmt.Println(e.Error())
+	// Collect parse and validation errors in one pass
+	allErrs := rowErrs
+	if err == nil {
+		allErrs = append(allErrs, validate.ValidateLink(links, valOpt)...)
+	}
+
+	// Check compliance with the selected profile
+	if err == nil && profile != nil && !validate.HasBlocking(allErrs) {
+		verdicts, cerr := compliance.Check(links, *profile)
+		if cerr != nil {
+			fmt.Println("An error has occurred: ", cerr.Error())
+			os.Exit(1)
 		}
-		os.Exit(1)
+		allErrs = append(allErrs, compliance.ToRowErrors(links, verdicts)...)
+	}
+	for _, e := range allErrs {
+		fmt.Println(e.Error())
 	}
-	valErrs := validate.ValidateLink(links, validate.ValidationOptions{})
-	if len(valErrs) > 0 {
-		for _, e := range valErrs {
-			fmt.Println(e.Error())
+
+	// Write machine-readable report if requested
+	if cfg.Validation.Report != "" {
+		if err := foio.WriteErrorReport(cfg.Validation.Report, allErrs); err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
 		}
+		fmt.Printf("Error report written to %s\n", cfg.Validation.Report)
+	}
+	if validate.HasBlocking(allErrs) || err != nil {
 		os.Exit(1)
 	}
+	if profile != nil {
+		fmt.Printf("OK — %d rows validated, compliant with %s\n", len(links), profile.Name)
+		return
+	}
 	fmt.Printf("OK — %d rows validated\n", len(links))
 }
 
-// Define function to run command
-func cmdRun(args []string){
-	flagRun := flag.NewFlagSet("run", flag.ExitOnError)
-	input := flagRun.String("in", "", "input CSV")
-	output := flagRun.String("out", "results.csv", "output CSV")
+// Define function to resolve a compliance profile flag, nil when not set
+func lookupProfile(name string) *compliance.Profile {
+	if name == "" {
+		return nil
+	}
+	p, ok := compliance.Lookup(name)
+	if !ok {
+		fmt.Printf("unknown --profile %q (available: %s)\n", name, strings.Join(compliance.Names(), ", "))
+		os.Exit(2)
+	}
+	return &p
+}
 
-	// Rise Time Budget options
-	enableRTB := flagRun.Bool("rtb", false, "enable RTB")
-	bitrate := flagRun.Float64("bitrate-gbps", 2.5, "bitrate (Gbps)")
-	txrt := flagRun.Float64("tx-rt-ns", 0.2, "Tx rise time (ns)")
-	rxrt := flagRun.Float64("rx-rt-ns", 0.2, "Rx rise time (ns)")
-	dispersion := flagRun.Float64("disp-ns-km", 0.0, "dispersion (ns/km)")
+// Define function to build validation options from the configuration
+func loadValidationOptions(cfg config.Config) validate.ValidationOptions {
+	opt := validate.ValidationOptions{
+		MaxFiberAttPerDbKm: cfg.Validation.MaxFiberAttDbPerKm,
+		SkipPlausibility:   cfg.Validation.SkipPlausibility,
+		Scenarios:          cfg.Validation.Scenarios,
+		Cables:             cfg.Cables,
+	}
+	if cfg.Validation.Rules == "" {
+		return opt
+	}
+	rules, err := validate.LoadRules(cfg.Validation.Rules)
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	opt.Rules = rules
+	return opt
+}
 
-	// Parse flags
-	_ = flagRun.Parse(args)
+// Define function to build CSV read options from the configuration
+func csvReadOptions(cfg config.Config) foio.CSVReadOptions {
+	delimiter, err := foio.ParseDelimiter(cfg.CSV.Delimiter)
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(2)
+	}
+	return foio.CSVReadOptions{Delimiter: delimiter, DecimalComma: cfg.CSV.DecimalComma}
+}
 
-	// Check if input is provided
-	if *input == "" {
-		fmt.Println("missing --in")
+// Define function to build CSV write options from the configuration
+func csvWriteOptions(cfg config.Config) foio.CSVWriteOptions {
+	decimalComma, err := foio.LocaleDecimalComma(cfg.CSV.Locale)
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(2)
+	}
+	delimiter, err := foio.ParseDelimiter(cfg.CSV.OutDelimiter)
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
 		os.Exit(2)
 	}
+	if delimiter == 0 && decimalComma {
+		delimiter = ';' // Keep "1,5" from being quoted in every cell
+	}
+	return foio.CSVWriteOptions{Delimiter: delimiter, Precision: cfg.CSV.Precision, DecimalComma: decimalComma}
+}
 
-	// Read input CSV
-	links, rowErrs, err := foio.ReadLinksCSV(*input, foio.CSVReadOptions{})
+// Define function to read links and stop on any parse error
+func readLinksOrExit(cfg config.Config) []model.LinkInput {
+	links, rowErrs, err := foio.ReadLinksCSV(cfg.Input, csvReadOptions(cfg))
 
-	// Check for read errors
+	// Check if row errors exist
+	for _, e := range rowErrs {
+		fmt.Println(e.Error())
+	}
 	if err != nil {
 		fmt.Println("An error has occurred: ", err.Error())
 		os.Exit(1)
 	}
-
-	// Check if row errors exist
 	if len(rowErrs) > 0 {
-		for _, e := range rowErrs {
-			fmt.Println(e.Error())
-		}
 		os.Exit(1)
 	}
+	return links
+}
 
-	// Process each link
-	valErrs := validate.ValidateLink(links, validate.ValidationOptions{})
-	if len(valErrs) > 0 {
-		for _, e := range valErrs {
-			fmt.Println(e.Error())
-		}
+// Define function to run command
+func cmdRun(args []string){
+	flagRun := flag.NewFlagSet("run", flag.ExitOnError)
+	configPath := addConfigFlags(flagRun)
+	flagRun.String("in", "", "input CSV")
+	flagRun.String("out", "results.csv", "output CSV")
+	flagRun.String("profile", "", "standards compliance profile (e.g. gpon-b+)")
+	channelsOut := flagRun.String("channels-out", "", "write the per-channel table of WDM links to this CSV")
+	servicesOut := flagRun.String("services-out", "", "write the per-service table of coexistence links to this CSV")
+
+	// Parse flags
+	_ = flagRun.Parse(args)
+	cfg := resolveConfig(flagRun, *configPath)
+
+	// Check if input is provided
+	if cfg.Input == "" {
+		fmt.Println("missing --in")
+		os.Exit(2)
+	}
+	valOpt := loadValidationOptions(cfg)
+	profile := lookupProfile(cfg.Profile)
+
+	// Read input CSV
+	links := readLinksOrExit(cfg)
+
+	// Process each link, only errors block the run
+	valErrs := validate.ValidateLink(links, valOpt)
+	for _, e := range valErrs {
+		fmt.Println(e.Error())
+	}
+	if validate.HasBlocking(valErrs) {
 		os.Exit(1)
 	}
 
 	// Define options for calculations
-	opt := calc.RunnerOptions{
-		EnableRTB:       *enableRTB,
-		BitrateGbps:     *bitrate,
-		TxRiseTimeNs:    *txrt,
-		RxRiseTimeNs:    *rxrt,
-		DispersionPerKm: *dispersion,
-	}
+	opt := cfg.RunnerOptions()
 	results := make([]model.LinkOutput, 0, len(links))
 	for _, link := range links {
 		res, err := calc.Compute(link, opt)
@@ -146,72 +259,77 @@ func cmdRun(args []string){
 		}
 		results = append(results, res)
 	}
-	if err := foio.WriteCSV(*output, results, ','); err != nil {
+
+	// Attach compliance verdicts for the selected profile
+	if profile != nil {
+		verdicts, err := compliance.Check(links, *profile)
+		if err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+		for i, v := range verdicts {
+			results[i].ComplianceProfile = v.Profile
+			results[i].ComplianceStatus = v.Status()
+			results[i].ComplianceClause = v.Clause()
+		}
+	}
+	if err := foio.WriteCSV(cfg.Output, results, csvWriteOptions(cfg)); err != nil {
 		fmt.Println("An error has occurred: ", err.Error())
 		os.Exit(1)
 	}
-	fmt.Printf("DONE — %d links written to %s\n", len(results), *output)
+	if *channelsOut != "" {
+		if err := foio.WriteChannelsCSV(*channelsOut, results, csvWriteOptions(cfg)); err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+	}
+	if *servicesOut != "" {
+		if err := foio.WriteServicesCSV(*servicesOut, results, csvWriteOptions(cfg)); err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+	}
+	echoConfig(cfg.Output, "run", cfg)
+	fmt.Printf("DONE — %d links written to %s\n", len(results), cfg.Output)
 }
 
 // Define function to run sweep command
 func cmdSweep(args []string){
 	flagSweep := flag.NewFlagSet("sweep", flag.ExitOnError)
-	input := flagSweep.String("in", "", "input CSV")
-	output := flagSweep.String("out", "result_sweep.csv", "output CSV")
-	vary := flagSweep.String("vary", "", "variation spec (e.g. system_margin_db=3,6)")
-
-	// Define options to enable RTB
-	enableRTB := flagSweep.Bool("rtb", false, "enable RTB")
-	bitrate := flagSweep.Float64("bitrate-gbps", 2.5, "bitrate (Gbps)")
-	txrt := flagSweep.Float64("tx-rt-ns", 0.2, "Tx rise time (ns)")
-	rxrt := flagSweep.Float64("rx-rt-ns", 0.2, "Rx rise time (ns)")
-	dispersion := flagSweep.Float64("disp-ns-km", 0.0, "dispersion (ns/km)")
+	configPath := addConfigFlags(flagSweep)
+	flagSweep.String("in", "", "input CSV")
+	flagSweep.String("out", "result_sweep.csv", "output CSV")
+	flagSweep.String("vary", "", "variation spec (e.g. system_margin_db=3,6)")
 
 	// Parse flags
 	_ = flagSweep.Parse(args)
+	cfg := resolveConfig(flagSweep, *configPath)
 
 	// Check if the input args are provided
-	if *input == "" || *vary == "" {
+	if cfg.Input == "" || len(cfg.Sweep.Variations) == 0 {
 		fmt.Println("missing --in or --vary")
 		os.Exit(2)
 	}
 
 	// Define options for runner
-	links, rowErrs, err := foio.ReadLinksCSV(*input, foio.CSVReadOptions{})
-	if err != nil {
-		fmt.Println("An error has occurred: ", err.Error())
-		os.Exit(1)
-	}
+	links := readLinksOrExit(cfg)
 
-	// Check if row errors exist
-	if len(rowErrs) > 0 {
-		for _, e := range rowErrs {
-			fmt.Println(e.Error())
+	// Check sweep variations up front, RunSweep panics on unknown fields
+	vars := cfg.Variations()
+	for _, v := range vars {
+		if _, err := sweep.ApplyVariations(model.LinkInput{}, v, 0); err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
 		}
-		os.Exit(1)
-	}
-
-	// Perform sweep variation
-	vars, err := sweep.ParseVariations(*vary)
-	if err != nil {
-		fmt.Println("An error has occurred: ", err.Error())
-		os.Exit(1)
 	}
 
 	// Define sweep options for calculations
-	opt := sweep.SweepOptions{
-		Runner: calc.RunnerOptions{
-			EnableRTB:       *enableRTB,
-			BitrateGbps:     *bitrate,
-			TxRiseTimeNs:    *txrt,
-			RxRiseTimeNs:    *rxrt,
-			DispersionPerKm: *dispersion,
-		},
-	}
-	results := sweep.RunSweep(links, []sweep.Variation{vars}, opt)
-	if err := foio.WriteCSV(*output, results, ','); err != nil {
+	opt := sweep.SweepOptions{Runner: cfg.RunnerOptions()}
+	results := sweep.RunSweep(links, vars, opt)
+	if err := foio.WriteCSV(cfg.Output, results, csvWriteOptions(cfg)); err != nil {
 		fmt.Println("An error has occurred: ", err.Error())
 		os.Exit(1)
 	}
-	fmt.Printf("DONE — %d swept links written to %s\n", len(results), *output)
-}
\ No newline at end of file
+	echoConfig(cfg.Output, "sweep", cfg)
+	fmt.Printf("DONE — %d swept links written to %s\n", len(results), cfg.Output)
+}
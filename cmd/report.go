@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/report"
+)
+
+// Define function to handle report command
+func cmdReport(args []string) {
+	flagReport := flag.NewFlagSet("report", flag.ExitOnError)
+	flagReport.String("in", "", "results CSV from run or sweep")
+	flagReport.String("out", "report.html", "output HTML file")
+	linksPath := flagReport.String("links", "", "input links CSV to merge fiber_length_km (enables margin vs length chart)")
+	title := flagReport.String("title", "", "report title")
+	flagReport.String("delimiter", "", "input delimiter: a character or tab, comma, semicolon, pipe (default ',')")
+	flagReport.Bool("decimal-comma", false, "input numbers use a decimal comma")
+	_ = flagReport.Parse(args)
+	cfg := resolveConfig(flagReport, "")
+
+	// Check if input is provided
+	if cfg.Input == "" {
+		fmt.Println("missing --in")
+		os.Exit(1)
+	}
+	results, rowErrs, err := foio.ReadResultsCSV(cfg.Input, csvReadOptions(cfg))
+	for _, e := range rowErrs {
+		fmt.Fprintln(os.Stderr, e.Error())
+	}
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+
+	opt := report.Options{Title: *title, Source: filepath.Base(cfg.Input)}
+	if *linksPath != "" {
+		cfg.Input = *linksPath
+		links := readLinksOrExit(cfg)
+		opt.Lengths = make(map[string]float64, len(links))
+		for _, l := range links {
+			opt.Lengths[l.LinkID] = l.FiberLengthKm
+		}
+	}
+
+	if err := report.WriteFile(cfg.Output, results, opt); err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	fmt.Printf("Report of %d results written to %s\n", len(results), cfg.Output)
+}
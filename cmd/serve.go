@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/server"
+)
+
+// Define function to run the HTTP API server
+func cmdServe(args []string) {
+	flagServe := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flagServe.String("addr", ":8080", "listen address")
+	maxBody := flagServe.Int64("max-body-bytes", server.DefaultMaxBodyBytes, "maximum request body size")
+	shutdown := flagServe.Duration("shutdown-timeout", server.DefaultShutdownTimeout, "graceful shutdown timeout")
+
+	// Runner options are the defaults for requests that send none
+	configPath := addConfigFlags(flagServe)
+	_ = flagServe.Parse(args)
+	cfg := resolveConfig(flagServe, *configPath)
+
+	srv := server.New(server.Options{
+		Addr:            *addr,
+		MaxBodyBytes:    *maxBody,
+		ShutdownTimeout: *shutdown,
+		Runner:          cfg.RawRunnerOptions(),
+		Validation:      loadValidationOptions(cfg),
+	})
+
+	// Stop on Ctrl+C or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Listening on %s\n", *addr)
+	start := time.Now()
+	if err := srv.Run(ctx); err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	fmt.Printf("Server stopped after %s\n", time.Since(start).Round(time.Second))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/analysis"
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+)
+
+// Define function to handle summarize command
+func cmdSummarize(args []string) {
+	flagSum := flag.NewFlagSet("summarize", flag.ExitOnError)
+	flagSum.String("in", "", "results CSV from run or sweep")
+	flagSum.String("out", "", "output file (default stdout)")
+	format := flagSum.String("format", "", "csv, json or md (default from --out extension, md for stdout)")
+	worstN := flagSum.Int("worst-n", 10, "number of lowest-margin links to list")
+	worstOut := flagSum.String("worst-out", "", "also write the worst links to this CSV file")
+	flagSum.String("delimiter", "", "input delimiter: a character or tab, comma, semicolon, pipe (default ',')")
+	flagSum.Bool("decimal-comma", false, "input numbers use a decimal comma")
+	flagSum.String("out-delimiter", "", "output delimiter (default ';' for decimal-comma locales, ',' otherwise)")
+	flagSum.Int("precision", 6, "digits after the decimal point in output, -1 for shortest")
+	flagSum.String("locale", "", "output number format locale, e.g. id-ID or de (default decimal point)")
+	_ = flagSum.Parse(args)
+	cfg := resolveConfig(flagSum, "")
+
+	// Check if input is provided
+	if cfg.Input == "" {
+		fmt.Println("missing --in")
+		os.Exit(1)
+	}
+	results, rowErrs, err := foio.ReadResultsCSV(cfg.Input, csvReadOptions(cfg))
+	for _, e := range rowErrs {
+		fmt.Fprintln(os.Stderr, e.Error())
+	}
+	if err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	if len(results) == 0 {
+		fmt.Println("An error has occurred: ", "no results to summarize")
+		os.Exit(1)
+	}
+
+	summary := analysis.Summarize(results, *worstN)
+	if *format == "" {
+		*format = analysis.FormatFromPath(cfg.Output)
+	}
+	writeOpt := csvWriteOptions(cfg)
+
+	// Write to stdout unless an output file is given
+	var w io.Writer = os.Stdout
+	if cfg.Output != "" {
+		file, err := os.Create(cfg.Output)
+		if err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := analysis.Write(w, summary, *format, writeOpt); err != nil {
+		fmt.Println("An error has occurred: ", err.Error())
+		os.Exit(1)
+	}
+	if *worstOut != "" {
+		file, err := os.Create(*worstOut)
+		if err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+		defer file.Close()
+		if err := analysis.WriteWorstCSV(file, summary, writeOpt); err != nil {
+			fmt.Println("An error has occurred: ", err.Error())
+			os.Exit(1)
+		}
+	}
+	if cfg.Output != "" {
+		fmt.Printf("Summary of %d results written to %s\n", len(results), cfg.Output)
+	}
+}
@@ -0,0 +1,74 @@
+// Example of embedding the engine in a Go service.
+//
+//	go run ./examples/embed
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/pkg/engine"
+)
+
+func main() {
+	// Configure once, reuse for every request
+	eng, err := engine.New(
+		engine.WithRiseTimeBudget(2.5, 0.2, 0.2),
+		engine.WithProfile("gpon-b+"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	links := []engine.Link{
+		{
+			LinkID: "L01", Scenario: "base",
+			TXPowerDbm: 4, RXSensitivityDbm: -28, SystemMarginDb: 3,
+			FiberLengthKm: 5, FiberAttDbPerKm: 0.30,
+			NSplice: 2, SpliceLossDb: 0.10,
+			NConnectors: 2, ConnectorLossDb: 0.50,
+			SplitterLossDb: 13.5,
+		},
+		{
+			LinkID: "L02", Scenario: "base",
+			TXPowerDbm: 4, RXSensitivityDbm: -28, SystemMarginDb: 3,
+			FiberLengthKm: 24, FiberAttDbPerKm: 0.35,
+			NSplice: 8, SpliceLossDb: 0.10,
+			NConnectors: 4, ConnectorLossDb: 0.50,
+			SplitterLossDb: 17.2,
+		},
+	}
+
+	// Bound the work with a deadline like any other request
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results, issues, err := eng.Run(ctx, links)
+	if errors.Is(err, engine.ErrInvalidInput) {
+		for _, i := range issues {
+			fmt.Println(i.Error())
+		}
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, i := range issues {
+		fmt.Println(i.Error())
+	}
+
+	// Typed statuses, no string comparisons
+	for i, r := range results {
+		fmt.Printf("%s margin=%.2f dB lpb=%s rtb=%s compliance=%s %s\n",
+			r.LinkID, r.MarginDb, r.LPBStatus, r.RTBStatus, r.ComplianceStatus, r.ComplianceClause)
+		if r.LPBStatus == engine.StatusFail {
+			sol, err := eng.Solve(ctx, links[i], "fiber_length_km")
+			if err == nil {
+				fmt.Printf("  max fiber length for %s: %.2f km\n", r.LinkID, sol.Limit)
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define kinds of link changes
+const (
+	ChangeAdded     = "added"
+	ChangeRemoved   = "removed"
+	ChangeModified  = "changed"
+	ChangeUnchanged = "unchanged"
+)
+
+// Define struct for diff thresholds
+type DiffOptions struct {
+	ToleranceDb         float64 // Margin moves within ±ToleranceDb count as unchanged
+	MaxDropDb           float64 // Margin drops larger than this are regressions, 0 disables
+	RemovedIsRegression bool    // Treat links missing from the new run as regressions
+	LPBOnly             bool    // Compare the power budget status only, not the other checks
+}
+
+// Define struct for one link compared across two runs
+type Change struct {
+	LinkID         string  `json:"link_id"`
+	Scenario       string  `json:"scenario"`
+	Kind           string  `json:"kind"`
+	OldStatus      string  `json:"old_status,omitempty"`
+	NewStatus      string  `json:"new_status,omitempty"`
+	OldMarginDb    float64 `json:"old_margin_db"`
+	NewMarginDb    float64 `json:"new_margin_db"`
+	DeltaMarginDb  float64 `json:"delta_margin_db"`
+	OldContributor string  `json:"old_contributor,omitempty"`
+	NewContributor string  `json:"new_contributor,omitempty"`
+	// Checks besides the power budget that passed in the old run and fail in the new one
+	FailedChecks []string `json:"failed_checks,omitempty"`
+	Regression   bool     `json:"regression"`
+}
+
+// Define struct for the diff of two runs
+type Diff struct {
+	Changes     []Change `json:"changes"` // Unchanged links are left out
+	Unchanged   int      `json:"unchanged"`
+	Added       int      `json:"added"`
+	Removed     int      `json:"removed"`
+	PassToFail  int      `json:"pass_to_fail"`
+	FailToPass  int      `json:"fail_to_pass"`
+	CheckFails  int      `json:"check_fails"` // Links with a check other than the power budget turning to FAIL
+	Regressions int      `json:"regressions"`
+}
+
+// Define function to list the statuses of the checks besides the power
+// budget, empty for checks that were not evaluated
+func checkStatuses(r model.LinkOutput) [][2]string {
+	rtb := ""
+	if r.AllowedRiseTimeNs > 0 {
+		rtb = "FAIL"
+		if r.RTBStatus {
+			rtb = "PASS"
+		}
+	}
+	return [][2]string{
+		{"rtb", rtb},
+		{"compliance", r.ComplianceStatus},
+		{"osnr", r.OSNRStatus},
+		{"ber", r.BERStatus},
+		{"post_fec", r.PostFECStatus},
+		{"pmd", r.PMDStatus},
+		{"orl", r.ReflectionStatus},
+		{"nonlinear", r.NonlinearStatus},
+		{"phy", r.PHYStatus},
+	}
+}
+
+// Define function to name the checks that passed before and fail now; checks
+// that became N/A or were not evaluated are not counted
+func failedChecks(old, new model.LinkOutput) (failed []string, changed bool) {
+	before, after := checkStatuses(old), checkStatuses(new)
+	for i := range before {
+		o, n := before[i][1], after[i][1]
+		if o != n {
+			changed = true
+		}
+		if o == "PASS" && n != "PASS" && n != "" && n != "N/A" {
+			failed = append(failed, before[i][0])
+		}
+	}
+	return failed, changed
+}
+
+// Define function to compare two result sets joined on link_id and scenario.
+// The power budget status and, unless LPBOnly is set, the status of every other
+// check are compared. Changes are ordered PASS to FAIL flips first, then other
+// regressions, then by margin delta (largest drop first).
+func Compare(old, new []model.LinkOutput, opt DiffOptions) Diff {
+	type key struct{ id, scenario string }
+	before := make(map[key]model.LinkOutput, len(old))
+	for _, r := range old {
+		before[key{r.LinkID, r.Scenario}] = r
+	}
+
+	var d Diff
+	seen := make(map[key]bool, len(new))
+	for _, n := range new {
+		k := key{n.LinkID, n.Scenario}
+		seen[k] = true
+		o, ok := before[k]
+		if !ok {
+			d.Added++
+			d.Changes = append(d.Changes, Change{
+				LinkID: n.LinkID, Scenario: n.Scenario, Kind: ChangeAdded,
+				NewStatus: n.LPBStatus, NewMarginDb: n.MarginDb, NewContributor: n.TopContributor1,
+			})
+			continue
+		}
+
+		c := Change{
+			LinkID: n.LinkID, Scenario: n.Scenario, Kind: ChangeModified,
+			OldStatus: o.LPBStatus, NewStatus: n.LPBStatus,
+			OldMarginDb: o.MarginDb, NewMarginDb: n.MarginDb, DeltaMarginDb: n.MarginDb - o.MarginDb,
+			OldContributor: o.TopContributor1, NewContributor: n.TopContributor1,
+		}
+		statusChanged := c.OldStatus != c.NewStatus
+		if !opt.LPBOnly {
+			failed, changed := failedChecks(o, n)
+			c.FailedChecks = failed
+			statusChanged = statusChanged || changed
+		}
+		marginMoved := math.Abs(c.DeltaMarginDb) > opt.ToleranceDb
+		if !statusChanged && !marginMoved && c.OldContributor == c.NewContributor {
+			d.Unchanged++
+			continue
+		}
+		switch {
+		case c.OldStatus == "PASS" && c.NewStatus != "PASS":
+			d.PassToFail++
+			c.Regression = true
+		case c.OldStatus != "PASS" && c.NewStatus == "PASS":
+			d.FailToPass++
+		}
+		if len(c.FailedChecks) > 0 {
+			d.CheckFails++
+			c.Regression = true
+		}
+		if opt.MaxDropDb > 0 && -c.DeltaMarginDb > opt.MaxDropDb {
+			c.Regression = true
+		}
+		d.Changes = append(d.Changes, c)
+	}
+	for _, o := range old {
+		if seen[key{o.LinkID, o.Scenario}] {
+			continue
+		}
+		d.Removed++
+		d.Changes = append(d.Changes, Change{
+			LinkID: o.LinkID, Scenario: o.Scenario, Kind: ChangeRemoved,
+			OldStatus: o.LPBStatus, OldMarginDb: o.MarginDb, OldContributor: o.TopContributor1,
+			Regression: opt.RemovedIsRegression,
+		})
+	}
+
+	for _, c := range d.Changes {
+		if c.Regression {
+			d.Regressions++
+		}
+	}
+	rank := func(c Change) int {
+		switch {
+		case c.Regression && c.OldStatus == "PASS" && c.NewStatus != "" && c.NewStatus != "PASS":
+			return 0
+		case c.Regression:
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(d.Changes, func(i, j int) bool {
+		a, b := d.Changes[i], d.Changes[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		return a.DeltaMarginDb < b.DeltaMarginDb
+	})
+	return d
+}
+
+// Define function to keep one scenario of a result set and relabel it, so two
+// scenarios can be compared on link_id alone
+func SelectScenario(results []model.LinkOutput, scenario, label string) []model.LinkOutput {
+	var out []model.LinkOutput
+	for _, r := range results {
+		if r.Scenario == scenario {
+			r.Scenario = label
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+)
+
+// Define header of the change table
+var diffHeaders = []string{
+	"link_id", "scenario", "kind", "old_status", "new_status",
+	"old_margin_db", "new_margin_db", "delta_margin_db", "old_contributor", "new_contributor", "failed_checks", "regression",
+}
+
+// Define function to format one change as table cells. Margins of added or
+// removed links are left empty on the missing side.
+func diffRow(c Change, formatFloat func(float64) string) []string {
+	oldMargin, newMargin, delta := formatFloat(c.OldMarginDb), formatFloat(c.NewMarginDb), formatFloat(c.DeltaMarginDb)
+	switch c.Kind {
+	case ChangeAdded:
+		oldMargin, delta = "", ""
+	case ChangeRemoved:
+		newMargin, delta = "", ""
+	}
+	return []string{
+		c.LinkID, c.Scenario, c.Kind, c.OldStatus, c.NewStatus,
+		oldMargin, newMargin, delta, c.OldContributor, c.NewContributor, strings.Join(c.FailedChecks, "+"), strconv.FormatBool(c.Regression),
+	}
+}
+
+// Define function to write a diff in the given format
+func WriteDiff(w io.Writer, d Diff, format string, opt foio.CSVWriteOptions) error {
+	formatFloat := foio.NumberFormatter(opt)
+	switch format {
+	case FormatCSV:
+		write := csv.NewWriter(w)
+		if opt.Delimiter != 0 {
+			write.Comma = opt.Delimiter
+		}
+		if err := write.Write(diffHeaders); err != nil {
+			return errors.New("An error has occurred while writing CSV headers: " + err.Error())
+		}
+		for _, c := range d.Changes {
+			if err := write.Write(diffRow(c, formatFloat)); err != nil {
+				return errors.New("An error has occurred while writing CSV row: " + err.Error())
+			}
+		}
+		write.Flush()
+		return write.Error()
+	case FormatJSON:
+		if d.Changes == nil {
+			d.Changes = []Change{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	case FormatMarkdown, "markdown":
+		var b strings.Builder
+		b.WriteString("## Diff\n\n")
+		fmt.Fprintf(&b, "- PASS → FAIL: %d\n- FAIL → PASS: %d\n- Other checks to FAIL: %d\n- Added: %d\n- Removed: %d\n- Unchanged: %d\n- Regressions: %d\n",
+			d.PassToFail, d.FailToPass, d.CheckFails, d.Added, d.Removed, d.Unchanged, d.Regressions)
+		if len(d.Changes) > 0 {
+			b.WriteString("\n")
+			writeTable(&b, diffHeaders, len(d.Changes), func(i int) []string { return diffRow(d.Changes[i], formatFloat) })
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	return errors.New("Unsupported diff format: " + format + " (use csv, json or md)")
+}
@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+)
+
+// Define supported summary output formats
+const (
+	FormatCSV      = "csv"
+	FormatJSON     = "json"
+	FormatMarkdown = "md"
+)
+
+// Define function to pick a format from an output file name, markdown for stdout
+func FormatFromPath(path string) string {
+	switch {
+	case strings.HasSuffix(strings.ToLower(path), ".csv"):
+		return FormatCSV
+	case strings.HasSuffix(strings.ToLower(path), ".json"):
+		return FormatJSON
+	}
+	return FormatMarkdown
+}
+
+// Define function to write a summary in the given format. CSV carries the
+// per-scenario table only; use WriteWorstCSV for the worst links.
+func Write(w io.Writer, s Summary, format string, opt foio.CSVWriteOptions) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, s, opt)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	case FormatMarkdown, "markdown":
+		return writeMarkdown(w, s, opt)
+	}
+	return errors.New("Unsupported summary format: " + format + " (use csv, json or md)")
+}
+
+// Define header of the per-scenario table
+var summaryHeaders = []string{
+	"scenario", "n_links", "pass_rate", "fail_rate",
+	"margin_mean_db", "margin_median_db", "margin_p05_db", "margin_p95_db", "margin_min_db", "margin_max_db",
+	"top_contributor", "top_contributor_links", "n_unbounded",
+}
+
+// Define function to format one scenario as table cells
+func summaryRow(s ScenarioSummary, formatFloat func(float64) string) []string {
+	// Unset margin statistics are left as empty cells
+	formatStat := func(x *float64) string {
+		if x == nil {
+			return ""
+		}
+		return formatFloat(*x)
+	}
+	return []string{
+		s.Scenario, strconv.Itoa(s.NLinks), formatFloat(s.PassRate), formatFloat(s.FailRate),
+		formatStat(s.MarginMeanDb), formatStat(s.MarginMedianDb), formatStat(s.MarginP05Db),
+		formatStat(s.MarginP95Db), formatStat(s.MarginMinDb), formatStat(s.MarginMaxDb),
+		s.TopContributor, strconv.Itoa(s.TopContributorLinks), strconv.Itoa(s.NUnbounded),
+	}
+}
+
+// Define function to write the per-scenario table as CSV
+func writeCSV(w io.Writer, s Summary, opt foio.CSVWriteOptions) error {
+	write := csv.NewWriter(w)
+	if opt.Delimiter != 0 {
+		write.Comma = opt.Delimiter
+	}
+	if err := write.Write(summaryHeaders); err != nil {
+		return errors.New("An error has occurred while writing CSV headers: " + err.Error())
+	}
+	formatFloat := foio.NumberFormatter(opt)
+	for _, sc := range s.Scenarios {
+		if err := write.Write(summaryRow(sc, formatFloat)); err != nil {
+			return errors.New("An error has occurred while writing CSV row: " + err.Error())
+		}
+	}
+	write.Flush()
+	return write.Error()
+}
+
+// Define function to write the worst links as CSV
+func WriteWorstCSV(w io.Writer, s Summary, opt foio.CSVWriteOptions) error {
+	write := csv.NewWriter(w)
+	if opt.Delimiter != 0 {
+		write.Comma = opt.Delimiter
+	}
+	if err := write.Write([]string{"link_id", "scenario", "margin_db", "lpb_status"}); err != nil {
+		return errors.New("An error has occurred while writing CSV headers: " + err.Error())
+	}
+	formatFloat := foio.NumberFormatter(opt)
+	for _, r := range s.Worst {
+		if err := write.Write([]string{r.LinkID, r.Scenario, formatFloat(r.MarginDb), r.LPBStatus}); err != nil {
+			return errors.New("An error has occurred while writing CSV row: " + err.Error())
+		}
+	}
+	write.Flush()
+	return write.Error()
+}
+
+// Define function to write the summary as markdown tables
+func writeMarkdown(w io.Writer, s Summary, opt foio.CSVWriteOptions) error {
+	formatFloat := foio.NumberFormatter(opt)
+	var b strings.Builder
+	b.WriteString("## Summary by scenario\n\n")
+	writeTable(&b, summaryHeaders, len(s.Scenarios), func(i int) []string { return summaryRow(s.Scenarios[i], formatFloat) })
+	if len(s.Worst) > 0 {
+		fmt.Fprintf(&b, "\n## Worst %d links\n\n", len(s.Worst))
+		writeTable(&b, []string{"link_id", "scenario", "margin_db", "lpb_status", "top_contributor_1"}, len(s.Worst), func(i int) []string {
+			r := s.Worst[i]
+			return []string{r.LinkID, r.Scenario, formatFloat(r.MarginDb), r.LPBStatus, r.TopContributor1}
+		})
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Define helper to write one markdown table
+func writeTable(b *strings.Builder, headers []string, n int, row func(int) []string) {
+	b.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat("---|", len(headers)) + "\n")
+	for i := 0; i < n; i++ {
+		cells := row(i)
+		for j, c := range cells {
+			cells[j] = strings.ReplaceAll(c, "|", `\|`)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+}
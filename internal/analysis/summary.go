@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define struct for the statistics of one scenario
+type ScenarioSummary struct {
+	Scenario            string   `json:"scenario"`
+	NLinks              int      `json:"n_links"`
+	PassRate            float64  `json:"pass_rate"`
+	FailRate            float64  `json:"fail_rate"`
+	MarginMeanDb        *float64 `json:"margin_mean_db,omitempty"`
+	MarginMedianDb      *float64 `json:"margin_median_db,omitempty"`
+	MarginP05Db         *float64 `json:"margin_p05_db,omitempty"`
+	MarginP95Db         *float64 `json:"margin_p95_db,omitempty"`
+	MarginMinDb         *float64 `json:"margin_min_db,omitempty"`
+	MarginMaxDb         *float64 `json:"margin_max_db,omitempty"`
+	TopContributor      string   `json:"top_contributor"`
+	TopContributorLinks int      `json:"top_contributor_links"`
+	NUnbounded          int      `json:"n_unbounded"` // Links with an unbounded penalty, left out of the margin statistics
+}
+
+// Define struct for the full summary of a results file
+type Summary struct {
+	Scenarios []ScenarioSummary  `json:"scenarios"`
+	Worst     []model.LinkOutput `json:"worst"`
+}
+
+// Define function to summarize results per scenario, sorted by fail rate
+// (highest first) then mean margin (lowest first), with the worst links overall
+func Summarize(results []model.LinkOutput, worstN int) Summary {
+	// Group results in first-seen scenario order
+	var order []string
+	groups := make(map[string][]model.LinkOutput)
+	for _, r := range results {
+		if _, ok := groups[r.Scenario]; !ok {
+			order = append(order, r.Scenario)
+		}
+		groups[r.Scenario] = append(groups[r.Scenario], r)
+	}
+
+	scenarios := make([]ScenarioSummary, 0, len(order))
+	for _, name := range order {
+		scenarios = append(scenarios, summarizeScenario(name, groups[name]))
+	}
+	sort.SliceStable(scenarios, func(i, j int) bool {
+		if scenarios[i].FailRate != scenarios[j].FailRate {
+			return scenarios[i].FailRate > scenarios[j].FailRate
+		}
+		// Scenarios without margin statistics sort last
+		mi, mj := scenarios[i].MarginMeanDb, scenarios[j].MarginMeanDb
+		if mi == nil || mj == nil {
+			return mi != nil && mj == nil
+		}
+		return *mi < *mj
+	})
+	return Summary{Scenarios: scenarios, Worst: Worst(results, worstN)}
+}
+
+// Define function to compute the statistics of one scenario
+func summarizeScenario(name string, results []model.LinkOutput) ScenarioSummary {
+	s := ScenarioSummary{Scenario: name, NLinks: len(results)}
+	margins := make([]float64, 0, len(results))
+	pass := 0
+	sum := 0.0
+	counts := make(map[string]int)
+	for _, r := range results {
+		if r.LPBStatus == "PASS" {
+			pass++
+		}
+		if r.TopContributor1 != "" {
+			counts[r.TopContributor1]++
+		}
+		// Unbounded links have no margin to average
+		if r.Unbounded() {
+			s.NUnbounded++
+			continue
+		}
+		margins = append(margins, r.MarginDb)
+		sum += r.MarginDb
+	}
+	sort.Float64s(margins)
+	s.PassRate = float64(pass) / float64(len(results))
+	s.FailRate = float64(len(results)-pass) / float64(len(results))
+	// Margin statistics stay unset when every link is unbounded
+	if len(margins) > 0 {
+		s.MarginMeanDb = float64Ptr(sum / float64(len(margins)))
+		s.MarginMedianDb = float64Ptr(Quantile(margins, 0.5))
+		s.MarginP05Db = float64Ptr(Quantile(margins, 0.05))
+		s.MarginP95Db = float64Ptr(Quantile(margins, 0.95))
+		s.MarginMinDb = float64Ptr(Quantile(margins, 0))
+		s.MarginMaxDb = float64Ptr(Quantile(margins, 1))
+	}
+
+	// Most common first contributor, ties broken by name
+	for c, n := range counts {
+		if n > s.TopContributorLinks || (n == s.TopContributorLinks && c < s.TopContributor) {
+			s.TopContributor, s.TopContributorLinks = c, n
+		}
+	}
+	return s
+}
+
+// Define helper to take the address of a statistic
+func float64Ptr(x float64) *float64 {
+	return &x
+}
+
+// Define function to return the q-quantile of sorted values using linear
+// interpolation between closest ranks (the pandas default)
+func Quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+// Define function to return the n links with the lowest margin, unbounded
+// links first
+func Worst(results []model.LinkOutput, n int) []model.LinkOutput {
+	if n <= 0 {
+		return []model.LinkOutput{}
+	}
+	worst := append([]model.LinkOutput(nil), results...)
+	sort.SliceStable(worst, func(i, j int) bool {
+		if worst[i].Unbounded() != worst[j].Unbounded() {
+			return worst[i].Unbounded()
+		}
+		return worst[i].MarginDb < worst[j].MarginDb
+	})
+	if len(worst) > n {
+		worst = worst[:n]
+	}
+	return worst
+}
@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+func TestSummarizeAllUnboundedLeavesMarginsUnset(t *testing.T) {
+	unbounded := []string{"dispersion_penalty_db"}
+	results := []model.LinkOutput{
+		{LinkID: "A", Scenario: "long", LPBStatus: "UNBOUNDED", UnboundedPenalties: unbounded},
+		{LinkID: "B", Scenario: "long", LPBStatus: "UNBOUNDED", UnboundedPenalties: unbounded},
+		{LinkID: "C", Scenario: "short", LPBStatus: "PASS", MarginDb: 4},
+		{LinkID: "D", Scenario: "short", LPBStatus: "FAIL", MarginDb: -2},
+	}
+	s := Summarize(results, 0)
+
+	// Fail rates tie at 1.0 and 0.5, so long sorts first
+	long, short := s.Scenarios[0], s.Scenarios[1]
+	if long.Scenario != "long" || long.NUnbounded != 2 || long.MarginMeanDb != nil || long.MarginMinDb != nil {
+		t.Errorf("long: %+v; want two unbounded links and no margin statistics", long)
+	}
+	if short.MarginMeanDb == nil || *short.MarginMeanDb != 1 || *short.MarginMinDb != -2 || *short.MarginMaxDb != 4 {
+		t.Errorf("short: %+v; want mean 1, min -2, max 4", short)
+	}
+
+	for _, format := range []string{FormatJSON, FormatCSV, FormatMarkdown} {
+		var b bytes.Buffer
+		if err := Write(&b, s, format, foio.CSVWriteOptions{}); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if strings.Contains(b.String(), "NaN") {
+			t.Errorf("%s output carries NaN:\n%s", format, b.String())
+		}
+	}
+
+	var b bytes.Buffer
+	if err := Write(&b, s, FormatJSON, foio.CSVWriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Scenarios []map[string]any `json:"scenarios"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded.Scenarios[0]["margin_mean_db"]; ok {
+		t.Errorf("long scenario JSON carries margin_mean_db: %v", decoded.Scenarios[0])
+	}
+}
+
+func TestSummarizeSortsScenariosWithoutMarginsLast(t *testing.T) {
+	results := []model.LinkOutput{
+		{LinkID: "A", Scenario: "open", LPBStatus: "UNBOUNDED", UnboundedPenalties: []string{"dispersion_penalty_db"}},
+		{LinkID: "B", Scenario: "tight", LPBStatus: "FAIL", MarginDb: -1},
+		{LinkID: "C", Scenario: "loose", LPBStatus: "FAIL", MarginDb: -5},
+	}
+	var got []string
+	for _, sc := range Summarize(results, 0).Scenarios {
+		got = append(got, sc.Scenario)
+	}
+	if strings.Join(got, ",") != "loose,tight,open" {
+		t.Errorf("order %v; want loose, tight, open", got)
+	}
+}
@@ -0,0 +1,73 @@
+package cable
+
+import (
+	"sort"
+	"strings"
+)
+
+// Define struct for the lifetime margin allowances of a cable type
+type Allowances struct {
+	RepairSplicesPerKm float64 `yaml:"repair_splices_per_km" json:"repair_splices_per_km"` // Expected repair splices over the design life
+	TempDriftDbPerKm   float64 `yaml:"temp_drift_db_per_km" json:"temp_drift_db_per_km"`   // Attenuation increase at the temperature extremes
+	AgingDb            float64 `yaml:"aging_db" json:"aging_db"`                           // Transmitter and component aging
+	CableCutDb         float64 `yaml:"cable_cut_db" json:"cable_cut_db"`                   // Emergency restoration of a cut
+}
+
+// Define the built-in cable types. Aerial cable sees the widest temperature
+// swing and the most repairs; indoor cable is neither repaired nor cut in
+// practice and only carries the component aging allowance.
+var catalog = map[string]Allowances{
+	"duct":          {RepairSplicesPerKm: 0.1, TempDriftDbPerKm: 0.005, AgingDb: 0.5, CableCutDb: 0.5},
+	"direct-buried": {RepairSplicesPerKm: 0.2, TempDriftDbPerKm: 0.005, AgingDb: 0.5, CableCutDb: 1.0},
+	"aerial":        {RepairSplicesPerKm: 0.3, TempDriftDbPerKm: 0.02, AgingDb: 0.5, CableCutDb: 1.0},
+	"indoor":        {AgingDb: 0.5},
+}
+
+// Define aliases for common spellings
+var aliases = map[string]string{
+	"underground": "duct",
+	"buried":      "direct-buried",
+	"overhead":    "aerial",
+	"adss":        "aerial",
+	"riser":       "indoor",
+	"plenum":      "indoor",
+}
+
+// Define function to look up a cable type by name (case-insensitive). Types in
+// overrides, keyed by name, replace or extend the built-in catalog.
+func Lookup(name string, overrides map[string]Allowances) (Allowances, bool) {
+	key := normalize(name)
+	for n, a := range overrides {
+		if normalize(n) == key {
+			return a, true
+		}
+	}
+	if a, ok := aliases[key]; ok {
+		key = a
+	}
+	a, ok := catalog[key]
+	return a, ok
+}
+
+// Define function to list available cable type names, overrides included
+func Names(overrides map[string]Allowances) []string {
+	seen := make(map[string]bool, len(catalog)+len(overrides))
+	for n := range catalog {
+		seen[n] = true
+	}
+	for n := range overrides {
+		seen[normalize(n)] = true
+	}
+	names := make([]string, 0, len(seen))
+	for n := range seen {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Define helper to normalize a cable type name
+func normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer("_", "-", " ", "-").Replace(name)
+}
@@ -0,0 +1,55 @@
+package calc
+
+import (
+	"errors"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/cable"
+)
+
+// Define loss assumed per repair splice when the link has no splice loss
+const DefaultRepairSpliceLossDb = 0.1
+
+// Define struct for the named margin allowances in dB. They are reserved
+// next to the system margin, which then only holds the unitemised remainder.
+type Allowances struct {
+	RepairDb      float64
+	TemperatureDb float64
+	AgingDb       float64
+	CableCutDb    float64
+}
+
+// Define struct for allowance inputs
+type AllowanceInputs struct {
+	LinkLengthKm float64
+	SpliceLossDb float64 // Per splice, 0 uses DefaultRepairSpliceLossDb
+	Cable        cable.Allowances
+}
+
+// Define function to calculate the margin allowances of a link
+func CalculateAllowances(input AllowanceInputs) (Allowances, error) {
+	// Check if inputs are valid
+	c := input.Cable
+	if c.RepairSplicesPerKm < 0 || c.TempDriftDbPerKm < 0 || c.AgingDb < 0 || c.CableCutDb < 0 {
+		return Allowances{}, errors.New("Margin allowances have to be zero or greater")
+	}
+	if input.LinkLengthKm < 0 {
+		return Allowances{}, errors.New("Link length does not have valid value")
+	}
+	spliceLoss := input.SpliceLossDb
+	if spliceLoss <= 0 {
+		spliceLoss = DefaultRepairSpliceLossDb
+	}
+
+	// Repairs and temperature drift grow with length, aging and cuts do not
+	return Allowances{
+		RepairDb:      c.RepairSplicesPerKm * input.LinkLengthKm * spliceLoss,
+		TemperatureDb: c.TempDriftDbPerKm * input.LinkLengthKm,
+		AgingDb:       c.AgingDb,
+		CableCutDb:    c.CableCutDb,
+	}, nil
+}
+
+// Define method to sum the allowances
+func (a Allowances) TotalDb() float64 {
+	return a.RepairDb + a.TemperatureDb + a.AgingDb + a.CableCutDb
+}
@@ -0,0 +1,50 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/cable"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+func TestComputeAllowances(t *testing.T) {
+	// 20 km: repair = splices/km · 20 · splice loss, temperature = drift · 20
+	custom := map[string]cable.Allowances{"aerial": {RepairSplicesPerKm: 0.4, TempDriftDbPerKm: 0.03, AgingDb: 0.5, CableCutDb: 1}}
+	tests := []struct {
+		name    string
+		link    model.LinkInput
+		cables  map[string]cable.Allowances
+		want    Allowances
+		totalDb float64
+	}{
+		{"duct", model.LinkInput{CableType: "duct"}, nil, Allowances{0.2, 0.1, 0.5, 0.5}, 1.3},
+		{"direct-buried", model.LinkInput{CableType: "direct-buried"}, nil, Allowances{0.4, 0.1, 0.5, 1}, 2},
+		{"aerial", model.LinkInput{CableType: "aerial"}, nil, Allowances{0.6, 0.4, 0.5, 1}, 2.5},
+		{"indoor", model.LinkInput{CableType: "indoor"}, nil, Allowances{0, 0, 0.5, 0}, 0.5},
+		// Repairs use the link's own splice loss: 0.3 · 20 · 0.05
+		{"aerial with 0.05 dB splices", model.LinkInput{CableType: "ADSS", SpliceLossDb: 0.05}, nil, Allowances{0.3, 0.4, 0.5, 1}, 2.2},
+		{"per-link aging", model.LinkInput{CableType: "duct", AgingMarginDb: 1}, nil, Allowances{0.2, 0.1, 1, 0.5}, 1.8},
+		{"no cable type", model.LinkInput{TempDriftDbPerKm: 0.01}, nil, Allowances{0, 0.2, 0, 0}, 0.2},
+		{"none asked for", model.LinkInput{}, nil, Allowances{}, 0},
+		// 0.4 · 20 · 0.1 + 0.03 · 20 + 0.5 + 1
+		{"configured aerial", model.LinkInput{CableType: "aerial"}, custom, Allowances{0.8, 0.6, 0.5, 1}, 2.9},
+	}
+	for _, tt := range tests {
+		link := tt.link
+		link.FiberLengthKm = 20
+		got, err := computeAllowances(link, RunnerOptions{Cables: tt.cables})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !near(got.RepairDb, tt.want.RepairDb, 1e-9) || !near(got.TemperatureDb, tt.want.TemperatureDb, 1e-9) ||
+			got.AgingDb != tt.want.AgingDb || got.CableCutDb != tt.want.CableCutDb || !near(got.TotalDb(), tt.totalDb, 1e-9) {
+			t.Errorf("%s: %+v, total %.2f dB; want %+v, %.2f", tt.name, got, got.TotalDb(), tt.want, tt.totalDb)
+		}
+	}
+	if _, err := computeAllowances(model.LinkInput{CableType: "submarine"}, RunnerOptions{}); err == nil {
+		t.Error("unknown cable type accepted")
+	}
+	if _, err := CalculateAllowances(AllowanceInputs{Cable: cable.Allowances{AgingDb: -1}}); err == nil {
+		t.Error("negative aging accepted")
+	}
+}
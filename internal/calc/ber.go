@@ -0,0 +1,98 @@
+package calc
+
+import (
+	"errors"
+	"math"
+)
+
+// Define BER defaults
+const (
+	DefaultQSlope    = 1.0   // Thermal-noise limited receiver, Q grows linearly with power
+	DefaultTargetBER = 1e-12 // Post-FEC BER target
+)
+
+// Define struct for BER inputs. The receiver is described by its sensitivity
+// at a reference BER; Q scales as (P_rx/P_ref)^QSlope, so 1 models a thermal
+// noise limited receiver and 0.5 a shot noise limited one.
+type BERInputs struct {
+	RxPowerDbm        float64
+	RefSensitivityDbm float64
+	RefBER            float64
+	QSlope            float64
+	FEC               string
+	TargetBER         float64
+}
+
+// Define struct for BER outputs
+type BERResults struct {
+	Q             float64
+	QDb           float64 // 20·log10(Q)
+	BER           float64 // Pre-FEC
+	Status        string  // Pre-FEC BER against the FEC threshold, or the target without FEC
+	PostFECBER    float64
+	PostFECStatus string
+}
+
+// Define function to estimate Q-factor and BER at the received power
+func CalculateBER(input BERInputs) (BERResults, error) {
+	// Check if inputs are valid
+	if input.RefBER <= 0 || input.RefBER >= 0.5 {
+		return BERResults{}, errors.New("Reference BER must be between 0 and 0.5")
+	}
+	code, ok := LookupFEC(input.FEC)
+	if !ok {
+		return BERResults{}, errors.New("Unknown FEC code: " + input.FEC)
+	}
+	slope := input.QSlope
+	if slope <= 0 {
+		slope = DefaultQSlope
+	}
+	target := input.TargetBER
+	if target <= 0 {
+		target = DefaultTargetBER
+	}
+
+	// Q at the reference point: BER = ½·erfc(Q/√2)
+	qRef := qFromBER(input.RefBER)
+	q := qRef * math.Pow(10, slope*(input.RxPowerDbm-input.RefSensitivityDbm)/10)
+	ber := berFromQ(q)
+
+	// Pre-FEC BER against what the decoder can correct, or the target without FEC
+	limit := target
+	if code.Name != "none" {
+		limit = code.ThresholdBER
+	}
+	res := BERResults{Q: q, QDb: 20 * math.Log10(q), BER: ber, Status: passFail(ber <= limit)}
+
+	// Post-FEC: analytic for Reed-Solomon; threshold codes correct to the
+	// target below their input threshold and leave the BER unchanged above it
+	switch {
+	case code.Name == "none":
+		res.PostFECBER = ber
+		res.PostFECStatus = res.Status
+	default:
+		if post, ok := code.PostFECBER(ber); ok {
+			res.PostFECBER = post
+			res.PostFECStatus = passFail(post <= target)
+		} else if ber <= code.ThresholdBER {
+			res.PostFECBER = math.Min(ber, target)
+			res.PostFECStatus = "PASS"
+		} else {
+			res.PostFECBER = ber
+			res.PostFECStatus = "FAIL"
+		}
+	}
+	return res, nil
+}
+
+// Define functions to convert between Q-factor and BER
+func qFromBER(ber float64) float64 { return math.Sqrt2 * math.Erfcinv(2*ber) }
+func berFromQ(q float64) float64   { return 0.5 * math.Erfc(q/math.Sqrt2) }
+
+// Define helper to render a status
+func passFail(pass bool) string {
+	if pass {
+		return "PASS"
+	}
+	return "FAIL"
+}
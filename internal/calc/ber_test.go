@@ -0,0 +1,86 @@
+package calc
+
+import (
+	"math"
+	"testing"
+)
+
+// Define helper to compare small probabilities to a relative tolerance
+func nearRel(got, want, rel float64) bool {
+	return math.Abs(got-want) <= rel*math.Abs(want)
+}
+
+func TestCalculateBER(t *testing.T) {
+	// BER 1e-12 is Q 7.0345; each dB of power scales Q by 10^(slope/10)
+	tests := []struct {
+		name       string
+		rxDb       float64 // Received power relative to the reference sensitivity
+		refBER     float64
+		slope      float64
+		fec        string
+		q          float64
+		ber        float64
+		status     string
+		post       float64
+		postStatus string
+	}{
+		{"at reference", 0, 1e-12, 0, "none", 7.03448, 1e-12, "PASS", 1e-12, "PASS"},
+		{"1 dB above", 1, 1e-12, 0, "none", 8.85589, 4.15092e-19, "PASS", 4.15092e-19, "PASS"},
+		{"2 dB below", -2, 1e-12, 0, "none", 4.43846, 4.53026e-6, "FAIL", 4.53026e-6, "FAIL"},
+		{"shot noise limited", -3, 1e-12, 0.5, "none", 4.98003, 3.17867e-7, "FAIL", 3.17867e-7, "FAIL"},
+		// Below the RS(255,239) threshold of 1.8e-4
+		{"rs corrects", 0, 1e-4, 0, "rs-255-239", 3.71902, 1e-4, "PASS", 2.16779e-14, "PASS"},
+		// Above it: the pre-FEC check fails and the decoder leaves 4.5e-6
+		{"rs overwhelmed", 0, 1e-3, 0, "rs-255-239", 3.09023, 1e-3, "FAIL", 4.45651e-6, "FAIL"},
+		// Threshold codes correct to the target below their threshold
+		{"hd-fec corrects", 0, 1e-3, 0, "hd-fec", 3.09023, 1e-3, "PASS", 1e-12, "PASS"},
+		{"ldpc overwhelmed", 0, 3e-2, 0, "ldpc", 1.88079, 3e-2, "FAIL", 3e-2, "FAIL"},
+	}
+	for _, tt := range tests {
+		res, err := CalculateBER(BERInputs{RxPowerDbm: -20 + tt.rxDb, RefSensitivityDbm: -20, RefBER: tt.refBER, QSlope: tt.slope, FEC: tt.fec})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		// math.Erfcinv is good to a few parts in 1e5 this far into the tail
+		if !near(res.Q, tt.q, 1e-4) || !near(res.QDb, 20*math.Log10(tt.q), 1e-4) || !nearRel(res.BER, tt.ber, 1e-4) || res.Status != tt.status {
+			t.Errorf("%s: Q %.5f, BER %.5g %s; want %.5f, %.5g %s", tt.name, res.Q, res.BER, res.Status, tt.q, tt.ber, tt.status)
+		}
+		if !nearRel(res.PostFECBER, tt.post, 1e-4) || res.PostFECStatus != tt.postStatus {
+			t.Errorf("%s: post-FEC BER %.5g %s; want %.5g %s", tt.name, res.PostFECBER, res.PostFECStatus, tt.post, tt.postStatus)
+		}
+	}
+}
+
+func TestCalculateBERRejectsBadInputs(t *testing.T) {
+	for _, in := range []BERInputs{{RefBER: 0}, {RefBER: 0.5}, {RefBER: 1e-12, FEC: "turbo"}} {
+		if _, err := CalculateBER(in); err == nil {
+			t.Errorf("%+v accepted", in)
+		}
+	}
+}
+
+func TestReedSolomonPostFECBER(t *testing.T) {
+	// Σ_{i>t} (i/n)·C(n,i)·p_s^i·(1-p_s)^(n-i) · 2^(m-1)/(2^m-1), p_s = 1-(1-BER)^m
+	tests := []struct {
+		code string
+		ber  float64
+		post float64
+	}{
+		{"rs-255-239", 1e-4, 2.16779e-14},
+		{"rs-255-239", 1.8e-4, 3.73067e-12},
+		{"rs-255-239", 1e-3, 4.45651e-6},
+		{"rs-248-216", 1e-3, 1.05005e-12},
+		{"rs-544-514", 1e-4, 2.00567e-20},
+		{"rs-544-514", 2.4e-4, 1.20592e-14},
+	}
+	for _, tt := range tests {
+		code, _ := LookupFEC(tt.code)
+		post, ok := code.PostFECBER(tt.ber)
+		if !ok || !nearRel(post, tt.post, 1e-5) {
+			t.Errorf("%s at %g: post-FEC BER %.5g (%v); want %.5g", tt.code, tt.ber, post, ok, tt.post)
+		}
+	}
+	if _, ok := fecCodes["hd-fec"].PostFECBER(1e-3); ok {
+		t.Error("hd-fec has an analytic post-FEC BER")
+	}
+}
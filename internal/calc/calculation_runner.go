@@ -1,27 +1,92 @@
 package calc
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"sort"
 
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/cable"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/fiber"
 	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/phy"
 )
 
 // Define struct for runner options
 type RunnerOptions struct {
-	EnableRTB bool
+	EnableRTB bool `json:"enable_rtb"`
 	// RTB defaults
-	BitrateGbps      float64
-	TxRiseTimeNs     float64
-	RxRiseTimeNs     float64
-	DispersionPerKm  float64
+	BitrateGbps      float64 `json:"bitrate_gbps"`
+	TxRiseTimeNs     float64 `json:"tx_rise_time_ns"`
+	RxRiseTimeNs     float64 `json:"rx_rise_time_ns"`
+	DispersionPerKm  float64 `json:"dispersion_ns_per_km"`
+	// BER defaults for links that leave fec or target_ber empty
+	FEC       string  `json:"fec,omitempty"`
+	TargetBER float64 `json:"target_ber,omitempty"`
+	// PMD limit as a share of the bit period (0 uses 0.1)
+	PMDBitFraction float64 `json:"pmd_bit_fraction,omitempty"`
+	// Cable types added to or replacing the built-in margin allowances
+	Cables map[string]cable.Allowances `json:"cables,omitempty"`
+}
+
+// Define the bitrate the rise time budget uses when it is enabled without one
+const DefaultRTBBitrateGbps = 2.5
+
+// Define function to fill the defaults of enabled checks: RTB without a bitrate
+// runs at DefaultRTBBitrateGbps, which then also drives the penalties and PMD.
+// Without RTB an unset bitrate stays unset.
+func (o RunnerOptions) WithDefaults() RunnerOptions {
+	if o.EnableRTB && o.BitrateGbps == 0 {
+		o.BitrateGbps = DefaultRTBBitrateGbps
+	}
+	return o
 }
 // Define function to run calculations on link inputs
 func Compute(link model.LinkInput, opt RunnerOptions) (model.LinkOutput, error) {
+	// WDM links are evaluated channel by channel, coexistence links service by service
+	if len(link.Channels) > 0 {
+		return computeChannels(link, opt)
+	}
+	if len(link.Services) > 0 {
+		return computeServices(link, opt)
+	}
+
 	// Loss precompute and breakdown
 	fiberLossDb := link.FiberLengthKm * link.FiberAttDbPerKm
 	connTotalDb := float64(link.NConnectors) * link.ConnectorLossDb
 	spliceTotalDb := float64(link.NSplice) * link.SpliceLossDb
+	wdmLossDb := link.MuxLossDb + link.DemuxLossDb + float64(link.NOADM)*link.OADMLossDb
+
+	// Power penalties, computed from the link or supplied per link
+	penalties, err := computePenalties(link, opt)
+	if err != nil {
+		return model.LinkOutput{}, fmt.Errorf("An error has occurred: %v", err.Error())
+	}
+
+	// Nonlinear thresholds when the source linewidth is known; launch power
+	// above them adds to the penalties
+	var nonlinear NonlinearResults
+	if link.SourceLinewidthMHz > 0 {
+		nlIn, err := LinkNonlinear(link)
+		if err == nil {
+			nonlinear, err = CalculateNonlinear(nlIn)
+		}
+		if err != nil {
+			return model.LinkOutput{}, fmt.Errorf("An error has occurred: %v", err.Error())
+		}
+		penalties.SBSDb = nonlinear.SBSPenaltyDb
+		penalties.SPMDb = nonlinear.SPMPenaltyDb
+	}
+	penaltyDb := penalties.TotalDb()
+	unbounded := penalties.Unbounded()
+	penalties = penalties.Finite()
+
+	// Margin allowances of the cable type, reserved like the system margin
+	allowances, err := computeAllowances(link, opt)
+	if err != nil {
+		return model.LinkOutput{}, fmt.Errorf("An error has occurred: %v", err.Error())
+	}
+	reservedDb := link.SystemMarginDb + allowances.TotalDb()
 
 	// Call LPB calculation
 	lpbInput := LPBInputs{
@@ -30,10 +95,11 @@ func Compute(link model.LinkInput, opt RunnerOptions) (model.LinkOutput, error)
 		FiberAttDbPerKm: link.FiberAttDbPerKm,
 		ConnLossDb: connTotalDb,
 		SpliceLossDb: spliceTotalDb,
-		SystemMarginDb: link.SystemMarginDb,
+		SystemMarginDb: reservedDb,
 		LinkLengthKm: link.FiberLengthKm,
-		OtherLossDb: link.OtherLossDb,
+		OtherLossDb: link.OtherLossDb + wdmLossDb,
 		SplitterLossDb: link.SplitterLossDb,
+		PenaltyDb: penaltyDb,
 	}
 	lpbOutput, err := CalculateLPB(lpbInput)
 	if err != nil {
@@ -51,6 +117,90 @@ func Compute(link model.LinkInput, opt RunnerOptions) (model.LinkOutput, error)
 		FiberLossDb: fiberLossDb,
 		ConnectorTotalDb: connTotalDb,
 		SpliceTotalDb: spliceTotalDb,
+		WDMLossDb: wdmLossDb,
+		DispersionPenaltyDb: penalties.DispersionDb,
+		ExtinctionPenaltyDb: penalties.ExtinctionRatioDb,
+		RINPenaltyDb: penalties.RINDb,
+		MPNPenaltyDb: penalties.MPNDb,
+		ReflectionPenaltyDb: penalties.ReflectionDb,
+		ChirpPenaltyDb: penalties.ChirpDb,
+		PenaltyTotalDb: penaltyDb,
+		EffectiveLengthKm: nonlinear.EffectiveLengthKm,
+		SBSThresholdDbm: nonlinear.SBSThresholdDbm,
+		SPMThresholdDbm: nonlinear.SPMThresholdDbm,
+		NonlinearPhaseRad: nonlinear.NonlinearPhaseRad,
+		SBSPenaltyDb: nonlinear.SBSPenaltyDb,
+		SPMPenaltyDb: nonlinear.SPMPenaltyDb,
+		NonlinearStatus: nonlinear.Status,
+		RepairAllowanceDb: allowances.RepairDb,
+		TemperatureAllowanceDb: allowances.TemperatureDb,
+		AgingAllowanceDb: allowances.AgingDb,
+		CableCutAllowanceDb: allowances.CableCutDb,
+		AllowanceTotalDb: allowances.TotalDb(),
+	}
+
+	// Amplified links: received power comes from the amplifier chain
+	if len(link.Amplifiers) > 0 {
+		osnrOut, err := CalculateOSNR(linkOSNR(link))
+		if err != nil {
+			return model.LinkOutput{}, fmt.Errorf("An error has occurred: %v", err.Error())
+		}
+		res.RxPowerDbm = osnrOut.RxPowerDbm
+		res.MarginDb = osnrOut.RxPowerDbm - link.RXSensitivityDbm - reservedDb - penaltyDb
+		res.LPBStatus = "FAIL"
+		if res.MarginDb >= 0 {
+			res.LPBStatus = "PASS"
+		}
+		res.AmplifierGainDb = osnrOut.NetGainDb
+		res.OSNRDb = osnrOut.OSNRDb
+		res.OSNRStatus = osnrOut.Status
+	}
+
+	// Unbounded penalties leave the link without a margin: the finite terms
+	// still count, the unbounded ones are named and flag the LPB status
+	if len(unbounded) > 0 {
+		res.LPBStatus = StatusUnbounded
+		res.UnboundedPenalties = unbounded
+	}
+
+	// BER and Q-factor at the received power, less the penalties, when the
+	// receiver has a BER model
+	if link.SensitivityBER > 0 {
+		fec, target := link.FEC, link.TargetBER
+		if fec == "" {
+			fec = opt.FEC
+		}
+		if target == 0 {
+			target = opt.TargetBER
+		}
+		berOut, err := CalculateBER(BERInputs{
+			RxPowerDbm:        res.RxPowerDbm - penaltyDb,
+			RefSensitivityDbm: link.RXSensitivityDbm,
+			RefBER:            link.SensitivityBER,
+			QSlope:            link.QSlope,
+			FEC:               fec,
+			TargetBER:         target,
+		})
+		if err != nil {
+			return model.LinkOutput{}, fmt.Errorf("An error has occurred: %v", err.Error())
+		}
+		res.QFactor = berOut.Q
+		res.BER = berOut.BER
+		res.BERStatus = berOut.Status
+		res.PostFECBER = berOut.PostFECBER
+		res.PostFECStatus = berOut.PostFECStatus
+	}
+
+	// Optical return loss when the link describes its reflectance
+	if link.ConnectorType != "" || link.ConnectorReflectanceDb != 0 || link.SpliceReflectanceDb != 0 || link.ORLToleranceDb > 0 {
+		orlOut, err := CalculateORL(LinkORL(link))
+		if err != nil {
+			return model.LinkOutput{}, fmt.Errorf("An error has occurred: %v", err.Error())
+		}
+		res.ORLDb = orlOut.ORLDb
+		res.ReflectionStatus = orlOut.Status
+		res.WorstReflectionEvent = orlOut.WorstEvent
+		res.WorstReflectanceDb = orlOut.WorstReflectanceDb
 	}
 
 	// Explainability placeholders
@@ -65,6 +215,39 @@ func Compute(link model.LinkInput, opt RunnerOptions) (model.LinkOutput, error)
 		{"splitter_loss_db", link.SplitterLossDb},
 		{"splice_loss_db", link.SpliceLossDb},
 	}
+	if wdmLossDb > 0 {
+		contributors = append(contributors, contribute{"wdm_loss_db", wdmLossDb})
+	}
+	// Penalty terms compete with the losses once they are present
+	for _, p := range []contribute{
+		{"dispersion_penalty_db", penalties.DispersionDb},
+		{"er_penalty_db", penalties.ExtinctionRatioDb},
+		{"rin_penalty_db", penalties.RINDb},
+		{"mpn_penalty_db", penalties.MPNDb},
+		{"reflection_penalty_db", penalties.ReflectionDb},
+		{"chirp_penalty_db", penalties.ChirpDb},
+		{"sbs_penalty_db", penalties.SBSDb},
+		{"spm_penalty_db", penalties.SPMDb},
+	} {
+		if p.value > 0 {
+			contributors = append(contributors, p)
+		}
+	}
+	// Unbounded terms outrank every finite loss
+	for _, name := range unbounded {
+		contributors = append(contributors, contribute{name, math.Inf(1)})
+	}
+	// Allowances too, so an audit sees when the reserve outweighs a loss
+	for _, a := range []contribute{
+		{"repair_allowance_db", allowances.RepairDb},
+		{"temperature_allowance_db", allowances.TemperatureDb},
+		{"aging_allowance_db", allowances.AgingDb},
+		{"cable_cut_allowance_db", allowances.CableCutDb},
+	} {
+		if a.value > 0 {
+			contributors = append(contributors, a)
+		}
+	}
 	// Sort contributors by value descending
 	sort.Slice(contributors, 
 		func(i, j int) bool { 
@@ -74,14 +257,42 @@ func Compute(link model.LinkInput, opt RunnerOptions) (model.LinkOutput, error)
 	res.TopContributor2 = contributors[1].name
 	res.TopContributor3 = contributors[2].name
 
-	// RTB calculation if enabled
+	// Multimode Ethernet PHY against its reach table
+	phyDef, hasPHY := phy.Lookup(link.PHY)
+	if link.PHY != "" {
+		if !hasPHY {
+			return model.LinkOutput{}, errors.New("Unknown PHY: " + link.PHY)
+		}
+		fiberName := ""
+		if t, ok := fiber.Lookup(link.FiberType); ok {
+			fiberName = t.Name
+		}
+		phyOut, err := CalculatePHY(PHYInputs{
+			PHY:           phyDef,
+			FiberType:     fiberName,
+			LinkLengthKm:  link.FiberLengthKm,
+			ChannelLossDb: lpbOutput.TotalLossDb,
+		})
+		if err != nil {
+			return model.LinkOutput{}, fmt.Errorf("An error has occurred: %v", err.Error())
+		}
+		res.PHYReachM = phyOut.ReachM
+		res.PHYMaxChannelLossDb = phyOut.MaxChannelLossDb
+		res.PHYLimit = phyOut.Limit
+		res.PHYStatus = phyOut.Status
+	}
+
+	// RTB calculation if enabled, at the bitrate of the link and with the
+	// modal dispersion of multimode fiber
+	bitrate := LinkBitrateGbps(link, opt)
 	if opt.EnableRTB {
 		rtbIn := RTBInputs{
-			BitrateGbps:      opt.BitrateGbps,
+			BitrateGbps:      bitrate,
 			TxRiseTimeNs:     opt.TxRiseTimeNs,
 			RxRiseTimeNs:     opt.RxRiseTimeNs,
 			FiberLengthKm:    link.FiberLengthKm,
 			DispersionPerKm:  opt.DispersionPerKm,
+			ModalRiseTimeNs:  ModalRiseTimeNs(link),
 		}
 		rtbOut, err := CalculateRTB(rtbIn)
 		if err != nil {
@@ -91,6 +302,188 @@ func Compute(link model.LinkInput, opt RunnerOptions) (model.LinkOutput, error)
 		res.SystemRiseTimeNs = rtbOut.TotalRiseTimeNs
 		res.AllowedRiseTimeNs = rtbOut.AllowedRiseTimeNs
 		res.RTBStatus = (rtbOut.Status == "PASS")
+		res.ModalRiseTimeNs = rtbIn.ModalRiseTimeNs
+	}
+
+	// PMD check when the link or its declared fiber type carries a coefficient
+	// and a bitrate is known
+	if pmd := LinkPMD(link); pmd > 0 && bitrate > 0 {
+		pmdOut, err := CalculatePMD(PMDInputs{
+			BitrateGbps:  bitrate,
+			LinkLengthKm: link.FiberLengthKm,
+			PMDPsSqrtKm:  pmd,
+			BitFraction:  opt.PMDBitFraction,
+		})
+		if err != nil {
+			return model.LinkOutput{}, fmt.Errorf("An error has occurred: %v", err.Error())
+		}
+		res.DGDPs = pmdOut.DGDPs
+		res.MaxDGDPs = pmdOut.MaxDGDPs
+		res.PMDStatus = pmdOut.Status
 	}
 	return res, nil
-}
\ No newline at end of file
+}
+
+// Define function to resolve the bitrate of a link: its bitrate_gbps value,
+// else the lane rate of a declared PHY, else the run-wide bitrate (0 when none
+// is given, which leaves the bitrate-dependent checks out)
+func LinkBitrateGbps(link model.LinkInput, opt RunnerOptions) float64 {
+	if link.BitrateGbps > 0 {
+		return link.BitrateGbps
+	}
+	if p, ok := phy.Lookup(link.PHY); ok {
+		return p.LaneRateGBd
+	}
+	return opt.BitrateGbps
+}
+
+// Define function to resolve the chromatic dispersion coefficient of a link in
+// ps/(nm·km); without a dispersion_ps_nm_km value the fiber type's dispersion
+// at the wavelength is used
+func LinkDispersion(link model.LinkInput) float64 {
+	if link.DispersionPsNmKm != 0 {
+		return link.DispersionPsNmKm
+	}
+	ft := fiber.Default
+	if t, ok := fiber.Lookup(link.FiberType); ok {
+		ft = t
+	}
+	return ft.DispersionAt(link.WavelengthNm)
+}
+
+// Define function to resolve the PMD coefficient of a link in ps/√km: the
+// pmd_ps_sqrt_km value, else the design value of a declared fiber type
+func LinkPMD(link model.LinkInput) float64 {
+	if link.PMDPsSqrtKm != 0 {
+		return link.PMDPsSqrtKm
+	}
+	if t, ok := fiber.Lookup(link.FiberType); ok {
+		return t.PMDPsSqrtKm
+	}
+	return 0
+}
+
+// Define function to build the OSNR inputs of an amplified link
+func linkOSNR(link model.LinkInput) OSNRInputs {
+	wdmLossDb := link.MuxLossDb + link.DemuxLossDb + float64(link.NOADM)*link.OADMLossDb
+	return OSNRInputs{
+		TxPowerDbm:      link.TXPowerDbm - link.MuxLossDb, // Mux sits before the first span
+		WavelengthNm:    link.WavelengthNm,
+		LinkLengthKm:    link.FiberLengthKm,
+		FiberAttDbPerKm: link.FiberAttDbPerKm,
+		SpliceLossDb:    float64(link.NSplice) * link.SpliceLossDb,
+		EndLossDb:       float64(link.NConnectors)*link.ConnectorLossDb + link.SplitterLossDb + link.OtherLossDb + wdmLossDb - link.MuxLossDb,
+		RequiredOSNRDb:  link.RequiredOSNRDb,
+		Amplifiers:      link.Amplifiers,
+	}
+}
+
+// Define function to build the nonlinear threshold inputs of a link: one span
+// from the transmitter, or the spans between amplifiers with their output power
+func LinkNonlinear(link model.LinkInput) (NonlinearInputs, error) {
+	area := link.EffectiveAreaUm2
+	if area == 0 {
+		if t, ok := fiber.Lookup(link.FiberType); ok {
+			area = t.EffectiveAreaUm2
+		}
+	}
+	in := NonlinearInputs{
+		WavelengthNm:     link.WavelengthNm,
+		FiberAttDbPerKm:  link.FiberAttDbPerKm,
+		EffectiveAreaUm2: area,
+		LinewidthMHz:     link.SourceLinewidthMHz,
+		Spans:            []Span{{LaunchDbm: link.TXPowerDbm - link.MuxLossDb, LengthKm: link.FiberLengthKm}},
+	}
+	if len(link.Amplifiers) > 0 {
+		osnrOut, err := CalculateOSNR(linkOSNR(link))
+		if err != nil {
+			return NonlinearInputs{}, err
+		}
+		in.Spans = osnrOut.Spans
+	}
+	return in, nil
+}
+
+// Define function to build the ORL inputs of a link; unknown connector types
+// are rejected by validation and fall back to the default here
+func LinkORL(link model.LinkInput) ORLInputs {
+	connRefl, err := ConnectorReflectanceDb(link.ConnectorType, link.ConnectorReflectanceDb)
+	if err != nil {
+		connRefl = ConnectorReflectance[DefaultConnectorType]
+	}
+	spliceRefl := link.SpliceReflectanceDb
+	if spliceRefl == 0 {
+		spliceRefl = DefaultSpliceReflectanceDb
+	}
+	return ORLInputs{
+		LinkLengthKm:           link.FiberLengthKm,
+		FiberAttDbPerKm:        link.FiberAttDbPerKm,
+		NConnectors:            link.NConnectors,
+		ConnectorLossDb:        link.ConnectorLossDb,
+		ConnectorReflectanceDb: connRefl,
+		NSplice:                link.NSplice,
+		SpliceLossDb:           link.SpliceLossDb,
+		SpliceReflectanceDb:    spliceRefl,
+		EndLossDb:              link.SplitterLossDb + link.OtherLossDb + link.MuxLossDb + link.DemuxLossDb + float64(link.NOADM)*link.OADMLossDb,
+		ToleranceDb:            link.ORLToleranceDb,
+	}
+}
+
+// Define function to resolve the allowance values of a link: its cable type
+// with per-link overrides. ok is false when the link asks for no allowances.
+func LinkAllowances(link model.LinkInput, opt RunnerOptions) (a cable.Allowances, ok bool, err error) {
+	if link.CableType != "" {
+		if a, ok = cable.Lookup(link.CableType, opt.Cables); !ok {
+			return a, false, errors.New("Unknown cable type: " + link.CableType)
+		}
+	}
+	for _, o := range []struct {
+		value float64
+		dst   *float64
+	}{
+		{link.RepairSplicesPerKm, &a.RepairSplicesPerKm},
+		{link.TempDriftDbPerKm, &a.TempDriftDbPerKm},
+		{link.AgingMarginDb, &a.AgingDb},
+		{link.CableCutMarginDb, &a.CableCutDb},
+	} {
+		if o.value != 0 {
+			*o.dst, ok = o.value, true
+		}
+	}
+	return a, ok || link.CableType != "", nil
+}
+
+// Define function to compute the margin allowances of a link
+func computeAllowances(link model.LinkInput, opt RunnerOptions) (Allowances, error) {
+	a, ok, err := LinkAllowances(link, opt)
+	if err != nil || !ok {
+		return Allowances{}, err
+	}
+	return CalculateAllowances(AllowanceInputs{
+		LinkLengthKm: link.FiberLengthKm,
+		SpliceLossDb: link.SpliceLossDb,
+		Cable:        a,
+	})
+}
+
+// Define function to compute the power penalties of a link
+func computePenalties(link model.LinkInput, opt RunnerOptions) (Penalties, error) {
+	return CalculatePenalties(PenaltyInputs{
+		BitrateGbps:       LinkBitrateGbps(link, opt),
+		LinkLengthKm:      link.FiberLengthKm,
+		DispersionPsNmKm:  LinkDispersion(link),
+		SpectralWidthNm:   link.SpectralWidthNm,
+		ExtinctionRatioDb: link.ExtinctionRatioDb,
+		RINDbHz:           link.RINDbHz,
+		MPNFactor:         link.MPNFactor,
+		RefBER:            link.SensitivityBER,
+		Supplied: Penalties{
+			DispersionDb:      link.DispersionPenaltyDb,
+			ExtinctionRatioDb: link.ExtinctionPenaltyDb,
+			RINDb:             link.RINPenaltyDb,
+			MPNDb:             link.MPNPenaltyDb,
+			ReflectionDb:      link.ReflectionPenaltyDb,
+			ChirpDb:           link.ChirpPenaltyDb,
+		},
+	})
+}
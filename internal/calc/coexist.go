@@ -0,0 +1,72 @@
+package calc
+
+import (
+	"math"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/coexist"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define function to build the single-wavelength link for one direction of a
+// service. The coexistence element sits at the OLT and the WDM1r filter at the
+// ONT, so their roles as mux and demux swap with the direction.
+func ServiceLink(link model.LinkInput, svc coexist.Service, path coexist.Path) model.LinkInput {
+	out := ChannelLink(link, model.Channel{Label: svc.Name, WavelengthNm: path.WavelengthNm})
+	out.Services = nil
+	out.TXPowerDbm = path.TxPowerDbm
+	out.RXSensitivityDbm = path.RxSensitivityDbm
+	if path.Direction == coexist.Upstream {
+		out.MuxLossDb += svc.WDM1rLossDb
+		out.DemuxLossDb += svc.CExLossDb
+	} else {
+		out.MuxLossDb += svc.CExLossDb
+		out.DemuxLossDb += svc.WDM1rLossDb
+	}
+	return out
+}
+
+// Define function to evaluate every service and direction on a shared ODN.
+// The link result is the limiting one: the smallest of the sensitivity margin
+// and the headroom below receiver overload.
+func computeServices(link model.LinkInput, opt RunnerOptions) (model.LinkOutput, error) {
+	var limiting model.LinkOutput
+	var outputs []model.ServiceOutput
+	worst := math.Inf(1)
+	for _, entry := range link.Services {
+		svc, err := coexist.Resolve(entry)
+		if err != nil {
+			return model.LinkOutput{}, err
+		}
+		for _, path := range svc.Paths {
+			out, err := Compute(ServiceLink(link, svc, path), opt)
+			if err != nil {
+				return model.LinkOutput{}, err
+			}
+			overload := path.OverloadDbm - out.RxPowerDbm
+			status := out.LPBStatus
+			if overload < 0 && status != StatusUnbounded {
+				status = "FAIL"
+			}
+			outputs = append(outputs, model.ServiceOutput{
+				Service:          svc.Name,
+				Direction:        path.Direction,
+				WavelengthNm:     path.WavelengthNm,
+				FilterLossDb:     svc.CExLossDb + svc.WDM1rLossDb,
+				TxPowerDbm:       path.TxPowerDbm,
+				RxSensitivityDbm: path.RxSensitivityDbm,
+				RxPowerDbm:       out.RxPowerDbm,
+				MarginDb:         out.MarginDb,
+				OverloadMarginDb: overload,
+				Status:           status,
+			})
+			if m := math.Min(lpbRank(out), overload); m < worst {
+				worst = m
+				limiting = out
+				limiting.LPBStatus = status
+				limiting.LimitingService = svc.Name + "/" + path.Direction
+			}
+		}
+	}
+	limiting.Services = outputs
+	return limiting, nil
+}
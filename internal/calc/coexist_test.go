@@ -0,0 +1,58 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+func TestComputeServices(t *testing.T) {
+	// 20 km at 0.35 dB/km (1310 nm) behind a 1:32 splitter; G.652 puts 1490 nm
+	// at 0.24638 and 1550 nm at 0.23726 dB/km. Each path crosses the CEx
+	// (1 dB) and the WDM1r (0.5 dB): 18.5 dB besides the fiber.
+	link := model.LinkInput{LinkID: "P1", FiberLengthKm: 20, FiberAttDbPerKm: 0.35, WavelengthNm: 1310, FiberType: "G.652.D",
+		SplitterLossDb: 17}
+	wdm1r := map[string]float64{"wdm1r": 0.5}
+
+	tests := []struct {
+		name     string
+		video    map[string]float64
+		limiting string
+		status   string
+		want     []model.ServiceOutput
+	}{
+		{"catalog powers", wdm1r, "rf-video/down", "PASS", []model.ServiceOutput{
+			// 1.5 − 23.4276 = −21.9276 dBm: 5.0724 dB above −27, 13.9276 below −8
+			{Service: "gpon", Direction: "down", RxPowerDbm: -21.9276, MarginDb: 5.0724, OverloadMarginDb: 13.9276, Status: "PASS"},
+			// 0.5 − 25.5 = −25 dBm: 3 dB above −28, 17 below −8
+			{Service: "gpon", Direction: "up", RxPowerDbm: -25, MarginDb: 3, OverloadMarginDb: 17, Status: "PASS"},
+			// 20 − 23.2452 = −3.2452 dBm: 2.7548 dB above −6, 5.2452 below 2
+			{Service: "rf-video", Direction: "down", RxPowerDbm: -3.2452, MarginDb: 2.7548, OverloadMarginDb: 5.2452, Status: "PASS"},
+		}},
+		// 28 dBm of video overloads the receiver by 2.7548 dB
+		{"video overload", map[string]float64{"wdm1r": 0.5, "down_tx": 28}, "rf-video/down", "FAIL", []model.ServiceOutput{
+			{Service: "gpon", Direction: "down", RxPowerDbm: -21.9276, MarginDb: 5.0724, OverloadMarginDb: 13.9276, Status: "PASS"},
+			{Service: "gpon", Direction: "up", RxPowerDbm: -25, MarginDb: 3, OverloadMarginDb: 17, Status: "PASS"},
+			{Service: "rf-video", Direction: "down", RxPowerDbm: 4.7548, MarginDb: 10.7548, OverloadMarginDb: -2.7548, Status: "FAIL"},
+		}},
+	}
+	for _, tt := range tests {
+		l := link
+		l.Services = []model.Service{{Name: "gpon", Params: wdm1r}, {Name: "rf-video", Params: tt.video}}
+		out, err := Compute(l, RunnerOptions{}.WithDefaults())
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if out.LimitingService != tt.limiting || out.LPBStatus != tt.status || len(out.Services) != len(tt.want) {
+			t.Fatalf("%s: limiting %s %s with %d paths; want %s %s with %d", tt.name, out.LimitingService, out.LPBStatus, len(out.Services), tt.limiting, tt.status, len(tt.want))
+		}
+		for i, w := range tt.want {
+			g := out.Services[i]
+			if g.Service != w.Service || g.Direction != w.Direction || g.FilterLossDb != 1.5 || g.Status != w.Status ||
+				!near(g.RxPowerDbm, w.RxPowerDbm, 1e-4) || !near(g.MarginDb, w.MarginDb, 1e-4) || !near(g.OverloadMarginDb, w.OverloadMarginDb, 1e-4) {
+				t.Errorf("%s: %s/%s Rx %.4f dBm, margin %.4f, overload %.4f, %s; want %.4f, %.4f, %.4f, %s", tt.name, g.Service, g.Direction,
+					g.RxPowerDbm, g.MarginDb, g.OverloadMarginDb, g.Status, w.RxPowerDbm, w.MarginDb, w.OverloadMarginDb, w.Status)
+			}
+		}
+	}
+}
@@ -15,6 +15,7 @@ type LPBInputs struct {
 	SystemMarginDb float64
 	LinkLengthKm float64
 	OtherLossDb float64
+	PenaltyDb float64 // Power penalties, reserved like the system margin
 }
 
 // Define struct for LPB outputs
@@ -38,7 +39,7 @@ func CalculateLPB(input LPBInputs) (LPBResults, error) {
 
 	// Received power and margin calculation: Pr = Pt - Ps or Total Loss
 	rxPower := input.TxPowerDbm - totalLoss
-	margin := rxPower - input.RxSensitivityDbm - input.SystemMarginDb
+	margin := rxPower - input.RxSensitivityDbm - input.SystemMarginDb - input.PenaltyDb
 
 	// Determine status
 	status := "FAIL"
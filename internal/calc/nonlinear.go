@@ -0,0 +1,111 @@
+package calc
+
+import (
+	"errors"
+	"math"
+)
+
+// Define constants of the nonlinear threshold model for silica fiber
+const (
+	BrillouinGainMW         = 5e-11   // Peak Brillouin gain g_B in m/W
+	BrillouinBandwidthMHz   = 20.0    // Brillouin gain bandwidth Δν_B at 1550 nm
+	SBSPolarizationFactor   = 2.0     // Polarization is scrambled along standard fiber
+	NonlinearIndexM2W       = 2.6e-20 // Kerr coefficient n2 in m²/W
+	DefaultEffectiveAreaUm2 = 80.0    // G.652 at 1550 nm
+	MaxSPMPhaseRad          = 1.0     // Nonlinear phase accepted before SPM distorts the pulse
+)
+
+// Define struct for a fiber span and the power launched into it
+type Span struct {
+	LaunchDbm float64
+	LengthKm  float64
+}
+
+// Define struct for nonlinear threshold inputs
+type NonlinearInputs struct {
+	WavelengthNm     float64 // 0 uses 1550 nm
+	FiberAttDbPerKm  float64
+	EffectiveAreaUm2 float64 // 0 uses DefaultEffectiveAreaUm2
+	LinewidthMHz     float64 // Source linewidth, SBS suppression dithering included
+	Spans            []Span  // Transmitter span first, then one per amplifier
+}
+
+// Define struct for nonlinear threshold outputs. The SBS values describe the
+// span closest to its threshold; SPM accumulates over all spans.
+type NonlinearResults struct {
+	EffectiveLengthKm float64
+	SBSThresholdDbm   float64
+	LaunchDbm         float64
+	SBSPenaltyDb      float64 // Launch power above the SBS threshold, scattered back
+	NonlinearPhaseRad float64
+	SPMThresholdDbm   float64 // Launch power per span for a 1 rad nonlinear phase
+	SPMPenaltyDb      float64 // Launch power reduction that brings the phase back to 1 rad
+	Status            string
+}
+
+// Define function to calculate the SBS and SPM thresholds of a link and the
+// penalty when the launch power exceeds them
+func CalculateNonlinear(input NonlinearInputs) (NonlinearResults, error) {
+	// Check if inputs are valid
+	if len(input.Spans) == 0 {
+		return NonlinearResults{}, errors.New("Nonlinear check needs at least one span")
+	}
+	if input.FiberAttDbPerKm < 0 || input.EffectiveAreaUm2 < 0 || input.LinewidthMHz < 0 {
+		return NonlinearResults{}, errors.New("Nonlinear parameters do not have valid values")
+	}
+	wavelength := input.WavelengthNm
+	if wavelength <= 0 {
+		wavelength = DefaultWavelengthNm
+	}
+	area := input.EffectiveAreaUm2
+	if area <= 0 {
+		area = DefaultEffectiveAreaUm2
+	}
+	areaM2 := area * 1e-12
+
+	// γ = 2π·n2 / (λ·A_eff), per W·km
+	gamma := 2 * math.Pi * NonlinearIndexM2W / (wavelength * 1e-9 * areaM2) * 1e3
+
+	res := NonlinearResults{SBSPenaltyDb: math.Inf(-1)}
+	totalLeff := 0.0
+	for _, s := range input.Spans {
+		if s.LengthKm <= 0 {
+			continue // Booster at the transmitter, no fiber before it
+		}
+		leff := EffectiveLengthKm(input.FiberAttDbPerKm, s.LengthKm)
+		totalLeff += leff
+
+		// P_th = 21·K·A_eff / (g_B·L_eff) · (1 + Δν_s/Δν_B)
+		thW := 21 * SBSPolarizationFactor * areaM2 / (BrillouinGainMW * leff * 1e3) * (1 + input.LinewidthMHz/BrillouinBandwidthMHz)
+		thDbm := mwToDbm(thW * 1e3)
+		if excess := s.LaunchDbm - thDbm; excess > res.SBSPenaltyDb {
+			res.EffectiveLengthKm = leff
+			res.SBSThresholdDbm = thDbm
+			res.LaunchDbm = s.LaunchDbm
+			res.SBSPenaltyDb = excess
+		}
+
+		// φ_NL = Σ γ·P·L_eff
+		res.NonlinearPhaseRad += gamma * dbmToMw(s.LaunchDbm) * 1e-3 * leff
+	}
+	if totalLeff == 0 {
+		return NonlinearResults{}, errors.New("Nonlinear check needs a span with fiber")
+	}
+	res.SBSPenaltyDb = math.Max(res.SBSPenaltyDb, 0)
+	res.SPMThresholdDbm = mwToDbm(MaxSPMPhaseRad / (gamma * totalLeff) * 1e3)
+	if res.NonlinearPhaseRad > MaxSPMPhaseRad {
+		res.SPMPenaltyDb = 10 * math.Log10(res.NonlinearPhaseRad/MaxSPMPhaseRad)
+	}
+	res.Status = passFail(res.SBSPenaltyDb == 0 && res.SPMPenaltyDb == 0)
+	return res, nil
+}
+
+// Define function to calculate the effective length L_eff = (1 − e^(−αL)) / α
+// of a span in km, with α converted from dB/km to 1/km
+func EffectiveLengthKm(attDbPerKm, lengthKm float64) float64 {
+	alpha := attDbPerKm * math.Ln10 / 10
+	if alpha == 0 {
+		return lengthKm
+	}
+	return (1 - math.Exp(-alpha*lengthKm)) / alpha
+}
@@ -0,0 +1,67 @@
+package calc
+
+import "testing"
+
+func TestCalculateNonlinear(t *testing.T) {
+	// 80 km at 0.2 dB/km: L_eff = 21.1693 km. With A_eff = 80 µm² the SBS
+	// threshold is 21·2·80e-12 / (5e-11·21169.3) W = 5.0166 dBm, and at
+	// 1550 nm γ = 1.31744 /(W·km), so 1 rad needs 1/(γ·L_eff) = 15.5456 dBm.
+	span := func(dbm float64) []Span { return []Span{{LaunchDbm: dbm, LengthKm: 80}} }
+	tests := []struct {
+		name      string
+		linewidth float64
+		spans     []Span
+		sbsTh     float64
+		sbs       float64
+		phase     float64
+		spmTh     float64
+		spm       float64
+		status    string
+	}{
+		{"3 dBm", 0, span(3), 5.0166, 0, 0.055646, 15.5456, 0, "PASS"},
+		// 10 − 5.0166 dB scattered back
+		{"10 dBm", 0, span(10), 5.0166, 4.9834, 0.278893, 15.5456, 0, "FAIL"},
+		// Dithering to 100 MHz raises the threshold by 10·log10(1 + 100/20)
+		{"10 dBm dithered", 100, span(10), 12.7981, 0, 0.278893, 15.5456, 0, "PASS"},
+		// 20 dBm: φ = 2.78893 rad, 10·log10(2.78893) dB over the SPM limit
+		{"20 dBm dithered", 1000, span(20), 22.0923, 0, 2.788929, 15.5456, 4.4544, "FAIL"},
+		// The booster span has no fiber; two 80 km spans double L_eff for SPM
+		{"two amplified spans", 1000, []Span{{LaunchDbm: 30}, {LaunchDbm: 17, LengthKm: 80}, {LaunchDbm: 17, LengthKm: 80}},
+			22.0923, 0, 2.795552, 12.5353, 4.4647, "FAIL"},
+	}
+	for _, tt := range tests {
+		res, err := CalculateNonlinear(NonlinearInputs{FiberAttDbPerKm: 0.2, LinewidthMHz: tt.linewidth, Spans: tt.spans})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !near(res.EffectiveLengthKm, 21.1693, 1e-4) || !near(res.SBSThresholdDbm, tt.sbsTh, 1e-4) || !near(res.SBSPenaltyDb, tt.sbs, 1e-4) {
+			t.Errorf("%s: L_eff %.4f km, SBS threshold %.4f dBm, penalty %.4f dB; want 21.1693, %.4f, %.4f",
+				tt.name, res.EffectiveLengthKm, res.SBSThresholdDbm, res.SBSPenaltyDb, tt.sbsTh, tt.sbs)
+		}
+		if !near(res.NonlinearPhaseRad, tt.phase, 1e-6) || !near(res.SPMThresholdDbm, tt.spmTh, 1e-4) || !near(res.SPMPenaltyDb, tt.spm, 1e-4) || res.Status != tt.status {
+			t.Errorf("%s: φ %.6f rad, SPM threshold %.4f dBm, penalty %.4f dB, %s; want %.6f, %.4f, %.4f, %s",
+				tt.name, res.NonlinearPhaseRad, res.SPMThresholdDbm, res.SPMPenaltyDb, res.Status, tt.phase, tt.spmTh, tt.spm, tt.status)
+		}
+	}
+	for _, in := range []NonlinearInputs{{}, {Spans: []Span{{LaunchDbm: 20}}}, {FiberAttDbPerKm: -0.2, Spans: span(3)}} {
+		if _, err := CalculateNonlinear(in); err == nil {
+			t.Errorf("%+v accepted", in)
+		}
+	}
+}
+
+func TestEffectiveLengthKm(t *testing.T) {
+	tests := []struct {
+		att, length, want float64
+	}{
+		{0.2, 80, 21.169275},
+		{0.2, 10, 8.013659},
+		{0, 10, 10},
+		{0, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := EffectiveLengthKm(tt.att, tt.length); !near(got, tt.want, 1e-6) {
+			t.Errorf("%g dB/km over %g km: %.6f km; want %.6f", tt.att, tt.length, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package calc
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Define typical reflectance per connector polish in dB (IEC 61753-1 grades)
+var ConnectorReflectance = map[string]float64{
+	"pc":  -40,
+	"upc": -50,
+	"apc": -65,
+}
+
+// Define reflectance defaults
+const (
+	DefaultConnectorType       = "upc"
+	DefaultSpliceReflectanceDb = -70.0  // Fusion splice, practically non-reflective
+	BackscatterCapture         = 1.5e-3 // Share of Rayleigh scattering guided back to the source
+	MaxORLDb                   = 80.0   // Reported for a link with nothing to reflect
+)
+
+// Define struct for ORL inputs. Connectors are split between the two ends
+// (the odd one at the transmitter), splices are spread evenly along the fiber
+// and the end loss (splitter, other) sits in front of the far-end connectors.
+type ORLInputs struct {
+	LinkLengthKm           float64
+	FiberAttDbPerKm        float64
+	NConnectors            int
+	ConnectorLossDb        float64 // Per connector
+	ConnectorReflectanceDb float64 // Per connector, negative
+	NSplice                int
+	SpliceLossDb           float64 // Per splice
+	SpliceReflectanceDb    float64 // Per splice, negative
+	EndLossDb              float64
+	ToleranceDb            float64 // Minimum ORL the transmitter tolerates, 0 for no check
+}
+
+// Define struct for ORL outputs
+type ORLResults struct {
+	ORLDb              float64 // Total optical return loss at the transmitter
+	BackscatterDb      float64 // Rayleigh backscatter alone
+	WorstEvent         string  // Event returning the most power to the transmitter
+	WorstReflectanceDb float64
+	Status             string // PASS, FAIL, or N/A without a tolerance
+}
+
+// Define struct for one reflective event
+type reflectiveEvent struct {
+	name        string
+	reflectance float64
+	roundTripDb float64
+}
+
+// Define function to calculate the optical return loss seen by the transmitter
+func CalculateORL(input ORLInputs) (ORLResults, error) {
+	// Check if inputs are valid
+	if input.ConnectorReflectanceDb > 0 || input.SpliceReflectanceDb > 0 {
+		return ORLResults{}, errors.New("Reflectance has to be zero or negative")
+	}
+	if input.FiberAttDbPerKm < 0 || input.LinkLengthKm < 0 {
+		return ORLResults{}, errors.New("Fiber loss does not have valid value")
+	}
+
+	// Place the events and the one-way loss in front of each
+	var events []reflectiveEvent
+	nearConn := (input.NConnectors + 1) / 2
+	for i := 0; i < nearConn; i++ {
+		events = append(events, reflectiveEvent{"connector " + strconv.Itoa(i+1) + " (0 km)", input.ConnectorReflectanceDb, 2 * float64(i) * input.ConnectorLossDb})
+	}
+	nearLoss := float64(nearConn) * input.ConnectorLossDb
+	for i := 0; i < input.NSplice; i++ {
+		pos := input.LinkLengthKm * float64(i+1) / float64(input.NSplice+1)
+		oneWay := nearLoss + pos*input.FiberAttDbPerKm + float64(i)*input.SpliceLossDb
+		events = append(events, reflectiveEvent{"splice " + strconv.Itoa(i+1) + " (" + strconv.FormatFloat(pos, 'f', 2, 64) + " km)", input.SpliceReflectanceDb, 2 * oneWay})
+	}
+	farLoss := nearLoss + input.LinkLengthKm*input.FiberAttDbPerKm + float64(input.NSplice)*input.SpliceLossDb + input.EndLossDb
+	for i := nearConn; i < input.NConnectors; i++ {
+		oneWay := farLoss + float64(i-nearConn)*input.ConnectorLossDb
+		events = append(events, reflectiveEvent{"connector " + strconv.Itoa(i+1) + " (" + strconv.FormatFloat(input.LinkLengthKm, 'f', 2, 64) + " km)", input.ConnectorReflectanceDb, 2 * oneWay})
+	}
+
+	// Rayleigh backscatter of the fiber: (S/2)·(1 − 10^(−2αL/10))
+	fiberLoss := input.LinkLengthKm * input.FiberAttDbPerKm
+	backscatter := BackscatterCapture / 2 * (1 - math.Pow(10, -2*fiberLoss/10))
+	total := backscatter
+	for _, e := range events {
+		total += dbToLinear(e.reflectance - e.roundTripDb)
+	}
+
+	res := ORLResults{
+		ORLDb:         math.Min(-10*math.Log10(total), MaxORLDb),
+		BackscatterDb: math.Min(-10*math.Log10(backscatter), MaxORLDb),
+	}
+	if len(events) > 0 {
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].reflectance-events[i].roundTripDb > events[j].reflectance-events[j].roundTripDb
+		})
+		res.WorstEvent = events[0].name
+		res.WorstReflectanceDb = events[0].reflectance
+	}
+	res.Status = "N/A"
+	if input.ToleranceDb > 0 {
+		res.Status = passFail(res.ORLDb >= input.ToleranceDb)
+	}
+	return res, nil
+}
+
+// Define function to resolve the per-connector reflectance of a link
+func ConnectorReflectanceDb(connectorType string, reflectanceDb float64) (float64, error) {
+	if reflectanceDb != 0 {
+		return reflectanceDb, nil
+	}
+	if connectorType == "" {
+		connectorType = DefaultConnectorType
+	}
+	r, ok := ConnectorReflectance[strings.ToLower(connectorType)]
+	if !ok {
+		return 0, errors.New("Unknown connector type: " + connectorType)
+	}
+	return r, nil
+}
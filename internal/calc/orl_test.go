@@ -0,0 +1,76 @@
+package calc
+
+import "testing"
+
+func TestCalculateORL(t *testing.T) {
+	// 20 km at 0.25 dB/km: backscatter 7.5e-4·(1 − 10^−1) = 6.75e-4, 31.7070 dB
+	fiber := ORLInputs{LinkLengthKm: 20, FiberAttDbPerKm: 0.25}
+	with := func(f func(*ORLInputs)) ORLInputs {
+		in := fiber
+		f(&in)
+		return in
+	}
+	tests := []struct {
+		name   string
+		in     ORLInputs
+		orl    float64
+		worst  string
+		status string
+	}{
+		{"nothing to reflect", ORLInputs{}, MaxORLDb, "", "N/A"},
+		{"fiber only", fiber, 31.7070, "", "N/A"},
+		// −50 dB at the transmitter and −50 − 2·5.5 at the far end
+		{"two upc", with(func(in *ORLInputs) {
+			in.NConnectors, in.ConnectorLossDb, in.ConnectorReflectanceDb = 2, 0.5, -50
+		}), 31.6381, "connector 1 (0 km)", "N/A"},
+		// Near end −40 and −41, far end −52 and −53 dB
+		{"four pc against 32 dB", with(func(in *ORLInputs) {
+			in.NConnectors, in.ConnectorLossDb, in.ConnectorReflectanceDb, in.ToleranceDb = 4, 0.5, -40, 32
+		}), 30.6261, "connector 1 (0 km)", "FAIL"},
+		// Splices at 5, 10 and 15 km return −73.5, −76.2 and −78.9 dB
+		{"splices", with(func(in *ORLInputs) {
+			in.NSplice, in.SpliceLossDb, in.SpliceReflectanceDb, in.ToleranceDb = 3, 0.1, -70, 30
+		}), 31.7064, "splice 1 (5.00 km)", "PASS"},
+		// The splitter hides the far-end connector: −65 − 2·22.8
+		{"apc behind a splitter", with(func(in *ORLInputs) {
+			in.NConnectors, in.ConnectorLossDb, in.ConnectorReflectanceDb = 2, 0.5, -65
+			in.NSplice, in.SpliceLossDb, in.SpliceReflectanceDb = 3, 0.1, -70
+			in.EndLossDb = 17
+		}), 31.7044, "connector 1 (0 km)", "N/A"},
+	}
+	for _, tt := range tests {
+		res, err := CalculateORL(tt.in)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !near(res.ORLDb, tt.orl, 1e-4) || res.WorstEvent != tt.worst || res.Status != tt.status {
+			t.Errorf("%s: ORL %.4f dB, worst %q, %s; want %.4f, %q, %s", tt.name, res.ORLDb, res.WorstEvent, res.Status, tt.orl, tt.worst, tt.status)
+		}
+	}
+	for _, in := range []ORLInputs{{ConnectorReflectanceDb: 10}, {SpliceReflectanceDb: 1}, {FiberAttDbPerKm: -0.1}} {
+		if _, err := CalculateORL(in); err == nil {
+			t.Errorf("%+v accepted", in)
+		}
+	}
+}
+
+func TestConnectorReflectanceDb(t *testing.T) {
+	tests := []struct {
+		connector string
+		given     float64
+		want      float64
+	}{
+		{"", 0, -50},
+		{"PC", 0, -40},
+		{"apc", 0, -65},
+		{"apc", -55, -55},
+	}
+	for _, tt := range tests {
+		if got, err := ConnectorReflectanceDb(tt.connector, tt.given); err != nil || got != tt.want {
+			t.Errorf("%q, %g: %g (%v); want %g", tt.connector, tt.given, got, err, tt.want)
+		}
+	}
+	if _, err := ConnectorReflectanceDb("spc", 0); err == nil {
+		t.Error("unknown connector type accepted")
+	}
+}
@@ -0,0 +1,127 @@
+package calc
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define physical constants and OSNR defaults
+const (
+	planckJs              = 6.62607015e-34
+	lightSpeedMs          = 299792458.0
+	DefaultWavelengthNm   = 1550.0
+	DefaultRefBandwidthNm = 0.1 // OSNR reference bandwidth
+)
+
+// Define struct for OSNR inputs. Fiber and splice loss are spread evenly along
+// the span; EndLossDb (connectors, splitter, other) is lumped at the receiver.
+type OSNRInputs struct {
+	TxPowerDbm      float64
+	WavelengthNm    float64
+	LinkLengthKm    float64
+	FiberAttDbPerKm float64
+	SpliceLossDb    float64
+	EndLossDb       float64
+	RefBandwidthNm  float64
+	RequiredOSNRDb  float64
+	Amplifiers      []model.Amplifier
+}
+
+// Define struct for OSNR outputs
+type OSNRResults struct {
+	RxPowerDbm float64
+	NetGainDb  float64 // Sum of the effective amplifier gains
+	OSNRDb     float64
+	Status     string
+	Spans      []Span // Fiber spans with their launch power, transmitter first
+}
+
+// Define function to propagate signal and ASE noise power through an amplifier chain
+func CalculateOSNR(input OSNRInputs) (OSNRResults, error) {
+	// Check if inputs are valid
+	if len(input.Amplifiers) == 0 {
+		return OSNRResults{}, errors.New("OSNR needs at least one amplifier")
+	}
+	wavelength := input.WavelengthNm
+	if wavelength <= 0 {
+		wavelength = DefaultWavelengthNm
+	}
+	refBw := input.RefBandwidthNm
+	if refBw <= 0 {
+		refBw = DefaultRefBandwidthNm
+	}
+
+	// ASE reference: h·ν·B in mW, with B the reference bandwidth in Hz
+	lambdaM := wavelength * 1e-9
+	nu := lightSpeedMs / lambdaM
+	bandwidthHz := lightSpeedMs * refBw * 1e-9 / (lambdaM * lambdaM)
+	hvB := planckJs * nu * bandwidthHz * 1e3
+
+	// Loss per km along the span, splices included
+	perKm := input.FiberAttDbPerKm
+	if input.LinkLengthKm > 0 {
+		perKm += input.SpliceLossDb / input.LinkLengthKm
+	}
+
+	amps := append([]model.Amplifier(nil), input.Amplifiers...)
+	sort.SliceStable(amps, func(i, j int) bool { return amps[i].PositionKm < amps[j].PositionKm })
+
+	// Track signal and accumulated ASE in mW
+	signal := dbmToMw(input.TxPowerDbm)
+	noise := 0.0
+	position, netGain := 0.0, 0.0
+	var spans []Span
+	for _, amp := range amps {
+		if amp.PositionKm < 0 || amp.PositionKm > input.LinkLengthKm {
+			return OSNRResults{}, errors.New("Amplifier position outside the link")
+		}
+		spans = append(spans, Span{LaunchDbm: mwToDbm(signal), LengthKm: amp.PositionKm - position})
+		span := dbToLinear(-(amp.PositionKm - position) * perKm)
+		signal *= span
+		noise *= span
+
+		// Output power is capped at the saturation power
+		gain := amp.GainDb
+		inDbm := mwToDbm(signal)
+		if amp.PsatDbm != 0 && inDbm+gain > amp.PsatDbm {
+			gain = math.Max(amp.PsatDbm-inDbm, 0)
+		}
+		g := dbToLinear(gain)
+
+		// ASE added at the output: P_ase = NF · h·ν·B · G
+		noise = noise*g + dbToLinear(amp.NoiseFigureDb)*hvB*g
+		signal *= g
+		netGain += gain
+		position = amp.PositionKm
+	}
+
+	// Last span and lumped receiver-side loss
+	spans = append(spans, Span{LaunchDbm: mwToDbm(signal), LengthKm: input.LinkLengthKm - position})
+	tail := dbToLinear(-(input.LinkLengthKm-position)*perKm - input.EndLossDb)
+	signal *= tail
+	noise *= tail
+
+	osnr := 10 * math.Log10(signal/noise)
+	status := "N/A"
+	if input.RequiredOSNRDb > 0 {
+		status = "FAIL"
+		if osnr >= input.RequiredOSNRDb {
+			status = "PASS"
+		}
+	}
+	return OSNRResults{
+		RxPowerDbm: mwToDbm(signal),
+		NetGainDb:  netGain,
+		OSNRDb:     osnr,
+		Status:     status,
+		Spans:      spans,
+	}, nil
+}
+
+// Define helpers for dB conversions
+func dbToLinear(db float64) float64 { return math.Pow(10, db/10) }
+func dbmToMw(dbm float64) float64   { return dbToLinear(dbm) }
+func mwToDbm(mw float64) float64    { return 10 * math.Log10(mw) }
@@ -0,0 +1,67 @@
+package calc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define helper to compare computed values with hand-computed ones
+func near(got, want, tol float64) bool {
+	return math.Abs(got-want) <= tol
+}
+
+func TestCalculateOSNR(t *testing.T) {
+	// At 1550 nm in 0.1 nm, h·ν·B is -57.961 dBm, so one amplifier gives
+	// OSNR = P_in - NF + 57.961 dB
+	edfa := func(km, gain, psat float64) model.Amplifier {
+		return model.Amplifier{Type: "edfa", PositionKm: km, GainDb: gain, NoiseFigureDb: 5, PsatDbm: psat}
+	}
+	tests := []struct {
+		name     string
+		lengthKm float64
+		required float64
+		amps     []model.Amplifier
+		osnr     float64
+		rx       float64
+		netGain  float64
+		status   string
+	}{
+		// -20 dBm in: 32.961 dB
+		{"one span", 80, 0, []model.Amplifier{edfa(80, 20, 0)}, 32.961, 0, 20, "N/A"},
+		// Two equal spans add their noise: 32.961 - 10·log10(2)
+		{"two equal spans", 160, 30, []model.Amplifier{edfa(80, 20, 0), edfa(160, 20, 0)}, 29.951, 0, 40, "FAIL"},
+		// 32.961 and 42.961 dB: -10·log10(10^-3.2961 + 10^-4.2961)
+		{"unequal spans", 120, 30, []model.Amplifier{edfa(80, 20, 0), edfa(120, 10, 0)}, 32.547, 0, 30, "PASS"},
+		// Booster capped at 10 dBm: gain 10 dB, 0 dBm in, 10 dB of fiber after
+		{"saturated booster", 40, 0, []model.Amplifier{edfa(0, 20, 10)}, 52.961, 0, 10, "N/A"},
+	}
+	for _, tt := range tests {
+		res, err := CalculateOSNR(OSNRInputs{
+			TxPowerDbm: 0, LinkLengthKm: tt.lengthKm, FiberAttDbPerKm: 0.25,
+			RequiredOSNRDb: tt.required, Amplifiers: tt.amps,
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !near(res.OSNRDb, tt.osnr, 1e-3) || !near(res.RxPowerDbm, tt.rx, 1e-9) || !near(res.NetGainDb, tt.netGain, 1e-9) || res.Status != tt.status {
+			t.Errorf("%s: OSNR %.3f dB, Rx %.3f dBm, gain %.1f dB, %s; want %.3f, %.3f, %.1f, %s",
+				tt.name, res.OSNRDb, res.RxPowerDbm, res.NetGainDb, res.Status, tt.osnr, tt.rx, tt.netGain, tt.status)
+		}
+	}
+}
+
+func TestCalculateOSNRLumpsEndLossAfterTheNoise(t *testing.T) {
+	// Receiver-side loss lowers signal and noise alike
+	in := OSNRInputs{TxPowerDbm: 0, LinkLengthKm: 80, FiberAttDbPerKm: 0.25, Amplifiers: []model.Amplifier{{PositionKm: 80, GainDb: 20, NoiseFigureDb: 5}}}
+	base, _ := CalculateOSNR(in)
+	in.EndLossDb = 3
+	lossy, _ := CalculateOSNR(in)
+	if !near(lossy.OSNRDb, base.OSNRDb, 1e-9) || !near(lossy.RxPowerDbm, base.RxPowerDbm-3, 1e-9) {
+		t.Errorf("end loss changed OSNR %.3f → %.3f, Rx %.3f → %.3f", base.OSNRDb, lossy.OSNRDb, base.RxPowerDbm, lossy.RxPowerDbm)
+	}
+	if _, err := CalculateOSNR(OSNRInputs{LinkLengthKm: 80}); err == nil {
+		t.Error("no amplifiers accepted")
+	}
+}
@@ -0,0 +1,154 @@
+package calc
+
+import (
+	"errors"
+	"math"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define LPB status of a link whose noise terms put the error floor above the
+// reference BER: no received power closes it, so it has no meaningful margin
+const StatusUnbounded = "UNBOUNDED"
+
+// Define struct for the power penalty terms in dB
+type Penalties struct {
+	DispersionDb      float64
+	ExtinctionRatioDb float64
+	RINDb             float64
+	MPNDb             float64
+	ReflectionDb      float64
+	ChirpDb           float64
+	SBSDb             float64 // Set by the nonlinear threshold check
+	SPMDb             float64
+}
+
+// Define struct for penalty inputs. Terms in Supplied are used as given when
+// greater than zero; the others are computed from the link parameters when
+// those are present (reflection and chirp are supply-only).
+type PenaltyInputs struct {
+	BitrateGbps       float64
+	LinkLengthKm      float64
+	DispersionPsNmKm  float64 // Chromatic dispersion coefficient D
+	SpectralWidthNm   float64 // RMS source spectral width
+	ExtinctionRatioDb float64 // P1/P0
+	RINDbHz           float64 // Relative intensity noise, e.g. -130
+	MPNFactor         float64 // Mode partition coefficient k (0..1)
+	RefBER            float64 // BER the penalties are referred to (0 uses 1e-12)
+	Supplied          Penalties
+}
+
+// Define function to calculate the power penalties of a link
+func CalculatePenalties(input PenaltyInputs) (Penalties, error) {
+	// Check if inputs are valid
+	s := input.Supplied
+	for _, v := range []float64{s.DispersionDb, s.ExtinctionRatioDb, s.RINDb, s.MPNDb, s.ReflectionDb, s.ChirpDb} {
+		if v < 0 {
+			return Penalties{}, errors.New("Supplied penalties have to be zero or greater")
+		}
+	}
+	if input.SpectralWidthNm < 0 || input.ExtinctionRatioDb < 0 || input.MPNFactor < 0 || input.MPNFactor > 1 {
+		return Penalties{}, errors.New("Penalty parameters do not have valid values")
+	}
+	refBER := input.RefBER
+	if refBER <= 0 {
+		refBER = DefaultTargetBER
+	}
+	q := qFromBER(refBER)
+
+	// Dispersion-limited spread: ε = B·L·|D|·σλ (Gb/s · km · ps/(nm·km) · nm → 1e-3)
+	spread := input.BitrateGbps * input.LinkLengthKm * math.Abs(input.DispersionPsNmKm) * input.SpectralWidthNm * 1e-3
+
+	p := s
+	if p.DispersionDb == 0 && spread > 0 {
+		// δ_d = −5·log10(1 − (4ε)²)
+		p.DispersionDb = noisePenalty(4 * spread)
+	}
+	if p.ExtinctionRatioDb == 0 && input.ExtinctionRatioDb > 0 {
+		// δ_ex = 10·log10((r + 1) / (r − 1))
+		r := dbToLinear(input.ExtinctionRatioDb)
+		p.ExtinctionRatioDb = 10 * math.Log10((r+1)/(r-1))
+	}
+	if p.RINDb == 0 && input.RINDbHz < 0 && input.BitrateGbps > 0 {
+		// δ_RIN = −5·log10(1 − Q²·RIN·Δf), receiver bandwidth Δf = B/2
+		rI := math.Sqrt(dbToLinear(input.RINDbHz) * input.BitrateGbps * 1e9 / 2)
+		p.RINDb = noisePenalty(q * rI)
+	}
+	if p.MPNDb == 0 && input.MPNFactor > 0 && spread > 0 {
+		// r_mpn = k/√2 · (1 − exp(−(π·ε)²)), δ_mpn = −5·log10(1 − Q²·r_mpn²)
+		rMPN := input.MPNFactor / math.Sqrt2 * (1 - math.Exp(-math.Pow(math.Pi*spread, 2)))
+		p.MPNDb = noisePenalty(q * rMPN)
+	}
+	return p, nil
+}
+
+// Define method to list the penalty terms by output column
+func (p Penalties) terms() []struct {
+	name  string
+	value float64
+} {
+	return []struct {
+		name  string
+		value float64
+	}{
+		{"dispersion_penalty_db", p.DispersionDb},
+		{"er_penalty_db", p.ExtinctionRatioDb},
+		{"rin_penalty_db", p.RINDb},
+		{"mpn_penalty_db", p.MPNDb},
+		{"reflection_penalty_db", p.ReflectionDb},
+		{"chirp_penalty_db", p.ChirpDb},
+		{"sbs_penalty_db", p.SBSDb},
+		{"spm_penalty_db", p.SPMDb},
+	}
+}
+
+// Define method to sum the finite penalty terms; unbounded terms are left out
+// and reported by Unbounded instead
+func (p Penalties) TotalDb() float64 {
+	total := 0.0
+	for _, t := range p.terms() {
+		if !math.IsInf(t.value, 1) {
+			total += t.value
+		}
+	}
+	return total
+}
+
+// Define method to name the penalty terms without a finite value
+func (p Penalties) Unbounded() []string {
+	var names []string
+	for _, t := range p.terms() {
+		if math.IsInf(t.value, 1) {
+			names = append(names, t.name)
+		}
+	}
+	return names
+}
+
+// Define method to replace unbounded terms by zero for the result columns
+func (p Penalties) Finite() Penalties {
+	for _, v := range []*float64{&p.DispersionDb, &p.ExtinctionRatioDb, &p.RINDb, &p.MPNDb, &p.ReflectionDb, &p.ChirpDb, &p.SBSDb, &p.SPMDb} {
+		if math.IsInf(*v, 1) {
+			*v = 0
+		}
+	}
+	return p
+}
+
+// Define function to rank results by margin with unbounded links below any
+// finite margin, so they are picked as the worst channel or service
+func lpbRank(out model.LinkOutput) float64 {
+	if out.Unbounded() {
+		return math.Inf(-1)
+	}
+	return out.MarginDb
+}
+
+// Define helper for the −5·log10(1 − x²) penalty shared by the noise terms,
+// +Inf once the noise alone reaches the decision threshold
+func noisePenalty(x float64) float64 {
+	if x >= 1 {
+		return math.Inf(1)
+	}
+	return -5 * math.Log10(1-x*x)
+}
@@ -0,0 +1,83 @@
+package calc
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCalculatePenalties(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		name string
+		in   PenaltyInputs
+		want Penalties
+	}{
+		// ε = 2.5·20·17·0.1e-3 = 0.085: δ_d = −5·log10(1 − 0.34²)
+		{"dispersion", PenaltyInputs{BitrateGbps: 2.5, LinkLengthKm: 20, DispersionPsNmKm: 17, SpectralWidthNm: 0.1},
+			Penalties{DispersionDb: 0.266756}},
+		// Sign of D does not matter
+		{"negative dispersion", PenaltyInputs{BitrateGbps: 2.5, LinkLengthKm: 20, DispersionPsNmKm: -17, SpectralWidthNm: 0.1},
+			Penalties{DispersionDb: 0.266756}},
+		// 4ε = 1 exactly: the eye is closed
+		{"dispersion limit", PenaltyInputs{BitrateGbps: 2.5, LinkLengthKm: 20, DispersionPsNmKm: 17, SpectralWidthNm: 0.25 / 0.85},
+			Penalties{DispersionDb: inf}},
+		// r = 10: 10·log10(11/9); r = 10^0.6: 10·log10(4.98/2.98)
+		{"extinction ratio 10 dB", PenaltyInputs{ExtinctionRatioDb: 10}, Penalties{ExtinctionRatioDb: 0.871502}},
+		{"extinction ratio 6 dB", PenaltyInputs{ExtinctionRatioDb: 6}, Penalties{ExtinctionRatioDb: 2.229504}},
+		// RIN·Δf = 1e-13·5e9: x = Q·√5e-4 = 0.1573 at 1e-12, 0.1341 at 1e-9
+		{"rin", PenaltyInputs{BitrateGbps: 10, RINDbHz: -130}, Penalties{RINDb: 0.054402}},
+		{"rin at 1e-9", PenaltyInputs{BitrateGbps: 10, RINDbHz: -130, RefBER: 1e-9}, Penalties{RINDb: 0.039413}},
+		// x = 7.03·√(1e-11·5e9) = 1.57
+		{"rin limit", PenaltyInputs{BitrateGbps: 10, RINDbHz: -110}, Penalties{RINDb: inf}},
+		// r_mpn = 0.5/√2·(1 − exp(−(0.085π)²)) = 0.02433, Q·r_mpn = 0.1712
+		{"mpn", PenaltyInputs{BitrateGbps: 2.5, LinkLengthKm: 20, DispersionPsNmKm: 17, SpectralWidthNm: 0.1, MPNFactor: 0.5},
+			Penalties{DispersionDb: 0.266756, MPNDb: 0.064574}},
+		// ε = 0.17: dispersion stays finite at 4ε = 0.68, MPN reaches Q·r_mpn = 1.23
+		{"mpn limit", PenaltyInputs{BitrateGbps: 2.5, LinkLengthKm: 40, DispersionPsNmKm: 17, SpectralWidthNm: 0.1, MPNFactor: 1},
+			Penalties{DispersionDb: 1.347704, MPNDb: inf}},
+		// Supplied terms win over computed ones
+		{"supplied", PenaltyInputs{BitrateGbps: 2.5, LinkLengthKm: 20, DispersionPsNmKm: 17, SpectralWidthNm: 0.1, ExtinctionRatioDb: 10,
+			Supplied: Penalties{DispersionDb: 1.5, ReflectionDb: 0.2, ChirpDb: 0.3}},
+			Penalties{DispersionDb: 1.5, ExtinctionRatioDb: 0.871502, ReflectionDb: 0.2, ChirpDb: 0.3}},
+	}
+	for _, tt := range tests {
+		got, err := CalculatePenalties(tt.in)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		g := []float64{got.DispersionDb, got.ExtinctionRatioDb, got.RINDb, got.MPNDb, got.ReflectionDb, got.ChirpDb}
+		w := []float64{tt.want.DispersionDb, tt.want.ExtinctionRatioDb, tt.want.RINDb, tt.want.MPNDb, tt.want.ReflectionDb, tt.want.ChirpDb}
+		for i := range g {
+			if !(g[i] == w[i] || near(g[i], w[i], 1e-4)) {
+				t.Errorf("%s: penalties %+v; want %+v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestPenaltiesUnboundedTerms(t *testing.T) {
+	p := Penalties{DispersionDb: math.Inf(1), ExtinctionRatioDb: 0.8715, RINDb: 0.0544, MPNDb: math.Inf(1), SBSDb: 0.5}
+	if !near(p.TotalDb(), 1.4259, 1e-9) {
+		t.Errorf("total %.4f dB; want the finite terms, 1.4259 dB", p.TotalDb())
+	}
+	if got := p.Unbounded(); !reflect.DeepEqual(got, []string{"dispersion_penalty_db", "mpn_penalty_db"}) {
+		t.Errorf("unbounded %v", got)
+	}
+	if f := p.Finite(); f.DispersionDb != 0 || f.MPNDb != 0 || f.ExtinctionRatioDb != 0.8715 || len(f.Unbounded()) != 0 {
+		t.Errorf("finite %+v", f)
+	}
+}
+
+func TestCalculatePenaltiesRejectsBadInputs(t *testing.T) {
+	for _, in := range []PenaltyInputs{
+		{Supplied: Penalties{ChirpDb: -0.1}},
+		{SpectralWidthNm: -1},
+		{MPNFactor: 1.5},
+	} {
+		if _, err := CalculatePenalties(in); err == nil {
+			t.Errorf("%+v accepted", in)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package calc
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/fiber"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/phy"
+)
+
+// Define rise time of a 1 MHz·km modal bandwidth over 1 km: t = 0.44 / BW
+const ModalRiseTimeNsMHz = 440.0
+
+// Define struct for PHY support inputs
+type PHYInputs struct {
+	PHY           phy.PHY
+	FiberType     string // Catalog name, e.g. "OM3"
+	LinkLengthKm  float64
+	ChannelLossDb float64
+}
+
+// Define struct for PHY support outputs
+type PHYResults struct {
+	ReachM           float64
+	MaxChannelLossDb float64
+	Limit            string // What the link exceeds: fiber, reach, channel_loss
+	Status           string
+}
+
+// Define function to check a link against the operating range of an Ethernet PHY
+func CalculatePHY(input PHYInputs) (PHYResults, error) {
+	// Check if inputs are valid
+	if input.LinkLengthKm < 0 || input.ChannelLossDb < 0 {
+		return PHYResults{}, errors.New("PHY check needs a valid length and channel loss")
+	}
+	reach, ok := input.PHY.Reach[input.FiberType]
+	if !ok {
+		return PHYResults{Limit: "fiber", Status: "FAIL"}, nil
+	}
+
+	// Both the operating range and the channel insertion loss have to hold
+	var limits []string
+	if input.LinkLengthKm*1000 > reach.MaxLengthM {
+		limits = append(limits, "reach")
+	}
+	if input.ChannelLossDb > reach.ChannelLossDb {
+		limits = append(limits, "channel_loss")
+	}
+	return PHYResults{
+		ReachM:           reach.MaxLengthM,
+		MaxChannelLossDb: reach.ChannelLossDb,
+		Limit:            strings.Join(limits, "+"),
+		Status:           passFail(len(limits) == 0),
+	}, nil
+}
+
+// Define function to estimate the modal dispersion rise time of a multimode
+// link in ns, t_modal = 440 · L / BW; 0 for single-mode fiber
+func ModalRiseTimeNs(link model.LinkInput) float64 {
+	t, ok := fiber.Lookup(link.FiberType)
+	if !ok || !t.Multimode {
+		return 0
+	}
+	wavelength := link.WavelengthNm
+	if wavelength == 0 {
+		wavelength = 850
+		if p, ok := phy.Lookup(link.PHY); ok {
+			wavelength = p.WavelengthNm
+		}
+	}
+	bw := t.ModalBandwidthAt(wavelength)
+	if bw <= 0 {
+		return 0
+	}
+	return ModalRiseTimeNsMHz * link.FiberLengthKm / bw
+}
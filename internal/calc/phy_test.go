@@ -0,0 +1,62 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/phy"
+)
+
+func TestCalculatePHY(t *testing.T) {
+	sr, _ := phy.Lookup("10gbase-sr")
+	tests := []struct {
+		name   string
+		fiber  string
+		km     float64
+		lossDb float64
+		reachM float64
+		maxDb  float64
+		limit  string
+		status string
+	}{
+		{"OM3 inside", "OM3", 0.3, 2.6, 300, 2.6, "", "PASS"},
+		{"OM4 too long", "OM4", 0.45, 2.0, 400, 2.9, "reach", "FAIL"},
+		// OM1 and OM2 allow 1.6 and 1.8 dB
+		{"OM1 too lossy", "OM1", 0.03, 2.0, 33, 1.6, "channel_loss", "FAIL"},
+		{"OM2 both", "OM2", 0.1, 1.9, 82, 1.8, "reach+channel_loss", "FAIL"},
+		{"single-mode", "G.652", 0.1, 1, 0, 0, "fiber", "FAIL"},
+	}
+	for _, tt := range tests {
+		res, err := CalculatePHY(PHYInputs{PHY: sr, FiberType: tt.fiber, LinkLengthKm: tt.km, ChannelLossDb: tt.lossDb})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if res.ReachM != tt.reachM || res.MaxChannelLossDb != tt.maxDb || res.Limit != tt.limit || res.Status != tt.status {
+			t.Errorf("%s: %+v; want %g m, %g dB, limit %q, %s", tt.name, res, tt.reachM, tt.maxDb, tt.limit, tt.status)
+		}
+	}
+	if _, err := CalculatePHY(PHYInputs{PHY: sr, FiberType: "OM3", LinkLengthKm: -1}); err == nil {
+		t.Error("negative length accepted")
+	}
+}
+
+func TestModalRiseTimeNs(t *testing.T) {
+	// t = 440 · L / BW
+	tests := []struct {
+		name string
+		link model.LinkInput
+		want float64
+	}{
+		{"OM3 at 850 nm", model.LinkInput{FiberType: "OM3", FiberLengthKm: 0.3, WavelengthNm: 850}, 0.066},
+		{"OM1 from the PHY wavelength", model.LinkInput{FiberType: "OM1", FiberLengthKm: 0.275, PHY: "1000base-sx"}, 0.605},
+		{"OM2 at 1300 nm", model.LinkInput{FiberType: "OM2", FiberLengthKm: 0.55, WavelengthNm: 1300}, 0.484},
+		// 4700 − 50/103 · 2230 = 3617.48 MHz·km at 900 nm
+		{"OM5 between 850 and 953 nm", model.LinkInput{FiberType: "OM5", FiberLengthKm: 0.1, WavelengthNm: 900}, 0.012163},
+		{"single-mode", model.LinkInput{FiberType: "G.652.D", FiberLengthKm: 10, WavelengthNm: 1310}, 0},
+	}
+	for _, tt := range tests {
+		if got := ModalRiseTimeNs(tt.link); !near(got, tt.want, 1e-6) {
+			t.Errorf("%s: %.6f ns; want %.6f", tt.name, got, tt.want)
+		}
+	}
+}
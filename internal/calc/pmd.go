@@ -0,0 +1,44 @@
+package calc
+
+import (
+	"errors"
+	"math"
+)
+
+// Define default PMD limit: mean DGD up to 10% of the bit period
+const DefaultPMDBitFraction = 0.1
+
+// Define struct for PMD inputs
+type PMDInputs struct {
+	BitrateGbps  float64
+	LinkLengthKm float64
+	PMDPsSqrtKm  float64 // PMD coefficient
+	BitFraction  float64 // Share of the bit period allowed (0 uses 0.1)
+}
+
+// Define struct for PMD outputs
+type PMDResults struct {
+	DGDPs    float64 // Mean differential group delay
+	MaxDGDPs float64
+	Status   string
+}
+
+// Define function to check the mean DGD of a link against the bit period
+func CalculatePMD(input PMDInputs) (PMDResults, error) {
+	// Check if inputs are valid
+	if input.BitrateGbps <= 0 {
+		return PMDResults{}, errors.New("Bitrate value does not have valid input")
+	}
+	if input.PMDPsSqrtKm < 0 || input.LinkLengthKm < 0 {
+		return PMDResults{}, errors.New("PMD coefficient does not have valid value")
+	}
+	fraction := input.BitFraction
+	if fraction <= 0 {
+		fraction = DefaultPMDBitFraction
+	}
+
+	// DGD = PMD · √L against fraction · T_bit (T_bit = 1000 / B ps)
+	dgd := input.PMDPsSqrtKm * math.Sqrt(input.LinkLengthKm)
+	maxDGD := fraction * 1000 / input.BitrateGbps
+	return PMDResults{DGDPs: dgd, MaxDGDPs: maxDGD, Status: passFail(dgd <= maxDGD)}, nil
+}
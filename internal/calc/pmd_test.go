@@ -0,0 +1,39 @@
+package calc
+
+import "testing"
+
+func TestCalculatePMD(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     PMDInputs
+		dgd    float64
+		maxDGD float64
+		status string
+	}{
+		// 0.5·√100 = 5 ps against 0.1·1000/10 = 10 ps
+		{"10G over 100 km", PMDInputs{BitrateGbps: 10, LinkLengthKm: 100, PMDPsSqrtKm: 0.5}, 5, 10, "PASS"},
+		// 0.5·√400 = 10 ps: the limit itself passes
+		{"10G at the limit", PMDInputs{BitrateGbps: 10, LinkLengthKm: 400, PMDPsSqrtKm: 0.5}, 10, 10, "PASS"},
+		// 0.2·√2500 = 10 ps against 0.1·1000/40 = 2.5 ps
+		{"40G over 2500 km", PMDInputs{BitrateGbps: 40, LinkLengthKm: 2500, PMDPsSqrtKm: 0.2}, 10, 2.5, "FAIL"},
+		// Same link with 15% of the bit period: 3.75 ps
+		{"40G with 15%", PMDInputs{BitrateGbps: 40, LinkLengthKm: 2500, PMDPsSqrtKm: 0.2, BitFraction: 0.15}, 10, 3.75, "FAIL"},
+		// 0.1·√2 = 0.1414 ps against 400 ps
+		{"2.5G short", PMDInputs{BitrateGbps: 2.5, LinkLengthKm: 2, PMDPsSqrtKm: 0.1}, 0.141421, 40, "PASS"},
+		{"zero length", PMDInputs{BitrateGbps: 10, PMDPsSqrtKm: 0.5}, 0, 10, "PASS"},
+	}
+	for _, tt := range tests {
+		res, err := CalculatePMD(tt.in)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !near(res.DGDPs, tt.dgd, 1e-6) || !near(res.MaxDGDPs, tt.maxDGD, 1e-9) || res.Status != tt.status {
+			t.Errorf("%s: DGD %.6f ps of %.2f ps %s; want %.6f of %.2f %s", tt.name, res.DGDPs, res.MaxDGDPs, res.Status, tt.dgd, tt.maxDGD, tt.status)
+		}
+	}
+	for _, in := range []PMDInputs{{PMDPsSqrtKm: 0.5, LinkLengthKm: 10}, {BitrateGbps: 10, PMDPsSqrtKm: -0.1}, {BitrateGbps: 10, LinkLengthKm: -1}} {
+		if _, err := CalculatePMD(in); err == nil {
+			t.Errorf("%+v accepted", in)
+		}
+	}
+}
@@ -11,6 +11,7 @@ type RTBInputs struct {
 	RxRiseTimeNs float64
 	FiberLengthKm float64
 	DispersionPerKm float64
+	ModalRiseTimeNs float64 // Modal dispersion of multimode fiber
 }
 
 // Define struct for RTB outputs
@@ -31,7 +32,7 @@ func CalculateRTB(input RTBInputs) (RTBResults, error){
 	}
 	// RTB Calculation logic
 	dispersion := input.FiberLengthKm * input.DispersionPerKm
-	systemRt := input.TxRiseTimeNs + input.RxRiseTimeNs + dispersion
+	systemRt := input.TxRiseTimeNs + input.RxRiseTimeNs + dispersion + input.ModalRiseTimeNs
 
 	// Allowed rise time calculation: Trx = 0.7 / Bitrate
 	allowedRt := 0.7 / input.BitrateGbps * 1000 // Convert to ns
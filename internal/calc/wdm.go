@@ -0,0 +1,67 @@
+package calc
+
+import (
+	"math"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/fiber"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define wavelength the link attenuation refers to when wavelength_nm is empty
+const DefaultReferenceNm = 1550.0
+
+// Define function to build the single-channel link for one channel of a WDM
+// plan. The link's attenuation and dispersion hold at its wavelength_nm and
+// are carried over to the channel with the fiber type's spectral shape.
+func ChannelLink(link model.LinkInput, ch model.Channel) model.LinkInput {
+	ft := fiber.Default
+	if t, ok := fiber.Lookup(link.FiberType); ok {
+		ft = t
+	}
+	ref := link.WavelengthNm
+	if ref <= 0 {
+		ref = DefaultReferenceNm
+	}
+
+	out := link
+	out.Channels = nil
+	out.WavelengthNm = ch.WavelengthNm
+	out.FiberAttDbPerKm = math.Max(0, link.FiberAttDbPerKm+ft.AttenuationAt(ch.WavelengthNm)-ft.AttenuationAt(ref))
+	if link.DispersionPsNmKm != 0 {
+		out.DispersionPsNmKm = link.DispersionPsNmKm + ft.DispersionAt(ch.WavelengthNm) - ft.DispersionAt(ref)
+	}
+	return out
+}
+
+// Define function to evaluate every channel of a WDM link. The link result is
+// the worst channel by margin; the per-channel results are attached to it.
+func computeChannels(link model.LinkInput, opt RunnerOptions) (model.LinkOutput, error) {
+	var worst model.LinkOutput
+	channels := make([]model.ChannelOutput, len(link.Channels))
+	for i, ch := range link.Channels {
+		chLink := ChannelLink(link, ch)
+		out, err := Compute(chLink, opt)
+		if err != nil {
+			return model.LinkOutput{}, err
+		}
+		channels[i] = model.ChannelOutput{
+			Label:            ch.Label,
+			WavelengthNm:     ch.WavelengthNm,
+			FiberAttDbPerKm:  chLink.FiberAttDbPerKm,
+			DispersionPsNmKm: LinkDispersion(chLink),
+			TotalLossDb:      out.TotalLossDb,
+			PenaltyTotalDb:   out.PenaltyTotalDb,
+			RxPowerDbm:       out.RxPowerDbm,
+			MarginDb:         out.MarginDb,
+			LPBStatus:        out.LPBStatus,
+		}
+		if i == 0 || lpbRank(out) < lpbRank(worst) {
+			worst = out
+			worst.WorstChannel = ch.Label
+			worst.WorstChannelNm = ch.WavelengthNm
+		}
+	}
+	worst.ChannelCount = len(channels)
+	worst.Channels = channels
+	return worst, nil
+}
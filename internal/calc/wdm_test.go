@@ -0,0 +1,56 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+func TestChannelLink(t *testing.T) {
+	// G.652 shape: attenuation 0.18897 dB/km at 1550 nm, 0.30171 at 1310,
+	// 0.20466 at 1471, 0.19007 at C21 (1560.606 nm); dispersion
+	// 17.34928 ps/(nm·km) at 1550, −0.18442 at 1310, 17.61454 − 17 at C21
+	tests := []struct {
+		name    string
+		attRef  float64
+		refNm   float64
+		dispRef float64
+		nm      float64
+		att     float64
+		disp    float64
+	}{
+		{"O band from 1550", 0.22, 1550, 17, 1310, 0.332741, -0.533706},
+		{"CWDM from 1550", 0.22, 1550, 17, 1471, 0.235685, 12.073297},
+		{"C21 from the default reference", 0.22, 0, 17, 1560.606236, 0.221096, 17.614541},
+		// Dispersion left unset stays unset
+		{"no dispersion", 0.22, 1550, 0, 1310, 0.332741, 0},
+		// 0.01 + 0.18897 − 0.30171 < 0 is clamped
+		{"clamped", 0.01, 1310, 0, 1550, 0, 0},
+	}
+	for _, tt := range tests {
+		link := model.LinkInput{FiberType: "G.652.D", FiberAttDbPerKm: tt.attRef, WavelengthNm: tt.refNm, DispersionPsNmKm: tt.dispRef,
+			Channels: []model.Channel{{Label: "x", WavelengthNm: tt.nm}}}
+		got := ChannelLink(link, link.Channels[0])
+		if got.WavelengthNm != tt.nm || got.Channels != nil || !near(got.FiberAttDbPerKm, tt.att, 1e-6) || !near(got.DispersionPsNmKm, tt.disp, 1e-6) {
+			t.Errorf("%s: %g nm, %.6f dB/km, %.6f ps/(nm·km); want %g, %.6f, %.6f",
+				tt.name, got.WavelengthNm, got.FiberAttDbPerKm, got.DispersionPsNmKm, tt.nm, tt.att, tt.disp)
+		}
+	}
+}
+
+func TestComputeChannelsPicksTheWorstChannel(t *testing.T) {
+	// 60 km at 0.22 dB/km from 1550 nm: the 1311 nm channel loses 6.711 dB more than 1551 nm
+	link := model.LinkInput{LinkID: "W1", TXPowerDbm: 3, RXSensitivityDbm: -28, FiberLengthKm: 60, FiberAttDbPerKm: 0.22,
+		WavelengthNm: 1550, FiberType: "G.652.D",
+		Channels: []model.Channel{{Label: "1551", WavelengthNm: 1551}, {Label: "1311", WavelengthNm: 1311}, {Label: "1471", WavelengthNm: 1471}}}
+	out, err := Compute(link, RunnerOptions{}.WithDefaults())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.WorstChannel != "1311" || out.ChannelCount != 3 || len(out.Channels) != 3 {
+		t.Fatalf("worst channel %s of %d; want 1311 of 3", out.WorstChannel, out.ChannelCount)
+	}
+	if d := out.Channels[1].TotalLossDb - out.Channels[0].TotalLossDb; !near(d, 6.711, 1e-3) {
+		t.Errorf("1311 nm loses %.3f dB more than 1551 nm", d)
+	}
+}
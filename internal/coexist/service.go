@@ -0,0 +1,107 @@
+package coexist
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define directions of a PON service
+const (
+	Downstream = "down"
+	Upstream   = "up"
+)
+
+// Define struct for one direction of a service on the shared ODN
+type Path struct {
+	Direction        string
+	WavelengthNm     float64
+	TxPowerDbm       float64 // Minimum launch power
+	RxSensitivityDbm float64 // Minimum receiver input
+	OverloadDbm      float64 // Maximum receiver input
+}
+
+// Define struct for a service that can share an ODN through coexistence elements
+type Service struct {
+	Name        string
+	Standard    string
+	CExLossDb   float64 // Coexistence element / video combiner at the OLT side
+	WDM1rLossDb float64 // Wavelength blocking filter at the ONT side
+	Paths       []Path
+}
+
+// Define the built-in services: GPON class B+ (ITU-T G.984.2), XGS-PON class
+// N1 (ITU-T G.9807.1) and a 1550 nm RF video overlay (ITU-T J.186)
+var services = map[string]Service{
+	"gpon": {Name: "gpon", Standard: "ITU-T G.984.2 B+", CExLossDb: 1.0, Paths: []Path{
+		{Downstream, 1490, 1.5, -27, -8},
+		{Upstream, 1310, 0.5, -28, -8},
+	}},
+	"xgs-pon": {Name: "xgs-pon", Standard: "ITU-T G.9807.1 N1", CExLossDb: 1.0, Paths: []Path{
+		{Downstream, 1577, 2, -28, -9},
+		{Upstream, 1270, 4, -26, -5},
+	}},
+	"rf-video": {Name: "rf-video", Standard: "ITU-T J.186", CExLossDb: 1.0, Paths: []Path{
+		{Downstream, 1550, 20, -6, 2},
+	}},
+}
+
+// Define the per-link parameters that override a catalog value
+var ParamNames = []string{"cex", "wdm1r", "down_tx", "down_rx", "down_overload", "up_tx", "up_rx", "up_overload"}
+
+// Define function to look up a service by name (case-insensitive)
+func Lookup(name string) (Service, bool) {
+	s, ok := services[strings.ToLower(strings.TrimSpace(name))]
+	return s, ok
+}
+
+// Define function to list available service names
+func Names() []string {
+	names := make([]string, 0, len(services))
+	for n := range services {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Define function to resolve a link's service entry against the catalog,
+// applying its parameter overrides
+func Resolve(svc model.Service) (Service, error) {
+	s, ok := Lookup(svc.Name)
+	if !ok {
+		return Service{}, errors.New("Unknown service " + svc.Name + " (available: " + strings.Join(Names(), ", ") + ")")
+	}
+	s.Paths = append([]Path(nil), s.Paths...)
+	for key, v := range svc.Params {
+		switch key {
+		case "cex":
+			s.CExLossDb = v
+			continue
+		case "wdm1r":
+			s.WDM1rLossDb = v
+			continue
+		}
+		dir, field, _ := strings.Cut(key, "_")
+		found := false
+		for i := range s.Paths {
+			if s.Paths[i].Direction != dir {
+				continue
+			}
+			switch field {
+			case "tx":
+				s.Paths[i].TxPowerDbm, found = v, true
+			case "rx":
+				s.Paths[i].RxSensitivityDbm, found = v, true
+			case "overload":
+				s.Paths[i].OverloadDbm, found = v, true
+			}
+		}
+		if !found {
+			return Service{}, errors.New("Service " + s.Name + " has no parameter " + key)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,26 @@
+package coexist
+
+import (
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+func TestResolve(t *testing.T) {
+	s, err := Resolve(model.Service{Name: "XGS-PON", Params: map[string]float64{"cex": 1.5, "wdm1r": 0.7, "up_tx": 5, "down_overload": -10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.CExLossDb != 1.5 || s.WDM1rLossDb != 0.7 || s.Paths[1].TxPowerDbm != 5 || s.Paths[0].OverloadDbm != -10 || s.Paths[0].TxPowerDbm != 2 {
+		t.Errorf("resolved %+v", s)
+	}
+	// The catalog entry is left alone
+	if c, _ := Lookup("xgs-pon"); c.Paths[1].TxPowerDbm != 4 || c.CExLossDb != 1 {
+		t.Errorf("catalog changed: %+v", c)
+	}
+	for _, svc := range []model.Service{{Name: "epon"}, {Name: "rf-video", Params: map[string]float64{"up_tx": 3}}, {Name: "gpon", Params: map[string]float64{"down_gain": 1}}} {
+		if _, err := Resolve(svc); err == nil {
+			t.Errorf("%+v accepted", svc)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package compliance
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define struct for a single failed clause
+type Violation struct {
+	Clause  string
+	Field   string
+	Value   float64
+	Message string
+}
+
+// Define struct for the compliance verdict of one link
+type Verdict struct {
+	LinkID     string
+	Scenario   string
+	Profile    string
+	Compliant  bool
+	Violations []Violation
+}
+
+// Define function to return the verdict as a status string
+func (v Verdict) Status() string {
+	if v.Compliant {
+		return "PASS"
+	}
+	return "FAIL"
+}
+
+// Define function to return the first failed clause, empty when compliant
+func (v Verdict) Clause() string {
+	if len(v.Violations) == 0 {
+		return ""
+	}
+	return v.Violations[0].Clause
+}
+
+// Define function to check links against a profile. Differential reach is
+// evaluated per scenario across links sharing a splitter_id.
+func Check(links []model.LinkInput, p Profile) ([]Verdict, error) {
+	verdicts := make([]Verdict, len(links))
+	for i, link := range links {
+		v := Verdict{LinkID: link.LinkID, Scenario: link.Scenario, Profile: p.Name}
+
+		// ODN loss range
+		odnLossDb := ODNLossDb(link)
+		if p.MaxODNLossDb > 0 && odnLossDb > p.MaxODNLossDb {
+			v.Violations = append(v.Violations, Violation{
+				Clause: p.LossClause, Field: "odn_loss_db", Value: odnLossDb,
+				Message: "ODN loss " + format(odnLossDb) + " dB above maximum " + format(p.MaxODNLossDb) + " dB",
+			})
+		}
+		if p.MinODNLossDb > 0 && odnLossDb < p.MinODNLossDb {
+			v.Violations = append(v.Violations, Violation{
+				Clause: p.LossClause, Field: "odn_loss_db", Value: odnLossDb,
+				Message: "ODN loss " + format(odnLossDb) + " dB below minimum " + format(p.MinODNLossDb) + " dB",
+			})
+		}
+
+		// Fiber distance
+		if p.MaxFiberDistanceKm > 0 && link.FiberLengthKm > p.MaxFiberDistanceKm {
+			v.Violations = append(v.Violations, Violation{
+				Clause: p.DistanceClause, Field: "fiber_length_km", Value: link.FiberLengthKm,
+				Message: "Fiber distance " + format(link.FiberLengthKm) + " km above maximum " + format(p.MaxFiberDistanceKm) + " km",
+			})
+		}
+
+		// Logical split
+		if p.MaxLogicalSplit > 0 && link.SplitRatio > p.MaxLogicalSplit {
+			v.Violations = append(v.Violations, Violation{
+				Clause: p.SplitClause, Field: "split_ratio", Value: float64(link.SplitRatio),
+				Message: "Split 1:" + strconv.Itoa(link.SplitRatio) + " above maximum 1:" + strconv.Itoa(p.MaxLogicalSplit),
+			})
+		}
+		verdicts[i] = v
+	}
+
+	// Differential reach across links on the same splitter
+	if p.MaxDifferentialReachKm > 0 {
+		type key struct{ scenario, id string }
+		minLen := make(map[key]float64)
+		maxLen := make(map[key]float64)
+		for _, link := range links {
+			if link.SplitterID == "" {
+				continue
+			}
+			k := key{link.Scenario, link.SplitterID}
+			if _, ok := minLen[k]; !ok {
+				minLen[k], maxLen[k] = link.FiberLengthKm, link.FiberLengthKm
+			}
+			minLen[k] = math.Min(minLen[k], link.FiberLengthKm)
+			maxLen[k] = math.Max(maxLen[k], link.FiberLengthKm)
+		}
+		for i, link := range links {
+			if link.SplitterID == "" {
+				continue
+			}
+			k := key{link.Scenario, link.SplitterID}
+			diff := maxLen[k] - minLen[k]
+			if diff > p.MaxDifferentialReachKm {
+				verdicts[i].Violations = append(verdicts[i].Violations, Violation{
+					Clause: p.DifferentialClause, Field: "fiber_length_km", Value: diff,
+					Message: "Differential reach " + format(diff) + " km on splitter " + strconv.Quote(link.SplitterID) +
+						" above maximum " + format(p.MaxDifferentialReachKm) + " km",
+				})
+			}
+		}
+	}
+
+	for i := range verdicts {
+		verdicts[i].Compliant = len(verdicts[i].Violations) == 0
+	}
+	return verdicts, nil
+}
+
+// Define function to calculate the ODN loss of a link: the passive loss of the
+// fiber, connectors, splices and splitter between OLT and ONU. Mux/demux, OADM
+// and coexistence element losses sit outside the ODN and are not counted.
+func ODNLossDb(link model.LinkInput) float64 {
+	return link.FiberLengthKm*link.FiberAttDbPerKm +
+		float64(link.NConnectors)*link.ConnectorLossDb +
+		float64(link.NSplice)*link.SpliceLossDb +
+		link.SplitterLossDb
+}
+
+// Define function to report violations as row errors
+func ToRowErrors(links []model.LinkInput, verdicts []Verdict) []model.RowError {
+	var errs []model.RowError
+	for i, v := range verdicts {
+		for _, viol := range v.Violations {
+			errs = append(errs, model.RowError{
+				Row: links[i].RowNumber(i), Line: links[i].Line, Field: viol.Field, Value: format(viol.Value),
+				Message: v.Profile + " non-compliant, " + viol.Clause + ": " + viol.Message,
+			})
+		}
+	}
+	return errs
+}
+
+// Define helper to format values in messages
+func format(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1000)/1000, 'f', -1, 64)
+}
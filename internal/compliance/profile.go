@@ -0,0 +1,101 @@
+package compliance
+
+import (
+	"sort"
+	"strings"
+)
+
+// Define struct for a standards compliance profile. Zero limits are not checked.
+type Profile struct {
+	Name     string
+	Standard string
+
+	// ODN / channel insertion loss range
+	MinODNLossDb float64
+	MaxODNLossDb float64
+	LossClause   string
+
+	// Fiber distance limits
+	MaxFiberDistanceKm     float64
+	DistanceClause         string
+	MaxDifferentialReachKm float64
+	DifferentialClause     string
+
+	// Logical split limit
+	MaxLogicalSplit int
+	SplitClause     string
+}
+
+// Define GPON reach and split limits shared by all ODN classes (ITU-T G.984.1)
+func gpon(name string, minDb, maxDb float64, class string) Profile {
+	return Profile{
+		Name: name, Standard: "ITU-T G.984",
+		MinODNLossDb: minDb, MaxODNLossDb: maxDb,
+		LossClause:             "ITU-T G.984.2 ODN class " + class + " attenuation range",
+		MaxFiberDistanceKm:     20,
+		DistanceClause:         "ITU-T G.984.1 maximum physical reach",
+		MaxDifferentialReachKm: 20,
+		DifferentialClause:     "ITU-T G.984.1 maximum differential fibre distance",
+		MaxLogicalSplit:        128,
+		SplitClause:            "ITU-T G.984.1 maximum logical split ratio",
+	}
+}
+
+// Define XGS-PON limits shared by all power classes (ITU-T G.9807.1)
+func xgspon(name string, minDb, maxDb float64, class string) Profile {
+	return Profile{
+		Name: name, Standard: "ITU-T G.9807.1",
+		MinODNLossDb: minDb, MaxODNLossDb: maxDb,
+		LossClause:             "ITU-T G.9807.1 optical path loss class " + class,
+		MaxFiberDistanceKm:     40,
+		DistanceClause:         "ITU-T G.9807.1 maximum fibre distance",
+		MaxDifferentialReachKm: 40,
+		DifferentialClause:     "ITU-T G.9807.1 maximum differential fibre distance",
+		MaxLogicalSplit:        256,
+		SplitClause:            "ITU-T G.9807.1 maximum logical split ratio",
+	}
+}
+
+// Define EPON limits (IEEE 802.3ah clause 60 PMDs)
+func epon(name string, minDb, maxDb, reachKm float64, pmd string) Profile {
+	return Profile{
+		Name: name, Standard: "IEEE 802.3ah",
+		MinODNLossDb: minDb, MaxODNLossDb: maxDb,
+		LossClause:         "IEEE 802.3 clause 60 " + pmd + " channel insertion loss",
+		MaxFiberDistanceKm: reachKm,
+		DistanceClause:     "IEEE 802.3 clause 60 " + pmd + " operating distance",
+		MaxLogicalSplit:    16,
+		SplitClause:        "IEEE 802.3 clause 60 " + pmd + " split ratio",
+	}
+}
+
+// Define the built-in profiles
+var profiles = map[string]Profile{
+	"gpon-a":     gpon("gpon-a", 5, 20, "A"),
+	"gpon-b":     gpon("gpon-b", 10, 25, "B"),
+	"gpon-b+":    gpon("gpon-b+", 13, 28, "B+"),
+	"gpon-c":     gpon("gpon-c", 15, 30, "C"),
+	"gpon-c+":    gpon("gpon-c+", 17, 32, "C+"),
+	"xgs-pon-n1": xgspon("xgs-pon-n1", 14, 29, "N1"),
+	"xgs-pon-n2": xgspon("xgs-pon-n2", 16, 31, "N2"),
+	"xgs-pon-e1": xgspon("xgs-pon-e1", 18, 33, "E1"),
+	"xgs-pon-e2": xgspon("xgs-pon-e2", 20, 35, "E2"),
+	"epon-px10":  epon("epon-px10", 5, 20, 10, "1000BASE-PX10"),
+	"epon-px20":  epon("epon-px20", 10, 24, 20, "1000BASE-PX20"),
+}
+
+// Define function to look up a profile by name (case-insensitive)
+func Lookup(name string) (Profile, bool) {
+	p, ok := profiles[strings.ToLower(strings.TrimSpace(name))]
+	return p, ok
+}
+
+// Define function to list available profile names
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+	for n := range profiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/cable"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/sweep"
+	"gopkg.in/yaml.v3"
+)
+
+// Define struct for a run configuration shared by validate, run and sweep
+type Config struct {
+	Input      string           `yaml:"input,omitempty"`
+	Output     string           `yaml:"output,omitempty"`
+	CSV        CSVConfig        `yaml:"csv"`
+	Runner     RunnerConfig     `yaml:"runner"`
+	Validation ValidationConfig `yaml:"validation"`
+	Sweep      SweepConfig      `yaml:"sweep,omitempty"`
+	Profile    string           `yaml:"profile,omitempty"`
+
+	// Cable types for margin allowances, added to or replacing the built-in ones
+	Cables map[string]cable.Allowances `yaml:"cables,omitempty"`
+}
+
+// Define struct for CSV input and output options
+type CSVConfig struct {
+	Delimiter    string `yaml:"delimiter,omitempty"`
+	DecimalComma bool   `yaml:"decimal_comma"`
+	OutDelimiter string `yaml:"out_delimiter,omitempty"` // Empty uses ';' for decimal-comma locales, ',' otherwise
+	Precision    int    `yaml:"precision"`
+	Locale       string `yaml:"locale,omitempty"` // Output number format, e.g. "id-ID" or "de"
+}
+
+// Define struct for calculation runner options
+type RunnerConfig struct {
+	RTB               bool    `yaml:"rtb"`
+	BitrateGbps       float64 `yaml:"bitrate_gbps"`
+	TxRiseTimeNs      float64 `yaml:"tx_rise_time_ns"`
+	RxRiseTimeNs      float64 `yaml:"rx_rise_time_ns"`
+	DispersionNsPerKm float64 `yaml:"dispersion_ns_per_km"`
+	FEC               string  `yaml:"fec,omitempty"`
+	TargetBER         float64 `yaml:"target_ber,omitempty"`
+	PMDBitFraction    float64 `yaml:"pmd_bit_fraction,omitempty"`
+}
+
+// Define struct for validation options
+type ValidationConfig struct {
+	Rules              string   `yaml:"rules,omitempty"`
+	Scenarios          []string `yaml:"scenarios,omitempty"`
+	MaxFiberAttDbPerKm float64  `yaml:"max_fiber_att_db_per_km,omitempty"`
+	SkipPlausibility   bool     `yaml:"skip_plausibility"`
+	Report             string   `yaml:"report,omitempty"`
+}
+
+// Define struct for sweep options
+type SweepConfig struct {
+	Variations []VariationConfig `yaml:"variations,omitempty"`
+}
+
+// Define struct for one sweep variation
+type VariationConfig struct {
+	Field  string    `yaml:"field"`
+	Values []float64 `yaml:"values,flow"`
+}
+
+// Define function to return the built-in defaults, the same values the CLI flags use
+func Default() Config {
+	return Config{
+		CSV: CSVConfig{Precision: 6}, // Matches io.DefaultPrecision
+		Runner: RunnerConfig{
+			TxRiseTimeNs: 0.2,
+			RxRiseTimeNs: 0.2,
+		},
+	}
+}
+
+// Define function to load a configuration file over the defaults.
+// Keys missing from the file keep their default value; metadata files
+// written by WriteMetadata are accepted as well.
+func Load(path string) (Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Default(), errors.New("Failed to open config file: " + err.Error())
+	}
+	meta := metadata{Config: Default()}
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&meta); err != nil && !errors.Is(err, io.EOF) {
+		return Default(), errors.New("Failed to parse config file: " + err.Error())
+	}
+	if _, ok := calc.LookupFEC(meta.Runner.FEC); !ok {
+		return Default(), errors.New("Unknown FEC code in config file: " + meta.Runner.FEC)
+	}
+	for name, a := range meta.Cables {
+		if a.RepairSplicesPerKm < 0 || a.TempDriftDbPerKm < 0 || a.AgingDb < 0 || a.CableCutDb < 0 {
+			return Default(), errors.New("Cable type " + name + " in config file has a negative allowance")
+		}
+	}
+	return meta.Config, nil
+}
+
+// Define function to convert the runner section into calculation options. An
+// unset bitrate falls back to calc.DefaultRTBBitrateGbps only with rtb enabled.
+func (c Config) RunnerOptions() calc.RunnerOptions {
+	return c.RawRunnerOptions().WithDefaults()
+}
+
+// Define function to convert the runner section as written, without the
+// defaults of enabled checks. Servers merge request options over it and fill
+// the defaults once the request has settled which checks run.
+func (c Config) RawRunnerOptions() calc.RunnerOptions {
+	return calc.RunnerOptions{
+		EnableRTB:       c.Runner.RTB,
+		BitrateGbps:     c.Runner.BitrateGbps,
+		TxRiseTimeNs:    c.Runner.TxRiseTimeNs,
+		RxRiseTimeNs:    c.Runner.RxRiseTimeNs,
+		DispersionPerKm: c.Runner.DispersionNsPerKm,
+		FEC:             c.Runner.FEC,
+		TargetBER:       c.Runner.TargetBER,
+		PMDBitFraction:  c.Runner.PMDBitFraction,
+		Cables:          c.Cables,
+	}
+}
+
+// Define function to convert the sweep section into variations
+func (c Config) Variations() []sweep.Variation {
+	vars := make([]sweep.Variation, len(c.Sweep.Variations))
+	for i, v := range c.Sweep.Variations {
+		vars[i] = sweep.Variation{Field: v.Field, Values: v.Values}
+	}
+	return vars
+}
+
+// Define struct for the metadata written next to an output file
+type metadata struct {
+	Command     string `yaml:"command,omitempty"`
+	GeneratedAt string `yaml:"generated_at,omitempty"`
+	Config      `yaml:",inline"`
+}
+
+// Define function to echo the resolved configuration next to an output file.
+// The file can be passed back with --config to reproduce the run.
+func WriteMetadata(outputPath, command string, cfg Config) (string, error) {
+	path := outputPath + ".meta.yaml"
+	raw, err := yaml.Marshal(metadata{
+		Command:     command,
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Config:      cfg,
+	})
+	if err != nil {
+		return "", errors.New("Failed to encode run metadata: " + err.Error())
+	}
+	header := []byte("# Resolved configuration, rerun with: fo " + command + " --config " + path + "\n")
+	if err := os.WriteFile(path, append(header, raw...), 0o644); err != nil {
+		return "", errors.New("Failed to write run metadata: " + err.Error())
+	}
+	return path, nil
+}
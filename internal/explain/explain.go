@@ -0,0 +1,395 @@
+package explain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/coexist"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/phy"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/sweep"
+)
+
+// Define struct for one derivation step
+type Step struct {
+	Name    string  `json:"name"`
+	Formula string  `json:"formula"`
+	Trace   string  `json:"trace"` // Formula with the numbers substituted
+	Value   float64 `json:"value"`
+	Unit    string  `json:"unit"`
+}
+
+// Define unit of a step without a finite value, e.g. a penalty whose noise
+// reaches the decision threshold; its value is reported as 0
+const UnitUnbounded = "unbounded"
+
+// Define struct for one change that would make the link pass
+type Fix struct {
+	Field  string  `json:"field"`
+	Change float64 `json:"change"` // Signed change to apply to the field
+	Target float64 `json:"target"`
+	Text   string  `json:"text"`
+}
+
+// Define struct for the explanation of one link
+type Explanation struct {
+	Link       model.LinkInput       `json:"link"`
+	Result     model.LinkOutput      `json:"result"`
+	LPB        []Step                `json:"lpb"`
+	RTB        []Step                `json:"rtb,omitempty"`
+	PMD        []Step                `json:"pmd,omitempty"`
+	Nonlinear  []Step                `json:"nonlinear,omitempty"`
+	PHY        []Step                `json:"phy,omitempty"`
+	ORL        []Step                `json:"orl,omitempty"`
+	OSNR       []Step                `json:"osnr,omitempty"`
+	Penalties  []Step                `json:"penalties,omitempty"`
+	Allowances []Step                `json:"allowances,omitempty"`
+	Channels   []model.ChannelOutput `json:"channels,omitempty"` // WDM links explain their worst channel
+	Services   []model.ServiceOutput `json:"services,omitempty"` // Coexistence links explain their limiting service
+	BER        []Step                `json:"ber,omitempty"`
+	Fixes      []Fix                 `json:"fixes,omitempty"`    // Set when the link fails
+	Limits     []Fix                 `json:"headroom,omitempty"` // Set when the link passes
+}
+
+// Define fields the fix search tries, in the order they are suggested
+var fixFields = []string{"fiber_length_km", "n_connector", "n_splice", "splitter_loss_db", "other_loss_db"}
+
+// Define function to trace the LPB and RTB derivation of a link and look
+// for single-field changes that bring the margin back to zero
+func Explain(link model.LinkInput, opt calc.RunnerOptions) (Explanation, error) {
+	res, err := calc.Compute(link, opt)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	// WDM links: trace the worst channel as a single-wavelength link
+	if len(link.Channels) > 0 {
+		for _, ch := range link.Channels {
+			if ch.Label == res.WorstChannel {
+				e, err := Explain(calc.ChannelLink(link, ch), opt)
+				e.Result, e.Channels = res, res.Channels
+				return e, err
+			}
+		}
+	}
+
+	// Coexistence links: trace the limiting service and direction
+	for _, entry := range link.Services {
+		svc, err := coexist.Resolve(entry)
+		if err != nil {
+			return Explanation{}, err
+		}
+		for _, path := range svc.Paths {
+			if svc.Name+"/"+path.Direction == res.LimitingService {
+				e, err := Explain(calc.ServiceLink(link, svc, path), opt)
+				e.Result, e.Services = res, res.Services
+				return e, err
+			}
+		}
+	}
+	e := Explanation{Link: link, Result: res}
+
+	// Received power, raised by the net gain of any amplifier chain
+	rxStep := Step{"Received power", "P_rx = P_tx − L_total", f(link.TXPowerDbm) + " dBm − " + f(res.TotalLossDb) + " dB", res.RxPowerDbm, "dBm"}
+	if len(link.Amplifiers) > 0 {
+		rxStep.Formula = "P_rx = P_tx − L_total + G_net"
+		rxStep.Trace += " + " + f(res.AmplifierGainDb) + " dB"
+	}
+
+	// Total loss, with the mux/demux and OADM ports of a WDM link
+	totalStep := Step{"Total loss", "L_total = L_fiber + L_conn + L_splice + L_splitter + L_other",
+		f(res.FiberLossDb) + " + " + f(res.ConnectorTotalDb) + " + " + f(res.SpliceTotalDb) + " + " + f(link.SplitterLossDb) + " + " + f(link.OtherLossDb),
+		res.TotalLossDb, "dB"}
+	if res.WDMLossDb > 0 {
+		totalStep.Formula += " + L_wdm"
+		totalStep.Trace += " + " + f(res.WDMLossDb)
+	}
+
+	// Link power budget, in the order CalculateLPB evaluates it
+	e.LPB = []Step{
+		{"Fiber loss", "L_fiber = α × L", f(link.FiberAttDbPerKm) + " dB/km × " + f(link.FiberLengthKm) + " km", res.FiberLossDb, "dB"},
+		{"Connector loss", "L_conn = n_conn × a_conn", strconv.Itoa(link.NConnectors) + " × " + f(link.ConnectorLossDb) + " dB", res.ConnectorTotalDb, "dB"},
+		{"Splice loss", "L_splice = n_splice × a_splice", strconv.Itoa(link.NSplice) + " × " + f(link.SpliceLossDb) + " dB", res.SpliceTotalDb, "dB"},
+		totalStep,
+		rxStep,
+		{"Margin", "M = P_rx − S_rx − M_sys", f(res.RxPowerDbm) + " dBm − (" + f(link.RXSensitivityDbm) + " dBm) − " + f(link.SystemMarginDb) + " dB", res.MarginDb, "dB"},
+	}
+
+	// Margin allowances of the cable type, reserved next to the system margin
+	if res.AllowanceTotalDb > 0 {
+		margin := &e.LPB[len(e.LPB)-1]
+		margin.Formula += " − M_alw"
+		margin.Trace += " − " + f(res.AllowanceTotalDb) + " dB"
+		e.Allowances = allowanceSteps(link, res, opt)
+	}
+
+	// Power penalties reserved next to the system margin
+	if res.PenaltyTotalDb > 0 {
+		margin := &e.LPB[len(e.LPB)-1]
+		margin.Formula += " − P_pen"
+		margin.Trace += " − " + f(res.PenaltyTotalDb) + " dB"
+		e.Penalties = penaltySteps(link, res, opt)
+	}
+
+	// Rise time budget, with modal dispersion on multimode fiber
+	if opt.EnableRTB {
+		dispersion := link.FiberLengthKm * opt.DispersionPerKm
+		bitrate := calc.LinkBitrateGbps(link, opt)
+		system := Step{"System rise time", "t_sys = t_tx + t_rx + t_disp", f(opt.TxRiseTimeNs) + " + " + f(opt.RxRiseTimeNs) + " + " + f(dispersion) + " ns", res.SystemRiseTimeNs, "ns"}
+		e.RTB = []Step{{"Fiber dispersion", "t_disp = D × L", f(opt.DispersionPerKm) + " ns/km × " + f(link.FiberLengthKm) + " km", dispersion, "ns"}}
+		if res.ModalRiseTimeNs > 0 {
+			bw := calc.ModalRiseTimeNsMHz * link.FiberLengthKm / res.ModalRiseTimeNs
+			e.RTB = append(e.RTB, Step{"Modal dispersion", "t_modal = 440 × L / BW_modal", "440 × " + f(link.FiberLengthKm) + " km / " + f(bw) + " MHz·km", res.ModalRiseTimeNs, "ns"})
+			system.Formula += " + t_modal"
+			system.Trace = f(opt.TxRiseTimeNs) + " + " + f(opt.RxRiseTimeNs) + " + " + f(dispersion) + " + " + f(res.ModalRiseTimeNs) + " ns"
+		}
+		e.RTB = append(e.RTB, system,
+			Step{"Allowed rise time", "t_max = 0.7 / B", "0.7 / " + f(bitrate) + " Gbps × 1000", res.AllowedRiseTimeNs, "ns"})
+	}
+
+	// Multimode Ethernet PHY against its IEEE 802.3 operating range
+	if res.PHYStatus != "" {
+		e.PHY = phySteps(link, res)
+	}
+
+	// OSNR at the receiver, ASE accumulated along the amplifier chain
+	if len(link.Amplifiers) > 0 {
+		e.OSNR = []Step{{"OSNR", "OSNR = P_sig / Σ(NF · h·ν·B_ref · G · L_after)", "0.1 nm reference bandwidth, " + strconv.Itoa(len(link.Amplifiers)) + " amplifier(s)", res.OSNRDb, "dB"}}
+	}
+
+	// Polarization mode dispersion
+	if res.PMDStatus != "" {
+		fraction := opt.PMDBitFraction
+		if fraction <= 0 {
+			fraction = calc.DefaultPMDBitFraction
+		}
+		e.PMD = []Step{
+			{"Mean DGD", "DGD = PMD × √L", f(calc.LinkPMD(link)) + " ps/√km × √" + f(link.FiberLengthKm) + " km", res.DGDPs, "ps"},
+			{"Allowed DGD", "DGD_max = k × 1000 / B", f(fraction) + " × 1000 / " + f(calc.LinkBitrateGbps(link, opt)) + " Gbps", res.MaxDGDPs, "ps"},
+		}
+	}
+
+	// Nonlinear thresholds against the launch power of each span
+	if res.NonlinearStatus != "" {
+		if in, err := calc.LinkNonlinear(link); err == nil {
+			e.Nonlinear = nonlinearSteps(in, res)
+		}
+	}
+
+	// Optical return loss at the transmitter
+	if res.ReflectionStatus != "" {
+		in := calc.LinkORL(link)
+		orl, _ := calc.CalculateORL(in)
+		connectors := "per-link value"
+		if link.ConnectorReflectanceDb == 0 {
+			connectors = strings.ToUpper(link.ConnectorType) + " polish"
+			if link.ConnectorType == "" {
+				connectors = strings.ToUpper(calc.DefaultConnectorType) + " polish (default)"
+			}
+		}
+		e.ORL = []Step{
+			{"Connector reflectance", "R_c", connectors, in.ConnectorReflectanceDb, "dB"},
+			{"Splice reflectance", "R_s", strconv.Itoa(link.NSplice) + " splice(s)", in.SpliceReflectanceDb, "dB"},
+			{"Rayleigh backscatter", "RL_bs = −10·log10(S/2 · (1 − 10^(−2·α·L/10)))", "S " + strconv.FormatFloat(calc.BackscatterCapture, 'g', -1, 64) + ", " + f(link.FiberAttDbPerKm) + " dB/km × " + f(link.FiberLengthKm) + " km", orl.BackscatterDb, "dB"},
+			{"Optical return loss", "ORL = −10·log10(Σ 10^((R_i − 2·A_i)/10) + 10^(−RL_bs/10))", strconv.Itoa(link.NConnectors) + " connector(s), " + strconv.Itoa(link.NSplice) + " splice(s), worst " + res.WorstReflectionEvent, res.ORLDb, "dB"},
+		}
+	}
+
+	// Q-factor and BER from the receiver reference point
+	if res.BERStatus != "" {
+		slope := link.QSlope
+		if slope <= 0 {
+			slope = calc.DefaultQSlope
+		}
+		qRef := math.Sqrt2 * math.Erfcinv(2*link.SensitivityBER)
+		e.BER = []Step{
+			{"Reference Q", "Q_ref = √2 · erfcinv(2 · BER_ref)", "√2 · erfcinv(2 × " + strconv.FormatFloat(link.SensitivityBER, 'g', 3, 64) + ")", qRef, ""},
+			{"Q-factor", "Q = Q_ref · 10^(s · (P_rx − S_rx) / 10)", f(qRef) + " · 10^(" + f(slope) + " × (" + f(res.RxPowerDbm) + " − (" + f(link.RXSensitivityDbm) + ")) / 10)", res.QFactor, ""},
+			{"Pre-FEC BER", "BER = ½ · erfc(Q / √2)", "½ · erfc(" + f(res.QFactor) + " / √2)", res.BER, ""},
+		}
+	}
+
+	// Suggest fixes for a failing budget, headroom for a passing one
+	for _, field := range fixFields {
+		sol, err := sweep.Solve(link, field, opt)
+		if err != nil {
+			continue // Field alone cannot close the gap, or does not affect margin
+		}
+		fix, ok := describe(field, sol)
+		if !ok {
+			continue
+		}
+		if res.MarginDb < 0 || res.Unbounded() {
+			e.Fixes = append(e.Fixes, fix)
+		} else {
+			e.Limits = append(e.Limits, fix)
+		}
+	}
+	if res.MarginDb < 0 {
+		deficit := -res.MarginDb
+		e.Fixes = append(e.Fixes, Fix{
+			Field: "tx_power_dbm", Change: deficit, Target: link.TXPowerDbm + deficit,
+			Text: "raise Tx power by " + f(deficit) + " dB (to " + f(link.TXPowerDbm+deficit) + " dBm)",
+		})
+	}
+	if opt.EnableRTB && !res.RTBStatus && link.FiberLengthKm > 0 {
+		// Chromatic and modal rise times both grow with length
+		perKm := opt.DispersionPerKm + res.ModalRiseTimeNs/link.FiberLengthKm
+		if maxLen := (res.AllowedRiseTimeNs - opt.TxRiseTimeNs - opt.RxRiseTimeNs) / perKm; perKm > 0 && maxLen > 0 {
+			e.Fixes = append(e.Fixes, Fix{
+				Field: "fiber_length_km", Change: maxLen - link.FiberLengthKm, Target: maxLen,
+				Text: "for RTB, reduce length by " + f(link.FiberLengthKm-maxLen) + " km (to " + f(maxLen) + " km)",
+			})
+		}
+	}
+	return e, nil
+}
+
+// Define function to turn a break-even solution into a readable change
+func describe(field string, sol sweep.Solution) (Fix, bool) {
+	switch field {
+	case "n_connector", "n_splice":
+		// Counts are whole numbers: the largest count that still passes
+		limit := math.Floor(sol.Limit)
+		change := limit - sol.Current
+		if change == 0 {
+			return Fix{}, false
+		}
+		noun := map[string]string{"n_connector": "connector", "n_splice": "splice"}[field]
+		n := int(math.Abs(change))
+		if n != 1 {
+			noun += "s"
+		}
+		text := "drop " + strconv.Itoa(n) + " " + noun
+		if change > 0 {
+			text = strconv.Itoa(n) + " more " + noun + " fit"
+		}
+		return Fix{Field: field, Change: change, Target: limit, Text: text + " (to " + strconv.Itoa(int(limit)) + ")"}, true
+	}
+	units := map[string]string{"fiber_length_km": " km", "splitter_loss_db": " dB", "other_loss_db": " dB"}
+	names := map[string]string{"fiber_length_km": "length", "splitter_loss_db": "splitter loss", "other_loss_db": "other loss"}
+	text := "reduce " + names[field] + " by "
+	if sol.Headroom > 0 {
+		text = names[field] + " can grow by "
+	}
+	return Fix{
+		Field: field, Change: sol.Headroom, Target: sol.Limit,
+		Text: text + f(math.Abs(sol.Headroom)) + units[field] + " (to " + f(sol.Limit) + units[field] + ")",
+	}, true
+}
+
+// Define helper to format numbers in traces
+func f(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1000)/1000, 'f', -1, 64)
+}
+
+// Define function to trace each non-zero penalty term, marking supplied values
+func penaltySteps(link model.LinkInput, res model.LinkOutput, opt calc.RunnerOptions) []Step {
+	bitrate := f(calc.LinkBitrateGbps(link, opt)) + " Gbps"
+	spread := bitrate + ", " + f(link.FiberLengthKm) + " km, |D| " + f(math.Abs(calc.LinkDispersion(link))) + " ps/(nm·km), σλ " + f(link.SpectralWidthNm) + " nm"
+	terms := []struct {
+		field    string
+		step     Step
+		supplied float64
+	}{
+		{"dispersion_penalty_db", Step{"Dispersion penalty", "δ_d = −5·log10(1 − (4·B·L·|D|·σλ)²)",
+			spread, res.DispersionPenaltyDb, "dB"}, link.DispersionPenaltyDb},
+		{"er_penalty_db", Step{"Extinction ratio penalty", "δ_ex = 10·log10((r + 1) / (r − 1))",
+			"r = " + f(link.ExtinctionRatioDb) + " dB", res.ExtinctionPenaltyDb, "dB"}, link.ExtinctionPenaltyDb},
+		{"rin_penalty_db", Step{"RIN penalty", "δ_RIN = −5·log10(1 − Q²·RIN·B/2)",
+			"RIN " + f(link.RINDbHz) + " dB/Hz, " + bitrate, res.RINPenaltyDb, "dB"}, link.RINPenaltyDb},
+		{"mpn_penalty_db", Step{"Mode partition penalty", "δ_mpn = −5·log10(1 − Q²·(k/√2 · (1 − e^−(π·B·L·|D|·σλ)²))²)",
+			"k " + f(link.MPNFactor) + ", " + spread, res.MPNPenaltyDb, "dB"}, link.MPNPenaltyDb},
+		{"reflection_penalty_db", Step{"Reflection penalty", "supplied", "reflection_penalty_db", res.ReflectionPenaltyDb, "dB"}, link.ReflectionPenaltyDb},
+		{"chirp_penalty_db", Step{"Chirp penalty", "supplied", "chirp_penalty_db", res.ChirpPenaltyDb, "dB"}, link.ChirpPenaltyDb},
+		{"sbs_penalty_db", Step{"SBS penalty", "δ_sbs = P_launch − P_th,SBS",
+			f(res.SBSPenaltyDb+res.SBSThresholdDbm) + " dBm − " + f(res.SBSThresholdDbm) + " dBm", res.SBSPenaltyDb, "dB"}, 0},
+		{"spm_penalty_db", Step{"SPM penalty", "δ_spm = 10·log10(φ_NL / 1 rad)",
+			"10·log10(" + f(res.NonlinearPhaseRad) + ")", res.SPMPenaltyDb, "dB"}, 0},
+	}
+	unbounded := make(map[string]bool)
+	for _, name := range res.UnboundedPenalties {
+		unbounded[name] = true
+	}
+	var steps []Step
+	for _, t := range terms {
+		if unbounded[t.field] {
+			t.step.Unit = UnitUnbounded
+		} else if t.step.Value == 0 {
+			continue
+		}
+		if t.supplied > 0 && t.step.Formula != "supplied" {
+			t.step.Formula = "supplied"
+			t.step.Trace = "per-link value"
+		}
+		steps = append(steps, t.step)
+	}
+	var total []string
+	for _, s := range steps {
+		if s.Unit != UnitUnbounded {
+			total = append(total, f(s.Value))
+		}
+	}
+	return append(steps, Step{"Total penalty", "P_pen = Σ δ", strings.Join(total, " + "), res.PenaltyTotalDb, "dB"})
+}
+
+// Define function to trace the margin allowances of a link
+func allowanceSteps(link model.LinkInput, res model.LinkOutput, opt calc.RunnerOptions) []Step {
+	a, _, _ := calc.LinkAllowances(link, opt)
+	spliceLoss := link.SpliceLossDb
+	if spliceLoss <= 0 {
+		spliceLoss = calc.DefaultRepairSpliceLossDb
+	}
+	source := func(override float64) string {
+		if override != 0 {
+			return "per-link value"
+		}
+		return "cable " + link.CableType
+	}
+	steps := []Step{
+		{"Repair allowance", "M_rep = r × L × a_splice", f(a.RepairSplicesPerKm) + " /km × " + f(link.FiberLengthKm) + " km × " + f(spliceLoss) + " dB", res.RepairAllowanceDb, "dB"},
+		{"Temperature allowance", "M_temp = Δα_T × L", f(a.TempDriftDbPerKm) + " dB/km × " + f(link.FiberLengthKm) + " km", res.TemperatureAllowanceDb, "dB"},
+		{"Aging allowance", "M_age", source(link.AgingMarginDb), res.AgingAllowanceDb, "dB"},
+		{"Cable-cut allowance", "M_cut", source(link.CableCutMarginDb), res.CableCutAllowanceDb, "dB"},
+	}
+	return append(steps, Step{"Total allowance", "M_alw = M_rep + M_temp + M_age + M_cut",
+		f(res.RepairAllowanceDb) + " + " + f(res.TemperatureAllowanceDb) + " + " + f(res.AgingAllowanceDb) + " + " + f(res.CableCutAllowanceDb),
+		res.AllowanceTotalDb, "dB"})
+}
+
+// Define function to trace the SBS and SPM thresholds of a link
+func nonlinearSteps(in calc.NonlinearInputs, res model.LinkOutput) []Step {
+	area := in.EffectiveAreaUm2
+	if area <= 0 {
+		area = calc.DefaultEffectiveAreaUm2
+	}
+	spans := 0
+	for _, s := range in.Spans {
+		if s.LengthKm > 0 {
+			spans++
+		}
+	}
+	return []Step{
+		{"Effective length", "L_eff = (1 − e^(−α·L)) / α", "α " + f(in.FiberAttDbPerKm) + " dB/km, limiting span", res.EffectiveLengthKm, "km"},
+		{"SBS threshold", "P_th = 21·K·A_eff / (g_B·L_eff) · (1 + Δν_s/Δν_B)",
+			"K " + f(calc.SBSPolarizationFactor) + ", A_eff " + f(area) + " µm², Δν_s " + f(in.LinewidthMHz) + " MHz, Δν_B " + f(calc.BrillouinBandwidthMHz) + " MHz",
+			res.SBSThresholdDbm, "dBm"},
+		{"Nonlinear phase", "φ_NL = Σ γ·P_launch·L_eff, γ = 2π·n2 / (λ·A_eff)", strconv.Itoa(spans) + " span(s)", res.NonlinearPhaseRad, "rad"},
+		{"SPM threshold", "P_th,SPM = 1 rad / (γ · Σ L_eff)", "per-span launch power", res.SPMThresholdDbm, "dBm"},
+	}
+}
+
+// Define function to trace a link against the operating range of its PHY
+func phySteps(link model.LinkInput, res model.LinkOutput) []Step {
+	p, _ := phy.Lookup(link.PHY)
+	fiberName := orNone(link.FiberType)
+	if res.PHYLimit == "fiber" {
+		return []Step{{"Operating range", p.Standard, p.Name + " is not specified over " + fiberName + " (" + strings.Join(p.FiberTypes(), ", ") + ")", 0, "m"}}
+	}
+	return []Step{
+		{"Operating range", p.Standard, p.Name + " over " + fiberName + ", link " + f(link.FiberLengthKm*1000) + " m", res.PHYReachM, "m"},
+		{"Channel insertion loss", "L_total ≤ L_channel,max", p.Name + " over " + fiberName + ", link " + f(res.TotalLossDb) + " dB", res.PHYMaxChannelLossDb, "dB"},
+	}
+}
@@ -0,0 +1,198 @@
+package explain
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Define function to print an explanation as plain text
+func Write(w io.Writer, e Explanation) error {
+	var b strings.Builder
+	l, r := e.Link, e.Result
+	fmt.Fprintf(&b, "Link %s (scenario %s)\n\n", l.LinkID, l.Scenario)
+
+	// Inputs
+	b.WriteString("Inputs\n")
+	inputs := [][2]string{
+		{"tx_power_dbm", f(l.TXPowerDbm) + " dBm"},
+		{"rx_sensitivity_dbm", f(l.RXSensitivityDbm) + " dBm"},
+		{"system_margin_db", f(l.SystemMarginDb) + " dB"},
+		{"fiber_length_km", f(l.FiberLengthKm) + " km"},
+		{"fiber_att_db_per_km", f(l.FiberAttDbPerKm) + " dB/km"},
+		{"n_splice", fmt.Sprint(l.NSplice)},
+		{"splice_loss_db", f(l.SpliceLossDb) + " dB"},
+		{"n_connector", fmt.Sprint(l.NConnectors)},
+		{"connector_loss_db", f(l.ConnectorLossDb) + " dB"},
+		{"splitter_loss_db", f(l.SplitterLossDb) + " dB"},
+		{"other_loss_db", f(l.OtherLossDb) + " dB"},
+	}
+	for i, a := range l.Amplifiers {
+		amp := a.Type + " at " + f(a.PositionKm) + " km, G " + f(a.GainDb) + " dB, NF " + f(a.NoiseFigureDb) + " dB"
+		if a.PsatDbm != 0 {
+			amp += ", Psat " + f(a.PsatDbm) + " dBm"
+		}
+		inputs = append(inputs, [2]string{fmt.Sprintf("amplifier %d", i+1), amp})
+	}
+	optional := []struct {
+		name, unit string
+		value      float64
+	}{
+		{"dispersion_ps_nm_km", "ps/(nm·km)", l.DispersionPsNmKm},
+		{"spectral_width_nm", "nm", l.SpectralWidthNm},
+		{"extinction_ratio_db", "dB", l.ExtinctionRatioDb},
+		{"rin_db_hz", "dB/Hz", l.RINDbHz},
+		{"mpn_k", "", l.MPNFactor},
+		{"pmd_ps_sqrt_km", "ps/√km", l.PMDPsSqrtKm},
+		{"bitrate_gbps", "Gbps", l.BitrateGbps},
+		{"mux_loss_db", "dB", l.MuxLossDb},
+		{"demux_loss_db", "dB", l.DemuxLossDb},
+		{"n_oadm", "", float64(l.NOADM)},
+		{"oadm_loss_db", "dB", l.OADMLossDb},
+		{"connector_reflectance_db", "dB", l.ConnectorReflectanceDb},
+		{"splice_reflectance_db", "dB", l.SpliceReflectanceDb},
+		{"orl_tolerance_db", "dB", l.ORLToleranceDb},
+		{"source_linewidth_mhz", "MHz", l.SourceLinewidthMHz},
+		{"effective_area_um2", "µm²", l.EffectiveAreaUm2},
+		{"repair_splices_per_km", "/km", l.RepairSplicesPerKm},
+		{"temp_drift_db_per_km", "dB/km", l.TempDriftDbPerKm},
+		{"aging_margin_db", "dB", l.AgingMarginDb},
+		{"cable_cut_margin_db", "dB", l.CableCutMarginDb},
+	}
+	if l.ConnectorType != "" {
+		inputs = append(inputs, [2]string{"connector_type", l.ConnectorType})
+	}
+	if l.CableType != "" {
+		inputs = append(inputs, [2]string{"cable_type", l.CableType})
+	}
+	if l.PHY != "" {
+		inputs = append(inputs, [2]string{"phy", l.PHY})
+	}
+	for _, o := range optional {
+		if o.value != 0 {
+			inputs = append(inputs, [2]string{o.name, strings.TrimSpace(f(o.value) + " " + o.unit)})
+		}
+	}
+	for _, in := range inputs {
+		fmt.Fprintf(&b, "  %-22s %s\n", in[0], in[1])
+	}
+
+	// WDM channels, the derivation below follows the worst one
+	if len(e.Channels) > 0 {
+		fmt.Fprintf(&b, "\nChannels (derivation below is for %s)\n", r.WorstChannel)
+		for _, ch := range e.Channels {
+			fmt.Fprintf(&b, "  %-8s %9s nm  α %s dB/km  margin %s dB  %s\n", ch.Label, f(ch.WavelengthNm), f(ch.FiberAttDbPerKm), f(ch.MarginDb), ch.LPBStatus)
+		}
+	}
+
+	// Coexistence services, the derivation below follows the limiting one
+	if len(e.Services) > 0 {
+		fmt.Fprintf(&b, "\nServices (derivation below is for %s)\n", r.LimitingService)
+		for _, s := range e.Services {
+			fmt.Fprintf(&b, "  %-14s %6s nm  filters %s dB  margin %s dB  overload headroom %s dB  %s\n",
+				s.Service+"/"+s.Direction, f(s.WavelengthNm), f(s.FilterLossDb), f(s.MarginDb), f(s.OverloadMarginDb), s.Status)
+		}
+	}
+
+	// Derivations
+	if len(e.Allowances) > 0 {
+		writeSteps(&b, "Margin allowances", e.Allowances)
+	}
+	if len(e.Penalties) > 0 {
+		writeSteps(&b, "Power penalties", e.Penalties)
+	}
+	writeSteps(&b, "Link power budget", e.LPB)
+	if r.Unbounded() {
+		fmt.Fprintf(&b, "  => LPB %s (%s, margin %s dB without them)\n", r.LPBStatus, strings.Join(r.UnboundedPenalties, ", "), f(r.MarginDb))
+	} else {
+		fmt.Fprintf(&b, "  => LPB %s (margin %s dB)\n", r.LPBStatus, f(r.MarginDb))
+	}
+	fmt.Fprintf(&b, "  Top contributors: %s, %s, %s\n", r.TopContributor1, r.TopContributor2, r.TopContributor3)
+	if len(e.RTB) > 0 {
+		writeSteps(&b, "Rise time budget", e.RTB)
+		status := "FAIL"
+		if r.RTBStatus {
+			status = "PASS"
+		}
+		fmt.Fprintf(&b, "  => RTB %s (t_sys %s ns, t_max %s ns)\n", status, f(r.SystemRiseTimeNs), f(r.AllowedRiseTimeNs))
+	}
+
+	if len(e.PHY) > 0 {
+		writeSteps(&b, "Ethernet PHY", e.PHY)
+		limit := ""
+		if r.PHYLimit != "" {
+			limit = ", exceeds " + r.PHYLimit
+		}
+		fmt.Fprintf(&b, "  => %s %s (reach %s m, max channel loss %s dB%s)\n", strings.ToUpper(l.PHY), r.PHYStatus, f(r.PHYReachM), f(r.PHYMaxChannelLossDb), limit)
+	}
+
+	if len(e.PMD) > 0 {
+		writeSteps(&b, "Polarization mode dispersion", e.PMD)
+		fmt.Fprintf(&b, "  => PMD %s (DGD %s ps, max %s ps)\n", r.PMDStatus, f(r.DGDPs), f(r.MaxDGDPs))
+	}
+
+	if len(e.Nonlinear) > 0 {
+		writeSteps(&b, "Nonlinear thresholds", e.Nonlinear)
+		fmt.Fprintf(&b, "  => Nonlinear %s (SBS penalty %s dB, SPM penalty %s dB)\n", r.NonlinearStatus, f(r.SBSPenaltyDb), f(r.SPMPenaltyDb))
+	}
+
+	if len(e.ORL) > 0 {
+		writeSteps(&b, "Optical return loss", e.ORL)
+		fmt.Fprintf(&b, "  => Reflection %s (ORL %s dB, tolerance %s dB)\n", r.ReflectionStatus, f(r.ORLDb), f(l.ORLToleranceDb))
+	}
+
+	if len(e.OSNR) > 0 {
+		writeSteps(&b, "Optical signal-to-noise ratio", e.OSNR)
+		fmt.Fprintf(&b, "  => OSNR %s (required %s dB)\n", r.OSNRStatus, f(l.RequiredOSNRDb))
+	}
+
+	if len(e.BER) > 0 {
+		writeSteps(&b, "Bit error rate", e.BER)
+		fmt.Fprintf(&b, "  => pre-FEC %s, post-FEC BER %s %s (fec %s)\n", r.BERStatus,
+			strconv.FormatFloat(r.PostFECBER, 'e', 3, 64), r.PostFECStatus, orNone(l.FEC))
+	}
+
+	// Fixes or headroom
+	if len(e.Fixes) > 0 {
+		b.WriteString("\nTo pass, any one of:\n")
+		for _, fix := range e.Fixes {
+			b.WriteString("  - " + fix.Text + "\n")
+		}
+	} else if r.MarginDb < 0 {
+		b.WriteString("\nNo single-field change makes this link pass.\n")
+	}
+	if len(e.Limits) > 0 {
+		b.WriteString("\nHeadroom before the budget fails:\n")
+		for _, fix := range e.Limits {
+			b.WriteString("  - " + fix.Text + "\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Define helper to name an empty FEC column
+func orNone(fec string) string {
+	if fec == "" {
+		return "default"
+	}
+	return fec
+}
+
+// Define helper to print a list of derivation steps
+func writeSteps(b *strings.Builder, title string, steps []Step) {
+	b.WriteString("\n" + title + "\n")
+	for _, s := range steps {
+		value := f(s.Value)
+		if s.Value != 0 && math.Abs(s.Value) < 1e-3 { // Error rates
+			value = strconv.FormatFloat(s.Value, 'e', 3, 64)
+		}
+		value = strings.TrimSpace(value + " " + s.Unit)
+		if s.Unit == UnitUnbounded {
+			value = "∞ (left out of the margin)"
+		}
+		fmt.Fprintf(b, "  %s\n    %s\n    = %s\n    = %s\n", s.Name, s.Formula, s.Trace, value)
+	}
+}
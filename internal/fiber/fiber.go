@@ -0,0 +1,186 @@
+package fiber
+
+import (
+	"math"
+	"strings"
+)
+
+// Define struct for a wavelength band with its plausible cabled attenuation
+type Band struct {
+	Name          string
+	MinNm         float64
+	MaxNm         float64
+	MinAttDbPerKm float64
+	MaxAttDbPerKm float64
+}
+
+// Define struct for the effective modal bandwidth of a multimode fiber at one
+// wavelength (EMB at 850/953 nm, overfilled bandwidth at 1300 nm)
+type ModalBandwidth struct {
+	WavelengthNm float64
+	MHzKm        float64
+}
+
+// Define struct for a fiber type in the catalog
+type Type struct {
+	Name      string
+	Multimode bool
+	Bands     []Band
+
+	// Chromatic dispersion model D(λ) = S0/4 · (λ − λ0⁴/λ³)
+	ZeroDispersionNm float64 // λ0
+	DispersionSlope  float64 // S0 in ps/(nm²·km)
+
+	// PMD link design value (PMD_Q) in ps/√km, 0 for multimode
+	PMDPsSqrtKm float64
+
+	// Effective area at 1550 nm in µm², 0 for multimode
+	EffectiveAreaUm2 float64
+
+	// Modal bandwidth per wavelength, multimode only
+	ModalBandwidth []ModalBandwidth
+}
+
+// Define single-mode bands shared by the ITU-T G.652/G.657 families
+var singleModeBands = []Band{
+	{"O", 1260, 1360, 0.28, 0.50},
+	{"E", 1360, 1460, 0.26, 0.60},
+	{"S", 1460, 1530, 0.19, 0.40},
+	{"C", 1530, 1565, 0.16, 0.35},
+	{"L", 1565, 1625, 0.17, 0.40},
+	{"U", 1625, 1675, 0.20, 0.50},
+}
+
+// Define multimode bands (850 nm and 1300 nm windows, SWDM up to 953 nm)
+var multimodeBands = []Band{
+	{"850", 840, 960, 2.0, 3.5},
+	{"1300", 1270, 1330, 0.4, 1.5},
+}
+
+// Define the fiber catalog keyed by normalized name
+var catalog = map[string]Type{
+	"g652": {Name: "G.652", Bands: singleModeBands, ZeroDispersionNm: 1312, DispersionSlope: 0.092, PMDPsSqrtKm: 0.2, EffectiveAreaUm2: 80},
+	"g657": {Name: "G.657", Bands: singleModeBands, ZeroDispersionNm: 1312, DispersionSlope: 0.092, PMDPsSqrtKm: 0.2, EffectiveAreaUm2: 72},
+	"g655": {Name: "G.655", Bands: []Band{
+		{"S", 1460, 1530, 0.20, 0.40},
+		{"C", 1530, 1565, 0.18, 0.35},
+		{"L", 1565, 1625, 0.19, 0.40},
+	}, ZeroDispersionNm: 1450, DispersionSlope: 0.07, PMDPsSqrtKm: 0.2, EffectiveAreaUm2: 55},
+	"g654": {Name: "G.654", Bands: []Band{
+		{"C", 1530, 1565, 0.15, 0.25},
+		{"L", 1565, 1625, 0.16, 0.28},
+	}, ZeroDispersionNm: 1300, DispersionSlope: 0.094, PMDPsSqrtKm: 0.2, EffectiveAreaUm2: 125},
+	"om1": {Name: "OM1", Multimode: true, Bands: multimodeBands, ZeroDispersionNm: 1332, DispersionSlope: 0.097,
+		ModalBandwidth: []ModalBandwidth{{850, 200}, {1300, 500}}},
+	"om2": {Name: "OM2", Multimode: true, Bands: multimodeBands, ZeroDispersionNm: 1316, DispersionSlope: 0.101,
+		ModalBandwidth: []ModalBandwidth{{850, 500}, {1300, 500}}},
+	"om3": {Name: "OM3", Multimode: true, Bands: multimodeBands, ZeroDispersionNm: 1316, DispersionSlope: 0.101,
+		ModalBandwidth: []ModalBandwidth{{850, 2000}, {1300, 500}}},
+	"om4": {Name: "OM4", Multimode: true, Bands: multimodeBands, ZeroDispersionNm: 1316, DispersionSlope: 0.101,
+		ModalBandwidth: []ModalBandwidth{{850, 4700}, {1300, 500}}},
+	"om5": {Name: "OM5", Multimode: true, Bands: multimodeBands, ZeroDispersionNm: 1316, DispersionSlope: 0.101,
+		ModalBandwidth: []ModalBandwidth{{850, 4700}, {953, 2470}, {1300, 500}}},
+}
+
+// Define aliases for common spellings
+var aliases = map[string]string{
+	"smf":    "g652",
+	"sm":     "g652",
+	"ssmf":   "g652",
+	"g652d":  "g652",
+	"g657a":  "g657",
+	"g657a1": "g657",
+	"g657a2": "g657",
+	"g657b3": "g657",
+	"nzdsf":  "g655",
+	"mmf":    "om3",
+	"om4a":   "om4",
+	"wbmmf":  "om5",
+}
+
+// Define default type assumed when a link does not declare one
+var Default = catalog["g652"]
+
+// Define function to look up a fiber type by name (e.g. "G.652.D", "smf", "OM3")
+func Lookup(name string) (Type, bool) {
+	key := normalize(name)
+	if a, ok := aliases[key]; ok {
+		key = a
+	}
+	t, ok := catalog[key]
+	return t, ok
+}
+
+// Define function to find the band containing a wavelength
+func (t Type) BandFor(wavelengthNm float64) (Band, bool) {
+	for _, b := range t.Bands {
+		if wavelengthNm >= b.MinNm && wavelengthNm < b.MaxNm {
+			return b, true
+		}
+	}
+	return Band{}, false
+}
+
+// Define function to find the maximum cabled attenuation in dB/km at a
+// wavelength; without a wavelength, or outside the bands, the largest of all bands
+func (t Type) MaxAttenuationAt(wavelengthNm float64) float64 {
+	if b, ok := t.BandFor(wavelengthNm); ok {
+		return b.MaxAttDbPerKm
+	}
+	max := 0.0
+	for _, b := range t.Bands {
+		max = math.Max(max, b.MaxAttDbPerKm)
+	}
+	return max
+}
+
+// Define function to find the modal bandwidth in MHz·km at a wavelength.
+// Between two specified wavelengths the bandwidth is interpolated linearly;
+// outside them the nearest one applies. 0 for single-mode fiber.
+func (t Type) ModalBandwidthAt(wavelengthNm float64) float64 {
+	bw := t.ModalBandwidth
+	if len(bw) == 0 {
+		return 0
+	}
+	if wavelengthNm <= bw[0].WavelengthNm {
+		return bw[0].MHzKm
+	}
+	for i := 1; i < len(bw); i++ {
+		if wavelengthNm <= bw[i].WavelengthNm {
+			lo, hi := bw[i-1], bw[i]
+			frac := (wavelengthNm - lo.WavelengthNm) / (hi.WavelengthNm - lo.WavelengthNm)
+			return lo.MHzKm + frac*(hi.MHzKm-lo.MHzKm)
+		}
+	}
+	return bw[len(bw)-1].MHzKm
+}
+
+// Define function to estimate the chromatic dispersion coefficient in ps/(nm·km)
+// at a wavelength from the fiber's zero-dispersion wavelength and slope
+func (t Type) DispersionAt(wavelengthNm float64) float64 {
+	if wavelengthNm <= 0 || t.DispersionSlope == 0 {
+		return 0
+	}
+	return t.DispersionSlope / 4 * (wavelengthNm - math.Pow(t.ZeroDispersionNm, 4)/math.Pow(wavelengthNm, 3))
+}
+
+// Define function to estimate the typical spectral attenuation in dB/km:
+// Rayleigh scattering (A/λ⁴), infrared absorption, the residual water peak
+// at 1383 nm and a constant waveguide term. Only the shape is meant to be
+// used, to carry a measured attenuation over to another wavelength.
+func (t Type) AttenuationAt(wavelengthNm float64) float64 {
+	um := wavelengthNm / 1000
+	rayleigh, floor := 0.8, 0.03
+	if t.Multimode {
+		rayleigh, floor = 1.2, 0.1
+	}
+	ir := 7.81e11 * math.Exp(-48.48/um)
+	water := 0.05 * math.Exp(-math.Pow((wavelengthNm-1383)/15, 2))
+	return rayleigh/math.Pow(um, 4) + ir + water + floor
+}
+
+// Define helper to normalize a fiber type name
+func normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(".", "", "-", "", " ", "", "_", "").Replace(name)
+}
@@ -0,0 +1,95 @@
+package generate
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"strconv"
+)
+
+// Define supported distribution kinds
+const (
+	KindUniform = "uniform"
+	KindChoice  = "choice"
+	KindNormal  = "normal"
+)
+
+// Define struct for the distribution of one field. Normal samples are clipped
+// to [Min, Max] when Max > Min.
+type Distribution struct {
+	Kind     string    `yaml:"kind"`
+	Min      float64   `yaml:"min,omitempty"`
+	Max      float64   `yaml:"max,omitempty"`
+	Values   []float64 `yaml:"values,omitempty,flow"`
+	Mean     float64   `yaml:"mean,omitempty"`
+	StdDev   float64   `yaml:"stddev,omitempty"`
+	Decimals int       `yaml:"decimals,omitempty"`
+}
+
+// Define helpers for the built-in distributions
+func uniform(min, max float64, decimals int) Distribution {
+	return Distribution{Kind: KindUniform, Min: min, Max: max, Decimals: decimals}
+}
+
+func choice(decimals int, values ...float64) Distribution {
+	return Distribution{Kind: KindChoice, Values: values, Decimals: decimals}
+}
+
+// Define function to return the default distributions, matching
+// src/data_generator/data_distribution.py
+func DefaultDistributions() map[string]Distribution {
+	return map[string]Distribution{
+		"fiber_length_km":     uniform(2.0, 40.0, 2),
+		"fiber_att_db_per_km": choice(3, 0.2, 0.3, 0.4, 0.5),
+		"tx_power_dbm":        choice(1, 2, 4, 6, 8),
+		"rx_sensitivity_dbm":  choice(1, -27, -28, -29, -20),
+		"system_margin_db":    choice(1, 2, 3, 4, 5),
+		"splitter_loss_db":    choice(2, 0.5, 1.0, 1.5, 2.0),
+		"splice_loss_db":      uniform(0.05, 0.15, 3),
+		"n_connector":         choice(0, 2, 4, 6, 8),
+		"connector_loss_db":   uniform(0.2, 0.5, 3),
+		"other_loss_db":       uniform(0, 2.5, 2),
+	}
+}
+
+// Define function to check a distribution before sampling
+func (d Distribution) Validate() error {
+	switch d.Kind {
+	case KindUniform:
+		if d.Max < d.Min {
+			return errors.New("uniform max is below min")
+		}
+	case KindChoice:
+		if len(d.Values) == 0 {
+			return errors.New("choice needs at least one value")
+		}
+	case KindNormal:
+		if d.StdDev < 0 {
+			return errors.New("normal stddev is negative")
+		}
+	default:
+		return errors.New("unknown kind " + strconv.Quote(d.Kind) + " (use uniform, choice or normal)")
+	}
+	if d.Decimals < 0 {
+		return errors.New("decimals is negative")
+	}
+	return nil
+}
+
+// Define function to draw one rounded sample
+func (d Distribution) Sample(rng *rand.Rand) float64 {
+	var x float64
+	switch d.Kind {
+	case KindUniform:
+		x = d.Min + rng.Float64()*(d.Max-d.Min)
+	case KindChoice:
+		x = d.Values[rng.IntN(len(d.Values))]
+	case KindNormal:
+		x = d.Mean + rng.NormFloat64()*d.StdDev
+		if d.Max > d.Min {
+			x = math.Min(math.Max(x, d.Min), d.Max)
+		}
+	}
+	scale := math.Pow(10, float64(d.Decimals))
+	return math.Round(x*scale) / scale
+}
@@ -0,0 +1,157 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// Define default generator settings
+const (
+	DefaultSpliceEveryKm = 3.0
+	DefaultMaxAttempts   = 1000
+)
+
+// Define struct for generator options
+type Options struct {
+	N        int
+	Seed     uint64
+	Scenario string
+
+	// Per-field distributions keyed by CSV column name, merged over DefaultDistributions
+	Distributions map[string]Distribution
+
+	// One splice every SpliceEveryKm of fiber (at least one). Zero samples
+	// n_splice from its own distribution instead.
+	SpliceEveryKm float64
+
+	// Links failing validation are resampled up to MaxAttempts times
+	MaxAttempts int
+	Validation  validate.ValidationOptions
+}
+
+// Define struct for a distribution file
+type File struct {
+	Distributions map[string]Distribution `yaml:"distributions"`
+	SpliceEveryKm *float64                `yaml:"splice_every_km"`
+}
+
+// Define map of fields a distribution can drive, keyed by CSV column name
+var setters = map[string]func(*model.LinkInput, float64){
+	"tx_power_dbm":        func(l *model.LinkInput, x float64) { l.TXPowerDbm = x },
+	"rx_sensitivity_dbm":  func(l *model.LinkInput, x float64) { l.RXSensitivityDbm = x },
+	"system_margin_db":    func(l *model.LinkInput, x float64) { l.SystemMarginDb = x },
+	"fiber_length_km":     func(l *model.LinkInput, x float64) { l.FiberLengthKm = x },
+	"fiber_att_db_per_km": func(l *model.LinkInput, x float64) { l.FiberAttDbPerKm = x },
+	"n_splice":            func(l *model.LinkInput, x float64) { l.NSplice = int(math.Round(x)) },
+	"splice_loss_db":      func(l *model.LinkInput, x float64) { l.SpliceLossDb = x },
+	"n_connector":         func(l *model.LinkInput, x float64) { l.NConnectors = int(math.Round(x)) },
+	"connector_loss_db":   func(l *model.LinkInput, x float64) { l.ConnectorLossDb = x },
+	"splitter_loss_db":    func(l *model.LinkInput, x float64) { l.SplitterLossDb = x },
+	"other_loss_db":       func(l *model.LinkInput, x float64) { l.OtherLossDb = x },
+	"wavelength_nm":       func(l *model.LinkInput, x float64) { l.WavelengthNm = x },
+}
+
+// Define function to load a distribution file over the given options
+func LoadFile(path string, opt *Options) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return errors.New("Failed to open distribution file: " + err.Error())
+	}
+	var f File
+	if err := yaml.Unmarshal(raw, &f); err != nil {
+		return errors.New("Failed to parse distribution file: " + err.Error())
+	}
+	if opt.Distributions == nil {
+		opt.Distributions = make(map[string]Distribution, len(f.Distributions))
+	}
+	for name, d := range f.Distributions {
+		opt.Distributions[name] = d
+	}
+	if f.SpliceEveryKm != nil {
+		opt.SpliceEveryKm = *f.SpliceEveryKm
+	}
+	return nil
+}
+
+// Define function to generate synthetic links. Every returned link passes
+// validate.ValidateLink without blocking errors.
+func Generate(opt Options) ([]model.LinkInput, error) {
+	if opt.N <= 0 {
+		return nil, errors.New("Number of links must be positive")
+	}
+	if opt.Scenario == "" {
+		opt.Scenario = "base"
+	}
+	if opt.MaxAttempts <= 0 {
+		opt.MaxAttempts = DefaultMaxAttempts
+	}
+	if opt.SpliceEveryKm < 0 {
+		return nil, errors.New("Splice spacing must not be negative")
+	}
+
+	// Merge and check distributions in a stable order
+	dists := DefaultDistributions()
+	for name, d := range opt.Distributions {
+		dists[name] = d
+	}
+	names := make([]string, 0, len(dists))
+	for name, d := range dists {
+		if _, ok := setters[name]; !ok {
+			return nil, errors.New("Unknown distribution field: " + name)
+		}
+		if err := d.Validate(); err != nil {
+			return nil, errors.New("Invalid distribution for " + name + ": " + err.Error())
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if _, ok := dists["n_splice"]; !ok && opt.SpliceEveryKm == 0 {
+		return nil, errors.New("n_splice needs a distribution when splice spacing is zero")
+	}
+
+	rng := rand.New(rand.NewPCG(opt.Seed, opt.Seed))
+	links := make([]model.LinkInput, 0, opt.N)
+	for i := 1; i <= opt.N; i++ {
+		var lastErr model.RowError
+		ok := false
+		for attempt := 0; attempt < opt.MaxAttempts && !ok; attempt++ {
+			link := model.LinkInput{LinkID: fmt.Sprintf("link_%05d", i), Scenario: opt.Scenario}
+			for _, name := range names {
+				if name == "n_splice" && opt.SpliceEveryKm > 0 {
+					continue
+				}
+				setters[name](&link, dists[name].Sample(rng))
+			}
+
+			// Splice count follows the fiber length (about one every 3 km)
+			if opt.SpliceEveryKm > 0 {
+				link.NSplice = max(1, int(link.FiberLengthKm/opt.SpliceEveryKm))
+			}
+
+			ok = true
+			for _, e := range validate.ValidateLink([]model.LinkInput{link}, opt.Validation) {
+				if e.IsBlocking() {
+					lastErr, ok = e, false
+					break
+				}
+			}
+			if ok {
+				links = append(links, link)
+			}
+		}
+		if !ok {
+			return nil, errors.New("Failed to generate a valid link_" + fmt.Sprintf("%05d", i) +
+				" after " + strconv.Itoa(opt.MaxAttempts) + " attempts: " + lastErr.Error())
+		}
+	}
+	return links, nil
+}
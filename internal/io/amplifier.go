@@ -0,0 +1,50 @@
+package io
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define function to parse the amplifiers column. Entries are separated by
+// '|' and written as type:position_km:gain_db:noise_figure_db[:psat_dbm],
+// e.g. "edfa:20:17:5:20|soa:45:12:7".
+func ParseAmplifiers(s string, decimalComma bool) ([]model.Amplifier, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var amps []model.Amplifier
+	for _, entry := range strings.Split(s, "|") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) < 4 || len(parts) > 5 {
+			return nil, errors.New("Amplifier " + strconv.Quote(entry) + " is not type:position_km:gain_db:noise_figure_db[:psat_dbm]")
+		}
+		amp := model.Amplifier{Type: strings.ToLower(strings.TrimSpace(parts[0]))}
+		values := []*float64{&amp.PositionKm, &amp.GainDb, &amp.NoiseFigureDb, &amp.PsatDbm}
+		for i, raw := range parts[1:] {
+			v, err := parseNumber(raw, decimalComma)
+			if err != nil {
+				return nil, errors.New("Amplifier " + strconv.Quote(entry) + " has a bad number " + strconv.Quote(raw))
+			}
+			*values[i] = v
+		}
+		amps = append(amps, amp)
+	}
+	return amps, nil
+}
+
+// Define function to format amplifiers back into the column syntax
+func FormatAmplifiers(amps []model.Amplifier, formatFloat func(float64) string) string {
+	entries := make([]string, len(amps))
+	for i, a := range amps {
+		e := a.Type + ":" + formatFloat(a.PositionKm) + ":" + formatFloat(a.GainDb) + ":" + formatFloat(a.NoiseFigureDb)
+		if a.PsatDbm != 0 {
+			e += ":" + formatFloat(a.PsatDbm)
+		}
+		entries[i] = e
+	}
+	return strings.Join(entries, "|")
+}
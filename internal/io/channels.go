@@ -0,0 +1,107 @@
+package io
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/wdm"
+)
+
+// Define limit on the channels one DWDM range may expand to, about the size
+// of the 100 GHz ITU grid
+const maxDWDMRange = 100
+
+// Define function to parse the channels column. Entries are separated by '|'
+// and each is one of:
+//
+//	cwdm          the full 18-channel CWDM grid (1271–1611 nm)
+//	1471-1611     the CWDM grid wavelengths in a range
+//	C21, C21.5    an ITU DWDM channel (190 + n/10 THz)
+//	C21-C60       consecutive 100 GHz DWDM channels (whole channels, at most 100)
+//	1550.12       a wavelength in nm
+func ParseChannels(s string, decimalComma bool) ([]model.Channel, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var channels []model.Channel
+	for _, entry := range strings.Split(s, "|") {
+		entry = strings.TrimSpace(entry)
+		bad := errors.New("Channel " + strconv.Quote(entry) + " is not cwdm, a CWDM range, an ITU channel (C21) or a wavelength in nm")
+		lo, hi, isRange := strings.Cut(entry, "-")
+		switch {
+		case strings.EqualFold(entry, "cwdm"):
+			for _, nm := range wdm.CWDM(wdm.CWDMFirstNm, wdm.CWDMLastNm) {
+				channels = append(channels, cwdmChannel(nm))
+			}
+		case isDWDM(lo) && !isRange:
+			n, err := parseNumber(lo[1:], decimalComma)
+			if err != nil || !validWavelength(wdm.DWDMWavelength(n)) {
+				return nil, bad
+			}
+			channels = append(channels, model.Channel{Label: wdm.DWDMLabel(n), WavelengthNm: wdm.DWDMWavelength(n)})
+		case isDWDM(lo):
+			// Ranges step over whole channels only
+			if !isDWDM(hi) {
+				return nil, bad
+			}
+			from, err1 := strconv.Atoi(lo[1:])
+			to, err2 := strconv.Atoi(hi[1:])
+			if err1 != nil || err2 != nil || to < from {
+				return nil, bad
+			}
+			if to-from >= maxDWDMRange {
+				return nil, errors.New("Channel range " + strconv.Quote(entry) + " spans more than " + strconv.Itoa(maxDWDMRange) + " DWDM channels")
+			}
+			for n := from; n <= to; n++ {
+				nm := wdm.DWDMWavelength(float64(n))
+				if !validWavelength(nm) {
+					return nil, bad
+				}
+				channels = append(channels, model.Channel{Label: wdm.DWDMLabel(float64(n)), WavelengthNm: nm})
+			}
+		case isRange:
+			from, err1 := parseNumber(lo, decimalComma)
+			to, err2 := parseNumber(hi, decimalComma)
+			grid := wdm.CWDM(from, to)
+			if err1 != nil || err2 != nil || len(grid) == 0 {
+				return nil, bad
+			}
+			for _, nm := range grid {
+				channels = append(channels, cwdmChannel(nm))
+			}
+		default:
+			nm, err := parseNumber(entry, decimalComma)
+			if err != nil || nm <= 0 {
+				return nil, bad
+			}
+			channels = append(channels, model.Channel{Label: strconv.FormatFloat(nm, 'f', -1, 64), WavelengthNm: nm})
+		}
+	}
+	return channels, nil
+}
+
+// Define function to format a channel plan back into the column syntax
+func FormatChannels(channels []model.Channel) string {
+	labels := make([]string, len(channels))
+	for i, c := range channels {
+		labels[i] = c.Label
+	}
+	return strings.Join(labels, "|")
+}
+
+// Define helpers for the channel syntax
+func isDWDM(s string) bool {
+	return len(s) > 1 && (s[0] == 'C' || s[0] == 'c')
+}
+
+func validWavelength(nm float64) bool {
+	return nm > 0 && !math.IsInf(nm, 0) && !math.IsNaN(nm)
+}
+
+func cwdmChannel(nm float64) model.Channel {
+	return model.Channel{Label: strconv.FormatFloat(nm, 'f', -1, 64), WavelengthNm: nm}
+}
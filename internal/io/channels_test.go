@@ -0,0 +1,46 @@
+package io
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseChannelsDWDM(t *testing.T) {
+	tests := []struct {
+		in      string
+		labels  string
+		firstNm float64
+		err     string
+	}{
+		{in: "C21", labels: "C21", firstNm: 1560.6062},
+		{in: "C21.5", labels: "C21.5", firstNm: 1560.2001},
+		{in: "C21-C24|C60", labels: "C21|C22|C23|C24|C60", firstNm: 1560.6062},
+		{in: "C0-C99", labels: "", firstNm: 299792.458 / 190},
+		{in: "C0-C100", err: "spans more than 100"},
+		{in: "C1-C1e9", err: "is not cwdm"},
+		{in: "C1-CInf", err: "is not cwdm"},
+		{in: "C1.5-C3", err: "is not cwdm"},
+		{in: "C24-C21", err: "is not cwdm"},
+		{in: "CNaN", err: "is not cwdm"},
+	}
+	for _, tt := range tests {
+		got, err := ParseChannels(tt.in, false)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: error %v; want %q", tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if tt.labels != "" && FormatChannels(got) != tt.labels {
+			t.Errorf("%s: channels %s; want %s", tt.in, FormatChannels(got), tt.labels)
+		}
+		if math.Abs(got[0].WavelengthNm-tt.firstNm) > 1e-4 {
+			t.Errorf("%s: first wavelength %.4f nm; want %.4f", tt.in, got[0].WavelengthNm, tt.firstNm)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package io
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define function to write the per-channel table of WDM results to a CSV file
+func WriteChannelsCSV(path string, results []model.LinkOutput, opt CSVWriteOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.New("Failed to create channels file: " + err.Error())
+	}
+	defer file.Close()
+	return WriteChannels(file, results, opt)
+}
+
+// Define function to write one row per WDM channel; single-channel links are skipped
+func WriteChannels(w io.Writer, results []model.LinkOutput, opt CSVWriteOptions) error {
+	write := csv.NewWriter(w)
+	if opt.Delimiter != 0 {
+		write.Comma = opt.Delimiter
+	}
+	headers := []string{
+		"link_id", "scenario", "channel", "wavelength_nm", "worst",
+		"fiber_att_db_per_km", "dispersion_ps_nm_km", "total_loss_db", "penalty_total_db",
+		"rx_power_dbm", "margin_db", "lpb_status",
+	}
+	if err := write.Write(headers); err != nil {
+		return errors.New("An error has occurred while writing CSV headers: " + err.Error())
+	}
+	formatFloat := NumberFormatter(opt)
+	for _, res := range results {
+		for _, ch := range res.Channels {
+			worst := ""
+			if ch.Label == res.WorstChannel {
+				worst = "*"
+			}
+			row := []string{
+				res.LinkID, res.Scenario, ch.Label, formatFloat(ch.WavelengthNm), worst,
+				formatFloat(ch.FiberAttDbPerKm), formatFloat(ch.DispersionPsNmKm), formatFloat(ch.TotalLossDb), formatFloat(ch.PenaltyTotalDb),
+				formatFloat(ch.RxPowerDbm), formatFloat(ch.MarginDb), ch.LPBStatus,
+			}
+			if err := write.Write(row); err != nil {
+				return errors.New("An error has occurred while writing CSV row: " + err.Error())
+			}
+		}
+	}
+	write.Flush()
+	return write.Error()
+}
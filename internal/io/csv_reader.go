@@ -3,6 +3,7 @@ package io
 import (
 	"encoding/csv"
 	"errors"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -26,9 +27,19 @@ func ReadLinksCSV(path string, opt CSVReadOptions) ([]model.LinkInput, []model.R
 		return nil, nil, errors.New("Failed to open CSV file: " + err.Error())
 	}
 	defer file.Close()
+	return ReadLinks(file, opt)
+}
+
+// Define function to read links from any CSV stream (file, HTTP body, ...)
+func ReadLinks(r io.Reader, opt CSVReadOptions) ([]model.LinkInput, []model.RowError, error) {
+	// Detect BOM-marked encodings before parsing
+	decoded, err := decodeInput(r)
+	if err != nil {
+		return nil, nil, errors.New("Failed to read CSV file: " + err.Error())
+	}
 
 	// Read and parse CSV content
-	reader := csv.NewReader(file)
+	reader := csv.NewReader(decoded)
 	if opt.Delimiter != 0 {
 		reader.Comma = opt.Delimiter
 	}
@@ -44,14 +55,23 @@ func ReadLinksCSV(path string, opt CSVReadOptions) ([]model.LinkInput, []model.R
 	for i, h := range records {
 		col[strings.ToLower(strings.TrimSpace(h))] = i
 	}
+	// Resolve aliases unless the canonical column is also present
+	for alias, name := range ColumnAliases {
+		if i, ok := col[alias]; ok {
+			if _, dup := col[name]; !dup {
+				col[name] = i
+			}
+		}
+	}
 
-	// Check required columns 
+	// Check required columns
 	var schemaErrors []model.RowError
 	for _, req := range RequiredColumns {
 		if _, ok := col[req]; !ok {
 			schemaErrors = append(schemaErrors, model.RowError{
-				Row: 0, 
-				Field: req, 
+				Row: 0,
+				Line: 1,
+				Field: req,
 				Message: "Missing required column",
 			})
 		}
@@ -61,16 +81,7 @@ func ReadLinksCSV(path string, opt CSVReadOptions) ([]model.LinkInput, []model.R
 		return nil, schemaErrors, errors.New("CSV schema validation failed")
 	}
 	// Parse float value
-	parseFloat := func(s string) (float64, error) {
-		s = strings.TrimSpace(s)
-		if s == "" {
-			return 0, nil
-		}
-		if opt.DecimalComma {
-			s = strings.ReplaceAll(s, ",", ".")
-		}
-		return strconv.ParseFloat(s, 64)
-	}
+	parseFloat := func(s string) (float64, error) { return parseNumber(s, opt.DecimalComma) }
 
 	// Parse integer value
 	parseInt := func(s string) (int, error) {
@@ -85,68 +96,168 @@ func ReadLinksCSV(path string, opt CSVReadOptions) ([]model.LinkInput, []model.R
 	var output []model.LinkInput
 	var rowErrs []model.RowError
 	rowIndex := 0 // Row index starts from 0 (header row)
-	
+
 	// Iterate through each record
 	for {
 		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowIndex++
 		if err != nil {
-			if err.Error() == "EOF" {
-				break
+			// Quote and field count problems only affect the current row
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Line: parseErr.Line, Message: parseErr.Err.Error()})
+				continue
 			}
-			return nil, nil, errors.New("CSV has reached the end of the file")
+			return nil, nil, errors.New("Failed to read CSV file: " + err.Error())
 		}
-		rowIndex++
 
-		// Generate function to capture row errors
-		get := func(name string) string { return rec[col[name]]}
+		// Generate function to capture a cell and its source line
+		get := func(name string) string {
+			idx, ok := col[name]
+			if !ok || idx >= len(rec) {
+				return ""
+			}
+			return rec[idx]
+		}
+		line := func(name string) int {
+			idx, ok := col[name]
+			if !ok || idx >= len(rec) {
+				idx = 0
+			}
+			l, _ := reader.FieldPos(idx)
+			return l
+		}
 
 		// Create new link input to capture id and scenarios
 		var link model.LinkInput
 		link.LinkID = get("link_id")
 		link.Scenario = get("scenario")
+		link.Row = rowIndex
+		link.Line = line("link_id")
+		link.FiberType = strings.TrimSpace(get("fiber_type"))
+		link.SplitterID = strings.TrimSpace(get("splitter_id"))
+		link.FEC = strings.ToLower(strings.TrimSpace(get("fec")))
+		link.ConnectorType = strings.ToLower(strings.TrimSpace(get("connector_type")))
+		link.CableType = strings.ToLower(strings.TrimSpace(get("cable_type")))
+		link.PHY = strings.ToLower(strings.TrimSpace(get("phy")))
 
-		// Parse and assign float and int values
-		if link.TXPowerDbm, err = parseFloat(get("tx_power_dbm")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "tx_power_dbm", Message: "Not a number"})
-			continue
+		// Parse every numeric field so all bad cells in a row are reported together
+		floatFields := []struct {
+			name string
+			dst *float64
+		}{
+			{"tx_power_dbm", &link.TXPowerDbm},
+			{"rx_sensitivity_dbm", &link.RXSensitivityDbm},
+			{"system_margin_db", &link.SystemMarginDb},
+			{"fiber_length_km", &link.FiberLengthKm},
+			{"fiber_att_db_per_km", &link.FiberAttDbPerKm},
+			{"splice_loss_db", &link.SpliceLossDb},
+			{"connector_loss_db", &link.ConnectorLossDb},
+			{"splitter_loss_db", &link.SplitterLossDb},
+			{"other_loss_db", &link.OtherLossDb},
+			{"wavelength_nm", &link.WavelengthNm},
+			{"required_osnr_db", &link.RequiredOSNRDb},
+			{"sensitivity_ber", &link.SensitivityBER},
+			{"q_slope", &link.QSlope},
+			{"target_ber", &link.TargetBER},
+			{"dispersion_ps_nm_km", &link.DispersionPsNmKm},
+			{"spectral_width_nm", &link.SpectralWidthNm},
+			{"extinction_ratio_db", &link.ExtinctionRatioDb},
+			{"rin_db_hz", &link.RINDbHz},
+			{"mpn_k", &link.MPNFactor},
+			{"dispersion_penalty_db", &link.DispersionPenaltyDb},
+			{"er_penalty_db", &link.ExtinctionPenaltyDb},
+			{"rin_penalty_db", &link.RINPenaltyDb},
+			{"mpn_penalty_db", &link.MPNPenaltyDb},
+			{"reflection_penalty_db", &link.ReflectionPenaltyDb},
+			{"chirp_penalty_db", &link.ChirpPenaltyDb},
+			{"pmd_ps_sqrt_km", &link.PMDPsSqrtKm},
+			{"bitrate_gbps", &link.BitrateGbps},
+			{"mux_loss_db", &link.MuxLossDb},
+			{"demux_loss_db", &link.DemuxLossDb},
+			{"oadm_loss_db", &link.OADMLossDb},
+			{"connector_reflectance_db", &link.ConnectorReflectanceDb},
+			{"splice_reflectance_db", &link.SpliceReflectanceDb},
+			{"orl_tolerance_db", &link.ORLToleranceDb},
+			{"repair_splices_per_km", &link.RepairSplicesPerKm},
+			{"temp_drift_db_per_km", &link.TempDriftDbPerKm},
+			{"aging_margin_db", &link.AgingMarginDb},
+			{"cable_cut_margin_db", &link.CableCutMarginDb},
+			{"source_linewidth_mhz", &link.SourceLinewidthMHz},
+			{"effective_area_um2", &link.EffectiveAreaUm2},
 		}
-		if link.RXSensitivityDbm, err = parseFloat(get("rx_sensitivity_dbm")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "rx_sensitivity_dbm", Message: "Not a number"})
-			continue
+		intFields := []struct {
+			name string
+			dst *int
+		}{
+			{"n_splice", &link.NSplice},
+			{"n_connector", &link.NConnectors},
+			{"splitter_port", &link.SplitterPort},
+			{"split_ratio", &link.SplitRatio},
+			{"n_oadm", &link.NOADM},
 		}
-		if link.SystemMarginDb, err = parseFloat(get("system_margin_db")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "system_margin_db", Message: "Not a number"})
-			continue
-		}
-		if link.FiberLengthKm, err = parseFloat(get("fiber_length_km")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "fiber_length_km", Message: "Not a number"})
-			continue
-		}
-		if link.FiberAttDbPerKm, err = parseFloat(get("fiber_att_db_per_km")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "fiber_att_db_per_km", Message: "Not a number"})
-			continue
+
+		var linkErrs []model.RowError
+		for _, f := range floatFields {
+			raw := get(f.name)
+			value, err := parseFloat(raw)
+			if err != nil {
+				linkErrs = append(linkErrs, model.RowError{Row: rowIndex, Line: line(f.name), Field: f.name, Value: raw, Message: "Not a number"})
+				continue
+			}
+			*f.dst = value
 		}
-		if link.NSplice, err = parseInt(get("n_splice")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "n_splice", Message: "Not an integer value"})
-			continue
+		for _, f := range intFields {
+			raw := get(f.name)
+			value, err := parseInt(raw)
+			if err != nil {
+				linkErrs = append(linkErrs, model.RowError{Row: rowIndex, Line: line(f.name), Field: f.name, Value: raw, Message: "Not an integer value"})
+				continue
+			}
+			*f.dst = value
 		}
-		if link.NConnectors, err = parseInt(get("n_connector")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "n_connector", Message: "Not an integer value"})
-			continue
+
+		amps, err := ParseAmplifiers(get("amplifiers"), opt.DecimalComma)
+		if err != nil {
+			linkErrs = append(linkErrs, model.RowError{Row: rowIndex, Line: line("amplifiers"), Field: "amplifiers", Value: get("amplifiers"), Message: err.Error()})
 		}
-		if link.SpliceLossDb, err = parseFloat(get("splice_loss_db")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "splice_loss_db", Message: "Not a number"})
-			continue
+		link.Amplifiers = amps
+		channels, err := ParseChannels(get("channels"), opt.DecimalComma)
+		if err != nil {
+			linkErrs = append(linkErrs, model.RowError{Row: rowIndex, Line: line("channels"), Field: "channels", Value: get("channels"), Message: err.Error()})
 		}
-		if link.ConnectorLossDb, err = parseFloat(get("connector_loss_db")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "connector_loss_db", Message: "Not a number"})
-			continue
+		link.Channels = channels
+		services, err := ParseServices(get("services"), opt.DecimalComma)
+		if err != nil {
+			linkErrs = append(linkErrs, model.RowError{Row: rowIndex, Line: line("services"), Field: "services", Value: get("services"), Message: err.Error()})
 		}
-		if link.SplitterLossDb, err = parseFloat(get("splitter_loss_db")); err != nil {
-			rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Field: "splitter_loss_db", Message: "Not a number"})
+		link.Services = services
+
+		// Skip rows with parse errors from the output
+		if len(linkErrs) > 0 {
+			rowErrs = append(rowErrs, linkErrs...)
 			continue
 		}
 		output = append(output, link)
 	}
 	return output, rowErrs, nil
-}
\ No newline at end of file
+}
+
+// Define function to parse a numeric cell, empty cells read as zero
+func parseNumber(s string, decimalComma bool) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if decimalComma {
+		// "1.234,5" uses '.' as thousands separator, "0.30" stays as is
+		if strings.Contains(s, ",") {
+			s = strings.ReplaceAll(s, ".", "")
+		}
+		s = strings.ReplaceAll(s, ",", ".")
+	}
+	return strconv.ParseFloat(s, 64)
+}
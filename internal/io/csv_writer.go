@@ -3,50 +3,177 @@ package io
 import (
 	"encoding/csv"
 	"errors"
+	"io"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
 )
 
+// Define default number of digits after the decimal point
+const DefaultPrecision = 6
+
+// Define struct for CSV write options
+type CSVWriteOptions struct {
+	Delimiter    rune // 0 uses ','
+	Precision    int  // Digits after the decimal point, negative uses the shortest exact form
+	DecimalComma bool // Write "1,5" instead of "1.5"
+}
+
 // Define function to write results into CSV format
-func WriteCSV(path string, results []model.LinkOutput, delimiter rune) error {
+func WriteCSV(path string, results []model.LinkOutput, opt CSVWriteOptions) error {
 	// Create or overwrite the CSV file
 	file, err := os.Create(path)
 	if err != nil { // Check if the path is valid
 		return errors.New("Failed to create CSV file: " + err.Error())
 	}
 	defer file.Close()
+	return WriteResults(file, results, opt)
+}
 
+// Define function to write results as CSV into any stream. Columns beyond the
+// original layout are grouped by feature and written only when used.
+func WriteResults(w io.Writer, results []model.LinkOutput, opt CSVWriteOptions) error {
 	// Write CSV headers
-	write := csv.NewWriter(file)
-	if delimiter != 0 {
-		write.Comma = delimiter
+	write := csv.NewWriter(w)
+	if opt.Delimiter != 0 {
+		write.Comma = opt.Delimiter
+	}
+
+	// Format and write each result row
+	formatFloat := NumberFormatter(opt)
+	optionalFloat := func(status string, x float64) string {
+		if status == "" { // Not evaluated for this link
+			return ""
+		}
+		return formatFloat(x)
+	}
+	optionalInt := func(x int) string {
+		if x == 0 { // Not a WDM link
+			return ""
+		}
+		return strconv.Itoa(x)
+	}
+	formatRate := RateFormatter(opt)
+	optionalRate := func(status string, x float64) string {
+		if status == "" { // Not evaluated for this link
+			return ""
+		}
+		return formatRate(x)
+	}
+
+	// Define column groups; the first one is the original results layout and
+	// the others are written only when at least one result carries their data
+	groups := []struct {
+		headers []string
+		used func(model.LinkOutput) bool
+		cells func(model.LinkOutput) []string
+	}{
+		{[]string{
+			"link_id","scenario",
+			"fiber_loss_db","splice_total_db","connector_total_db","total_loss_db",
+			"rx_power_dbm","margin_db","lpb_status",
+			"system_rise_time_ns","allowed_rise_time_ns","rtb_pass",
+			"top_contributor_1","top_contributor_2","top_contributor_3",
+		}, nil, func(res model.LinkOutput) []string {
+			return []string{
+				res.LinkID, res.Scenario,
+				formatFloat(res.FiberLossDb), formatFloat(res.SpliceTotalDb),
+				formatFloat(res.ConnectorTotalDb), formatFloat(res.TotalLossDb),
+				formatFloat(res.RxPowerDbm), formatFloat(res.MarginDb), res.LPBStatus,
+				formatFloat(res.SystemRiseTimeNs), formatFloat(res.AllowedRiseTimeNs), 
+				strconv.FormatBool(res.RTBStatus), res.TopContributor1, res.TopContributor2,
+				res.TopContributor3,
+			}
+		}},
+		{[]string{"compliance_profile","compliance_status","compliance_clause"},
+			func(res model.LinkOutput) bool { return res.ComplianceProfile != "" },
+			func(res model.LinkOutput) []string {
+				return []string{res.ComplianceProfile, res.ComplianceStatus, res.ComplianceClause}
+			}},
+		{[]string{"amplifier_gain_db","osnr_db","osnr_status"},
+			func(res model.LinkOutput) bool { return res.OSNRStatus != "" },
+			func(res model.LinkOutput) []string {
+				return []string{optionalFloat(res.OSNRStatus, res.AmplifierGainDb), optionalFloat(res.OSNRStatus, res.OSNRDb), res.OSNRStatus}
+			}},
+		{[]string{"q_factor","ber","ber_status","post_fec_ber","post_fec_status"},
+			func(res model.LinkOutput) bool { return res.BERStatus != "" },
+			func(res model.LinkOutput) []string {
+				return []string{optionalFloat(res.BERStatus, res.QFactor), optionalRate(res.BERStatus, res.BER), res.BERStatus,
+					optionalRate(res.BERStatus, res.PostFECBER), res.PostFECStatus}
+			}},
+		{[]string{"dispersion_penalty_db","er_penalty_db","rin_penalty_db","mpn_penalty_db","reflection_penalty_db","chirp_penalty_db","penalty_total_db","unbounded_penalties"},
+			func(res model.LinkOutput) bool { return res.PenaltyTotalDb != 0 || len(res.UnboundedPenalties) > 0 },
+			func(res model.LinkOutput) []string {
+				return []string{formatFloat(res.DispersionPenaltyDb), formatFloat(res.ExtinctionPenaltyDb), formatFloat(res.RINPenaltyDb),
+					formatFloat(res.MPNPenaltyDb), formatFloat(res.ReflectionPenaltyDb), formatFloat(res.ChirpPenaltyDb), formatFloat(res.PenaltyTotalDb),
+					strings.Join(res.UnboundedPenalties, "+")}
+			}},
+		{[]string{"dgd_ps","max_dgd_ps","pmd_status"},
+			func(res model.LinkOutput) bool { return res.PMDStatus != "" },
+			func(res model.LinkOutput) []string {
+				return []string{optionalFloat(res.PMDStatus, res.DGDPs), optionalFloat(res.PMDStatus, res.MaxDGDPs), res.PMDStatus}
+			}},
+		{[]string{"wdm_loss_db","channel_count","worst_channel","worst_channel_nm"},
+			func(res model.LinkOutput) bool { return res.WDMLossDb != 0 || res.ChannelCount > 0 },
+			func(res model.LinkOutput) []string {
+				return []string{formatFloat(res.WDMLossDb), optionalInt(res.ChannelCount), res.WorstChannel, optionalFloat(res.WorstChannel, res.WorstChannelNm)}
+			}},
+		{[]string{"limiting_service"},
+			func(res model.LinkOutput) bool { return res.LimitingService != "" },
+			func(res model.LinkOutput) []string { return []string{res.LimitingService} }},
+		{[]string{"orl_db","reflection_status","worst_reflection_event","worst_reflectance_db"},
+			func(res model.LinkOutput) bool { return res.ReflectionStatus != "" },
+			func(res model.LinkOutput) []string {
+				return []string{optionalFloat(res.ReflectionStatus, res.ORLDb), res.ReflectionStatus, res.WorstReflectionEvent,
+					optionalFloat(res.WorstReflectionEvent, res.WorstReflectanceDb)}
+			}},
+		{[]string{"repair_allowance_db","temperature_allowance_db","aging_allowance_db","cable_cut_allowance_db","allowance_total_db"},
+			func(res model.LinkOutput) bool { return res.AllowanceTotalDb != 0 },
+			func(res model.LinkOutput) []string {
+				return []string{formatFloat(res.RepairAllowanceDb), formatFloat(res.TemperatureAllowanceDb), formatFloat(res.AgingAllowanceDb),
+					formatFloat(res.CableCutAllowanceDb), formatFloat(res.AllowanceTotalDb)}
+			}},
+		{[]string{"effective_length_km","sbs_threshold_dbm","spm_threshold_dbm","nonlinear_phase_rad","sbs_penalty_db","spm_penalty_db","nonlinear_status"},
+			func(res model.LinkOutput) bool { return res.NonlinearStatus != "" },
+			func(res model.LinkOutput) []string {
+				return []string{optionalFloat(res.NonlinearStatus, res.EffectiveLengthKm), optionalFloat(res.NonlinearStatus, res.SBSThresholdDbm),
+					optionalFloat(res.NonlinearStatus, res.SPMThresholdDbm), optionalFloat(res.NonlinearStatus, res.NonlinearPhaseRad),
+					optionalFloat(res.NonlinearStatus, res.SBSPenaltyDb), optionalFloat(res.NonlinearStatus, res.SPMPenaltyDb), res.NonlinearStatus}
+			}},
+		{[]string{"modal_rise_time_ns","phy_reach_m","phy_max_channel_loss_db","phy_limit","phy_status"},
+			func(res model.LinkOutput) bool { return res.PHYStatus != "" || res.ModalRiseTimeNs != 0 },
+			func(res model.LinkOutput) []string {
+				return []string{formatFloat(res.ModalRiseTimeNs), optionalFloat(res.PHYStatus, res.PHYReachM),
+					optionalFloat(res.PHYStatus, res.PHYMaxChannelLossDb), res.PHYLimit, res.PHYStatus}
+			}},
 	}
 
-	// Define header row
-	headers := []string{
-		"link_id","scenario",
-		"fiber_loss_db","splice_total_db","connector_total_db","total_loss_db",
-		"rx_power_dbm","margin_db","lpb_status",
-		"system_rise_time_ns","allowed_rise_time_ns","rtb_pass",
-		"top_contributor_1","top_contributor_2","top_contributor_3",
+	// Keep the groups that carry data for at least one result
+	var headers []string
+	var cells []func(model.LinkOutput) []string
+	for _, g := range groups {
+		used := g.used == nil
+		for _, res := range results {
+			if used {
+				break
+			}
+			used = g.used(res)
+		}
+		if used {
+			headers = append(headers, g.headers...)
+			cells = append(cells, g.cells)
+		}
 	}
 	if err := write.Write(headers); err != nil {
 		return errors.New("An error has occurred while writing CSV headers: " + err.Error())
 	}
 
-	// Format and write each result row
-	formatFloat := func(x float64) string { return strconv.FormatFloat(x, 'f', 6, 64) }
 	for _, res := range results { // Iterate over results
-		row := []string {
-			res.LinkID, res.Scenario,
-			formatFloat(res.FiberLossDb), formatFloat(res.SpliceTotalDb),
-			formatFloat(res.ConnectorTotalDb), formatFloat(res.TotalLossDb),
-			formatFloat(res.RxPowerDbm), formatFloat(res.MarginDb), res.LPBStatus,
-			formatFloat(res.SystemRiseTimeNs), formatFloat(res.AllowedRiseTimeNs), 
-			strconv.FormatBool(res.RTBStatus), res.TopContributor1, res.TopContributor2,
-			res.TopContributor3,
+		row := make([]string, 0, len(headers))
+		for _, cell := range cells {
+			row = append(row, cell(res)...)
 		}
 		if err := write.Write(row); err != nil { // Check for write errors
 			return errors.New("An error has occurred while writing CSV row: " + err.Error())
@@ -54,4 +181,31 @@ func WriteCSV(path string, results []model.LinkOutput, delimiter rune) error {
 	}
 	write.Flush()
 	return write.Error()
-} 
\ No newline at end of file
+}
+
+// Define function to build the formatter for error rates, which need scientific
+// notation whatever the precision
+func RateFormatter(opt CSVWriteOptions) func(float64) string {
+	return func(x float64) string {
+		s := strconv.FormatFloat(x, 'e', 3, 64)
+		if opt.DecimalComma {
+			s = strings.Replace(s, ".", ",", 1)
+		}
+		return s
+	}
+}
+
+// Define function to build the number formatter for the given write options
+func NumberFormatter(opt CSVWriteOptions) func(float64) string {
+	precision := opt.Precision
+	if precision < 0 {
+		precision = -1
+	}
+	return func(x float64) string {
+		s := strconv.FormatFloat(x, 'f', precision, 64)
+		if opt.DecimalComma {
+			s = strings.Replace(s, ".", ",", 1)
+		}
+		return s
+	}
+}
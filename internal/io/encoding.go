@@ -0,0 +1,64 @@
+package io
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Define byte order marks
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// Define function to detect the input encoding from its byte order mark and
+// return a UTF-8 reader. Files without a BOM are read as UTF-8.
+func decodeInput(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(3)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(head, bomUTF8):
+		_, _ = br.Discard(len(bomUTF8))
+		return br, nil
+	case bytes.HasPrefix(head, bomUTF16LE):
+		_, _ = br.Discard(len(bomUTF16LE))
+		return decodeUTF16(br, false)
+	case bytes.HasPrefix(head, bomUTF16BE):
+		_, _ = br.Discard(len(bomUTF16BE))
+		return decodeUTF16(br, true)
+	}
+	return br, nil
+}
+
+// Define function to transcode UTF-16 (as saved by Excel "Unicode Text") to UTF-8
+func decodeUTF16(r io.Reader, bigEndian bool) (io.Reader, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw)%2 != 0 {
+		return nil, errors.New("UTF-16 input has an odd number of bytes")
+	}
+	units := make([]uint16, len(raw)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(raw[2*i])<<8 | uint16(raw[2*i+1])
+		} else {
+			units[i] = uint16(raw[2*i+1])<<8 | uint16(raw[2*i])
+		}
+	}
+	runes := utf16.Decode(units)
+	out := make([]byte, 0, len(runes))
+	for _, c := range runes {
+		out = utf8.AppendRune(out, c)
+	}
+	return bytes.NewReader(out), nil
+}
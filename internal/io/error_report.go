@@ -0,0 +1,80 @@
+package io
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define function to write row errors as a machine-readable report.
+// The format is picked from the file extension: .json or .csv.
+func WriteErrorReport(path string, errs []model.RowError) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return writeErrorReportJSON(path, errs)
+	case ".csv":
+		return writeErrorReportCSV(path, errs)
+	default:
+		return errors.New("Unsupported error report format: " + path + " (use .json or .csv)")
+	}
+}
+
+// Define function to write the error report as JSON
+func writeErrorReportJSON(path string, errs []model.RowError) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.New("Failed to create error report: " + err.Error())
+	}
+	defer file.Close()
+
+	// Always emit an array, even when there are no errors
+	if errs == nil {
+		errs = []model.RowError{}
+	}
+	enc := json.NewEncoder(file)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(errs); err != nil {
+		return errors.New("An error has occurred while writing error report: " + err.Error())
+	}
+	return nil
+}
+
+// Define function to write the error report as CSV
+func writeErrorReportCSV(path string, errs []model.RowError) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.New("Failed to create error report: " + err.Error())
+	}
+	defer file.Close()
+
+	write := csv.NewWriter(file)
+	if err := write.Write([]string{"row", "line", "field", "value", "message", "severity", "refs"}); err != nil {
+		return errors.New("An error has occurred while writing CSV headers: " + err.Error())
+	}
+	for _, e := range errs {
+		severity := e.Severity
+		if severity == "" {
+			severity = model.SeverityError
+		}
+		refs := make([]string, len(e.Refs))
+		for i, r := range e.Refs {
+			refs[i] = strconv.Itoa(r)
+		}
+		row := []string{
+			strconv.Itoa(e.Row), strconv.Itoa(e.Line),
+			e.Field, e.Value, e.Message, severity,
+			strings.Join(refs, ";"),
+		}
+		if err := write.Write(row); err != nil {
+			return errors.New("An error has occurred while writing CSV row: " + err.Error())
+		}
+	}
+	write.Flush()
+	return write.Error()
+}
@@ -0,0 +1,170 @@
+package io
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define function to write link inputs into a CSV file readable by ReadLinksCSV
+func WriteLinksCSV(path string, links []model.LinkInput, opt CSVWriteOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.New("Failed to create CSV file: " + err.Error())
+	}
+	defer file.Close()
+	return WriteLinks(file, links, opt)
+}
+
+// Define function to write link inputs as CSV. Optional columns are written
+// only when at least one link sets them.
+func WriteLinks(w io.Writer, links []model.LinkInput, opt CSVWriteOptions) error {
+	write := csv.NewWriter(w)
+	if opt.Delimiter != 0 {
+		write.Comma = opt.Delimiter
+	}
+	formatFloat := NumberFormatter(opt)
+	formatInt := func(x int) string { return strconv.Itoa(x) }
+	formatRate := RateFormatter(opt)
+
+	// Define every column with its cell formatter
+	columns := []struct {
+		name string
+		cell func(model.LinkInput) string
+	}{
+		{"link_id", func(l model.LinkInput) string { return l.LinkID }},
+		{"scenario", func(l model.LinkInput) string { return l.Scenario }},
+		{"tx_power_dbm", func(l model.LinkInput) string { return formatFloat(l.TXPowerDbm) }},
+		{"rx_sensitivity_dbm", func(l model.LinkInput) string { return formatFloat(l.RXSensitivityDbm) }},
+		{"system_margin_db", func(l model.LinkInput) string { return formatFloat(l.SystemMarginDb) }},
+		{"fiber_length_km", func(l model.LinkInput) string { return formatFloat(l.FiberLengthKm) }},
+		{"fiber_att_db_per_km", func(l model.LinkInput) string { return formatFloat(l.FiberAttDbPerKm) }},
+		{"n_splice", func(l model.LinkInput) string { return formatInt(l.NSplice) }},
+		{"splice_loss_db", func(l model.LinkInput) string { return formatFloat(l.SpliceLossDb) }},
+		{"n_connector", func(l model.LinkInput) string { return formatInt(l.NConnectors) }},
+		{"connector_loss_db", func(l model.LinkInput) string { return formatFloat(l.ConnectorLossDb) }},
+		{"splitter_loss_db", func(l model.LinkInput) string { return formatFloat(l.SplitterLossDb) }},
+		{"other_loss_db", func(l model.LinkInput) string { return formatFloat(l.OtherLossDb) }},
+		{"wavelength_nm", func(l model.LinkInput) string { return formatFloat(l.WavelengthNm) }},
+		{"fiber_type", func(l model.LinkInput) string { return l.FiberType }},
+		{"splitter_id", func(l model.LinkInput) string { return l.SplitterID }},
+		{"splitter_port", func(l model.LinkInput) string { return formatInt(l.SplitterPort) }},
+		{"split_ratio", func(l model.LinkInput) string { return formatInt(l.SplitRatio) }},
+		{"amplifiers", func(l model.LinkInput) string { return FormatAmplifiers(l.Amplifiers, formatFloat) }},
+		{"required_osnr_db", func(l model.LinkInput) string { return formatFloat(l.RequiredOSNRDb) }},
+		{"sensitivity_ber", func(l model.LinkInput) string { return formatRate(l.SensitivityBER) }},
+		{"q_slope", func(l model.LinkInput) string { return formatFloat(l.QSlope) }},
+		{"fec", func(l model.LinkInput) string { return l.FEC }},
+		{"target_ber", func(l model.LinkInput) string { return formatRate(l.TargetBER) }},
+		{"dispersion_ps_nm_km", func(l model.LinkInput) string { return formatFloat(l.DispersionPsNmKm) }},
+		{"spectral_width_nm", func(l model.LinkInput) string { return formatFloat(l.SpectralWidthNm) }},
+		{"extinction_ratio_db", func(l model.LinkInput) string { return formatFloat(l.ExtinctionRatioDb) }},
+		{"rin_db_hz", func(l model.LinkInput) string { return formatFloat(l.RINDbHz) }},
+		{"mpn_k", func(l model.LinkInput) string { return formatFloat(l.MPNFactor) }},
+		{"dispersion_penalty_db", func(l model.LinkInput) string { return formatFloat(l.DispersionPenaltyDb) }},
+		{"er_penalty_db", func(l model.LinkInput) string { return formatFloat(l.ExtinctionPenaltyDb) }},
+		{"rin_penalty_db", func(l model.LinkInput) string { return formatFloat(l.RINPenaltyDb) }},
+		{"mpn_penalty_db", func(l model.LinkInput) string { return formatFloat(l.MPNPenaltyDb) }},
+		{"reflection_penalty_db", func(l model.LinkInput) string { return formatFloat(l.ReflectionPenaltyDb) }},
+		{"chirp_penalty_db", func(l model.LinkInput) string { return formatFloat(l.ChirpPenaltyDb) }},
+		{"pmd_ps_sqrt_km", func(l model.LinkInput) string { return formatFloat(l.PMDPsSqrtKm) }},
+		{"bitrate_gbps", func(l model.LinkInput) string { return formatFloat(l.BitrateGbps) }},
+		{"channels", func(l model.LinkInput) string { return FormatChannels(l.Channels) }},
+		{"mux_loss_db", func(l model.LinkInput) string { return formatFloat(l.MuxLossDb) }},
+		{"demux_loss_db", func(l model.LinkInput) string { return formatFloat(l.DemuxLossDb) }},
+		{"n_oadm", func(l model.LinkInput) string { return formatInt(l.NOADM) }},
+		{"oadm_loss_db", func(l model.LinkInput) string { return formatFloat(l.OADMLossDb) }},
+		{"services", func(l model.LinkInput) string { return FormatServices(l.Services, formatFloat) }},
+		{"connector_type", func(l model.LinkInput) string { return l.ConnectorType }},
+		{"connector_reflectance_db", func(l model.LinkInput) string { return formatFloat(l.ConnectorReflectanceDb) }},
+		{"splice_reflectance_db", func(l model.LinkInput) string { return formatFloat(l.SpliceReflectanceDb) }},
+		{"orl_tolerance_db", func(l model.LinkInput) string { return formatFloat(l.ORLToleranceDb) }},
+		{"cable_type", func(l model.LinkInput) string { return l.CableType }},
+		{"repair_splices_per_km", func(l model.LinkInput) string { return formatFloat(l.RepairSplicesPerKm) }},
+		{"temp_drift_db_per_km", func(l model.LinkInput) string { return formatFloat(l.TempDriftDbPerKm) }},
+		{"aging_margin_db", func(l model.LinkInput) string { return formatFloat(l.AgingMarginDb) }},
+		{"cable_cut_margin_db", func(l model.LinkInput) string { return formatFloat(l.CableCutMarginDb) }},
+		{"source_linewidth_mhz", func(l model.LinkInput) string { return formatFloat(l.SourceLinewidthMHz) }},
+		{"effective_area_um2", func(l model.LinkInput) string { return formatFloat(l.EffectiveAreaUm2) }},
+		{"phy", func(l model.LinkInput) string { return l.PHY }},
+	}
+
+	// Keep required columns and the optional columns that carry data
+	used := make(map[string]bool, len(OptionalColumns))
+	for _, l := range links {
+		used["wavelength_nm"] = used["wavelength_nm"] || l.WavelengthNm != 0
+		used["fiber_type"] = used["fiber_type"] || l.FiberType != ""
+		used["splitter_id"] = used["splitter_id"] || l.SplitterID != ""
+		used["splitter_port"] = used["splitter_port"] || l.SplitterPort != 0
+		used["split_ratio"] = used["split_ratio"] || l.SplitRatio != 0
+		used["amplifiers"] = used["amplifiers"] || len(l.Amplifiers) > 0
+		used["required_osnr_db"] = used["required_osnr_db"] || l.RequiredOSNRDb != 0
+		used["sensitivity_ber"] = used["sensitivity_ber"] || l.SensitivityBER != 0
+		used["q_slope"] = used["q_slope"] || l.QSlope != 0
+		used["fec"] = used["fec"] || l.FEC != ""
+		used["target_ber"] = used["target_ber"] || l.TargetBER != 0
+		used["dispersion_ps_nm_km"] = used["dispersion_ps_nm_km"] || l.DispersionPsNmKm != 0
+		used["spectral_width_nm"] = used["spectral_width_nm"] || l.SpectralWidthNm != 0
+		used["extinction_ratio_db"] = used["extinction_ratio_db"] || l.ExtinctionRatioDb != 0
+		used["rin_db_hz"] = used["rin_db_hz"] || l.RINDbHz != 0
+		used["mpn_k"] = used["mpn_k"] || l.MPNFactor != 0
+		used["dispersion_penalty_db"] = used["dispersion_penalty_db"] || l.DispersionPenaltyDb != 0
+		used["er_penalty_db"] = used["er_penalty_db"] || l.ExtinctionPenaltyDb != 0
+		used["rin_penalty_db"] = used["rin_penalty_db"] || l.RINPenaltyDb != 0
+		used["mpn_penalty_db"] = used["mpn_penalty_db"] || l.MPNPenaltyDb != 0
+		used["reflection_penalty_db"] = used["reflection_penalty_db"] || l.ReflectionPenaltyDb != 0
+		used["chirp_penalty_db"] = used["chirp_penalty_db"] || l.ChirpPenaltyDb != 0
+		used["pmd_ps_sqrt_km"] = used["pmd_ps_sqrt_km"] || l.PMDPsSqrtKm != 0
+		used["bitrate_gbps"] = used["bitrate_gbps"] || l.BitrateGbps != 0
+		used["channels"] = used["channels"] || len(l.Channels) > 0
+		used["mux_loss_db"] = used["mux_loss_db"] || l.MuxLossDb != 0
+		used["demux_loss_db"] = used["demux_loss_db"] || l.DemuxLossDb != 0
+		used["n_oadm"] = used["n_oadm"] || l.NOADM != 0
+		used["oadm_loss_db"] = used["oadm_loss_db"] || l.OADMLossDb != 0
+		used["services"] = used["services"] || len(l.Services) > 0
+		used["connector_type"] = used["connector_type"] || l.ConnectorType != ""
+		used["connector_reflectance_db"] = used["connector_reflectance_db"] || l.ConnectorReflectanceDb != 0
+		used["splice_reflectance_db"] = used["splice_reflectance_db"] || l.SpliceReflectanceDb != 0
+		used["orl_tolerance_db"] = used["orl_tolerance_db"] || l.ORLToleranceDb != 0
+		used["cable_type"] = used["cable_type"] || l.CableType != ""
+		used["repair_splices_per_km"] = used["repair_splices_per_km"] || l.RepairSplicesPerKm != 0
+		used["temp_drift_db_per_km"] = used["temp_drift_db_per_km"] || l.TempDriftDbPerKm != 0
+		used["aging_margin_db"] = used["aging_margin_db"] || l.AgingMarginDb != 0
+		used["cable_cut_margin_db"] = used["cable_cut_margin_db"] || l.CableCutMarginDb != 0
+		used["source_linewidth_mhz"] = used["source_linewidth_mhz"] || l.SourceLinewidthMHz != 0
+		used["effective_area_um2"] = used["effective_area_um2"] || l.EffectiveAreaUm2 != 0
+		used["phy"] = used["phy"] || l.PHY != ""
+	}
+	optional := make(map[string]bool, len(OptionalColumns))
+	for _, name := range OptionalColumns {
+		optional[name] = true
+	}
+	var headers []string
+	var cells []func(model.LinkInput) string
+	for _, c := range columns {
+		if optional[c.name] && !used[c.name] {
+			continue
+		}
+		headers = append(headers, c.name)
+		cells = append(cells, c.cell)
+	}
+
+	if err := write.Write(headers); err != nil {
+		return errors.New("An error has occurred while writing CSV headers: " + err.Error())
+	}
+	row := make([]string, len(cells))
+	for _, l := range links {
+		for i, cell := range cells {
+			row[i] = cell(l)
+		}
+		if err := write.Write(row); err != nil {
+			return errors.New("An error has occurred while writing CSV row: " + err.Error())
+		}
+	}
+	write.Flush()
+	return write.Error()
+}
@@ -0,0 +1,60 @@
+package io
+
+import (
+	"errors"
+	"strings"
+)
+
+// Define locales whose numbers use a decimal comma. Lookup uses the language
+// part of tags such as "id-ID" or "de_DE".
+var decimalCommaLanguages = map[string]bool{
+	"id": true, "de": true, "fr": true, "es": true, "it": true, "nl": true,
+	"pt": true, "ru": true, "pl": true, "tr": true, "sv": true, "da": true,
+	"fi": true, "nb": true, "cs": true, "vi": true,
+}
+
+// Define locales whose numbers use a decimal point
+var decimalPointLanguages = map[string]bool{
+	"en": true, "ja": true, "zh": true, "ko": true, "th": true, "ms": true, "c": true,
+}
+
+// Define function to report whether a locale writes numbers with a decimal comma.
+// An empty locale means the decimal point.
+func LocaleDecimalComma(locale string) (bool, error) {
+	if locale == "" {
+		return false, nil
+	}
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "-_."); i >= 0 {
+		lang = lang[:i]
+	}
+	switch {
+	case decimalCommaLanguages[lang]:
+		return true, nil
+	case decimalPointLanguages[lang]:
+		return false, nil
+	}
+	return false, errors.New("Unknown locale: " + locale)
+}
+
+// Define function to parse a delimiter flag value. Accepts a single character
+// or the names "tab", "comma", "semicolon" and "pipe".
+func ParseDelimiter(s string) (rune, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return 0, nil
+	case "tab", `\t`:
+		return '\t', nil
+	case "comma":
+		return ',', nil
+	case "semicolon":
+		return ';', nil
+	case "pipe":
+		return '|', nil
+	}
+	r := []rune(s)
+	if len(r) != 1 || r[0] == '"' || r[0] == '\r' || r[0] == '\n' {
+		return 0, errors.New("Invalid delimiter: " + s)
+	}
+	return r[0], nil
+}
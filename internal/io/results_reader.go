@@ -0,0 +1,188 @@
+package io
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Columns a results file must carry to be analysed
+var RequiredResultColumns = []string{"link_id", "scenario", "margin_db", "lpb_status"}
+
+// Define function to read a results CSV written by run or sweep
+func ReadResultsCSV(path string, opt CSVReadOptions) ([]model.LinkOutput, []model.RowError, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, errors.New("Failed to open CSV file: " + err.Error())
+	}
+	defer file.Close()
+	return ReadResults(file, opt)
+}
+
+// Define function to read results from any stream. Missing optional columns
+// stay at their zero value; rows with bad numbers are reported and skipped.
+func ReadResults(r io.Reader, opt CSVReadOptions) ([]model.LinkOutput, []model.RowError, error) {
+	decoded, err := decodeInput(r)
+	if err != nil {
+		return nil, nil, errors.New("Failed to read CSV file: " + err.Error())
+	}
+	reader := csv.NewReader(decoded)
+	if opt.Delimiter != 0 {
+		reader.Comma = opt.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.New("Failed to read CSV file: " + err.Error())
+	}
+	col := make(map[string]int, len(header))
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	var schemaErrors []model.RowError
+	for _, req := range RequiredResultColumns {
+		if _, ok := col[req]; !ok {
+			schemaErrors = append(schemaErrors, model.RowError{Row: 0, Line: 1, Field: req, Message: "Missing required column"})
+		}
+	}
+	if len(schemaErrors) > 0 {
+		return nil, schemaErrors, errors.New("CSV schema validation failed")
+	}
+
+	var output []model.LinkOutput
+	var rowErrs []model.RowError
+	rowIndex := 0
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowIndex++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrs = append(rowErrs, model.RowError{Row: rowIndex, Line: parseErr.Line, Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, errors.New("Failed to read CSV file: " + err.Error())
+		}
+		get := func(name string) string {
+			idx, ok := col[name]
+			if !ok || idx >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[idx])
+		}
+		line, _ := reader.FieldPos(0)
+
+		res := model.LinkOutput{
+			LinkID:               get("link_id"),
+			Scenario:             get("scenario"),
+			LPBStatus:            strings.ToUpper(get("lpb_status")),
+			TopContributor1:      get("top_contributor_1"),
+			TopContributor2:      get("top_contributor_2"),
+			TopContributor3:      get("top_contributor_3"),
+			ComplianceProfile:    get("compliance_profile"),
+			ComplianceStatus:     get("compliance_status"),
+			ComplianceClause:     get("compliance_clause"),
+			OSNRStatus:           get("osnr_status"),
+			BERStatus:            get("ber_status"),
+			PostFECStatus:        get("post_fec_status"),
+			PMDStatus:            get("pmd_status"),
+			WorstChannel:         get("worst_channel"),
+			LimitingService:      get("limiting_service"),
+			ReflectionStatus:     get("reflection_status"),
+			WorstReflectionEvent: get("worst_reflection_event"),
+			NonlinearStatus:      get("nonlinear_status"),
+			PHYLimit:             get("phy_limit"),
+			PHYStatus:            get("phy_status"),
+		}
+		if unbounded := get("unbounded_penalties"); unbounded != "" {
+			res.UnboundedPenalties = strings.Split(unbounded, "+")
+		}
+		floatFields := []struct {
+			name string
+			dst  *float64
+		}{
+			{"fiber_loss_db", &res.FiberLossDb},
+			{"splice_total_db", &res.SpliceTotalDb},
+			{"connector_total_db", &res.ConnectorTotalDb},
+			{"total_loss_db", &res.TotalLossDb},
+			{"rx_power_dbm", &res.RxPowerDbm},
+			{"margin_db", &res.MarginDb},
+			{"system_rise_time_ns", &res.SystemRiseTimeNs},
+			{"allowed_rise_time_ns", &res.AllowedRiseTimeNs},
+			{"amplifier_gain_db", &res.AmplifierGainDb},
+			{"osnr_db", &res.OSNRDb},
+			{"q_factor", &res.QFactor},
+			{"ber", &res.BER},
+			{"post_fec_ber", &res.PostFECBER},
+			{"dispersion_penalty_db", &res.DispersionPenaltyDb},
+			{"er_penalty_db", &res.ExtinctionPenaltyDb},
+			{"rin_penalty_db", &res.RINPenaltyDb},
+			{"mpn_penalty_db", &res.MPNPenaltyDb},
+			{"reflection_penalty_db", &res.ReflectionPenaltyDb},
+			{"chirp_penalty_db", &res.ChirpPenaltyDb},
+			{"penalty_total_db", &res.PenaltyTotalDb},
+			{"dgd_ps", &res.DGDPs},
+			{"max_dgd_ps", &res.MaxDGDPs},
+			{"wdm_loss_db", &res.WDMLossDb},
+			{"worst_channel_nm", &res.WorstChannelNm},
+			{"orl_db", &res.ORLDb},
+			{"worst_reflectance_db", &res.WorstReflectanceDb},
+			{"repair_allowance_db", &res.RepairAllowanceDb},
+			{"temperature_allowance_db", &res.TemperatureAllowanceDb},
+			{"aging_allowance_db", &res.AgingAllowanceDb},
+			{"cable_cut_allowance_db", &res.CableCutAllowanceDb},
+			{"allowance_total_db", &res.AllowanceTotalDb},
+			{"effective_length_km", &res.EffectiveLengthKm},
+			{"sbs_threshold_dbm", &res.SBSThresholdDbm},
+			{"spm_threshold_dbm", &res.SPMThresholdDbm},
+			{"nonlinear_phase_rad", &res.NonlinearPhaseRad},
+			{"sbs_penalty_db", &res.SBSPenaltyDb},
+			{"spm_penalty_db", &res.SPMPenaltyDb},
+			{"modal_rise_time_ns", &res.ModalRiseTimeNs},
+			{"phy_reach_m", &res.PHYReachM},
+			{"phy_max_channel_loss_db", &res.PHYMaxChannelLossDb},
+		}
+		var linkErrs []model.RowError
+		for _, f := range floatFields {
+			raw := get(f.name)
+			value, err := parseNumber(raw, opt.DecimalComma)
+			if err != nil {
+				linkErrs = append(linkErrs, model.RowError{Row: rowIndex, Line: line, Field: f.name, Value: raw, Message: "Not a number"})
+				continue
+			}
+			*f.dst = value
+		}
+		if raw := get("margin_db"); raw == "" {
+			linkErrs = append(linkErrs, model.RowError{Row: rowIndex, Line: line, Field: "margin_db", Message: "Missing margin"})
+		}
+		if raw := get("rtb_pass"); raw != "" {
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				linkErrs = append(linkErrs, model.RowError{Row: rowIndex, Line: line, Field: "rtb_pass", Value: raw, Message: "Not a boolean value"})
+			}
+			res.RTBStatus = b
+		}
+		if raw := get("channel_count"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				linkErrs = append(linkErrs, model.RowError{Row: rowIndex, Line: line, Field: "channel_count", Value: raw, Message: "Not an integer value"})
+			}
+			res.ChannelCount = n
+		}
+		if len(linkErrs) > 0 {
+			rowErrs = append(rowErrs, linkErrs...)
+			continue
+		}
+		output = append(output, res)
+	}
+	return output, rowErrs, nil
+}
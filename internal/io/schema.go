@@ -15,3 +15,55 @@ var RequiredColumns = []string{
 	"splitter_loss_db",
 	"other_loss_db",
 }
+
+// Optional columns are read when present and left at zero value otherwise
+var OptionalColumns = []string{
+	"wavelength_nm",
+	"fiber_type",
+	"splitter_id",
+	"splitter_port",
+	"split_ratio",
+	"amplifiers",
+	"required_osnr_db",
+	"sensitivity_ber",
+	"q_slope",
+	"fec",
+	"target_ber",
+	"dispersion_ps_nm_km",
+	"spectral_width_nm",
+	"extinction_ratio_db",
+	"rin_db_hz",
+	"mpn_k",
+	"dispersion_penalty_db",
+	"er_penalty_db",
+	"rin_penalty_db",
+	"mpn_penalty_db",
+	"reflection_penalty_db",
+	"chirp_penalty_db",
+	"pmd_ps_sqrt_km",
+	"bitrate_gbps",
+	"channels",
+	"mux_loss_db",
+	"demux_loss_db",
+	"n_oadm",
+	"oadm_loss_db",
+	"services",
+	"connector_type",
+	"connector_reflectance_db",
+	"splice_reflectance_db",
+	"orl_tolerance_db",
+	"cable_type",
+	"repair_splices_per_km",
+	"temp_drift_db_per_km",
+	"aging_margin_db",
+	"cable_cut_margin_db",
+	"source_linewidth_mhz",
+	"effective_area_um2",
+	"phy",
+}
+
+// Column aliases accepted on input, mapped to their canonical name.
+// engineering_margin_db is the header written by the legacy Python generator.
+var ColumnAliases = map[string]string{
+	"engineering_margin_db": "system_margin_db",
+}
@@ -0,0 +1,62 @@
+package io
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define function to parse the services column. Entries are separated by '|'
+// and written as name[:param=value...], e.g. "gpon|xgs-pon:cex=1.2:up_tx=5|rf-video".
+// Names and parameters are checked by validation against the service catalog.
+func ParseServices(s string, decimalComma bool) ([]model.Service, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var svcs []model.Service
+	for _, entry := range strings.Split(s, "|") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		svc := model.Service{Name: strings.ToLower(strings.TrimSpace(parts[0]))}
+		if svc.Name == "" {
+			return nil, errors.New("Service " + strconv.Quote(entry) + " has no name")
+		}
+		for _, p := range parts[1:] {
+			key, raw, ok := strings.Cut(p, "=")
+			if !ok {
+				return nil, errors.New("Service " + strconv.Quote(entry) + " parameter " + strconv.Quote(p) + " is not key=value")
+			}
+			v, err := parseNumber(raw, decimalComma)
+			if err != nil {
+				return nil, errors.New("Service " + strconv.Quote(entry) + " has a bad number " + strconv.Quote(raw))
+			}
+			if svc.Params == nil {
+				svc.Params = make(map[string]float64)
+			}
+			svc.Params[strings.ToLower(strings.TrimSpace(key))] = v
+		}
+		svcs = append(svcs, svc)
+	}
+	return svcs, nil
+}
+
+// Define function to format services back into the column syntax
+func FormatServices(svcs []model.Service, formatFloat func(float64) string) string {
+	entries := make([]string, len(svcs))
+	for i, svc := range svcs {
+		keys := make([]string, 0, len(svc.Params))
+		for k := range svc.Params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		e := svc.Name
+		for _, k := range keys {
+			e += ":" + k + "=" + formatFloat(svc.Params[k])
+		}
+		entries[i] = e
+	}
+	return strings.Join(entries, "|")
+}
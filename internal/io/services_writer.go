@@ -0,0 +1,54 @@
+package io
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define function to write the per-service table of coexistence results to a CSV file
+func WriteServicesCSV(path string, results []model.LinkOutput, opt CSVWriteOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.New("Failed to create services file: " + err.Error())
+	}
+	defer file.Close()
+	return WriteServices(file, results, opt)
+}
+
+// Define function to write one row per service direction; links without services are skipped
+func WriteServices(w io.Writer, results []model.LinkOutput, opt CSVWriteOptions) error {
+	write := csv.NewWriter(w)
+	if opt.Delimiter != 0 {
+		write.Comma = opt.Delimiter
+	}
+	headers := []string{
+		"link_id", "scenario", "service", "direction", "limiting", "wavelength_nm", "filter_loss_db",
+		"tx_power_dbm", "rx_sensitivity_dbm", "rx_power_dbm", "margin_db", "overload_margin_db", "status",
+	}
+	if err := write.Write(headers); err != nil {
+		return errors.New("An error has occurred while writing CSV headers: " + err.Error())
+	}
+	formatFloat := NumberFormatter(opt)
+	for _, res := range results {
+		for _, s := range res.Services {
+			limiting := ""
+			if s.Service+"/"+s.Direction == res.LimitingService {
+				limiting = "*"
+			}
+			row := []string{
+				res.LinkID, res.Scenario, s.Service, s.Direction, limiting, formatFloat(s.WavelengthNm), formatFloat(s.FilterLossDb),
+				formatFloat(s.TxPowerDbm), formatFloat(s.RxSensitivityDbm), formatFloat(s.RxPowerDbm),
+				formatFloat(s.MarginDb), formatFloat(s.OverloadMarginDb), s.Status,
+			}
+			if err := write.Write(row); err != nil {
+				return errors.New("An error has occurred while writing CSV row: " + err.Error())
+			}
+		}
+	}
+	write.Flush()
+	return write.Error()
+}
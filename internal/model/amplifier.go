@@ -0,0 +1,16 @@
+package model
+
+// Define amplifier types
+const (
+	AmplifierEDFA = "edfa"
+	AmplifierSOA  = "soa"
+)
+
+// Define struct for an inline optical amplifier
+type Amplifier struct {
+	Type          string  `json:"type"`            // "edfa" or "soa"
+	PositionKm    float64 `json:"position_km"`     // Distance from the transmitter
+	GainDb        float64 `json:"gain_db"`         // Small-signal gain
+	NoiseFigureDb float64 `json:"noise_figure_db"` // Noise figure
+	PsatDbm       float64 `json:"psat_dbm"`        // Output saturation power, 0 means unlimited
+}
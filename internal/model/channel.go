@@ -0,0 +1,20 @@
+package model
+
+// Define struct for one channel of a WDM channel plan
+type Channel struct {
+	Label        string  `json:"label"`         // Grid name, e.g. "C21" or "1471"
+	WavelengthNm float64 `json:"wavelength_nm"` // Center wavelength
+}
+
+// Define struct for the evaluation of one WDM channel
+type ChannelOutput struct {
+	Label            string  `json:"label"`
+	WavelengthNm     float64 `json:"wavelength_nm"`
+	FiberAttDbPerKm  float64 `json:"fiber_att_db_per_km"` // Attenuation at the channel wavelength
+	DispersionPsNmKm float64 `json:"dispersion_ps_nm_km"` // Chromatic dispersion at the channel wavelength
+	TotalLossDb      float64 `json:"total_loss_db"`
+	PenaltyTotalDb   float64 `json:"penalty_total_db"`
+	RxPowerDbm       float64 `json:"rx_power_dbm"`
+	MarginDb         float64 `json:"margin_db"`
+	LPBStatus        string  `json:"lpb_status"`
+}
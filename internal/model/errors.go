@@ -1,25 +1,72 @@
 package model
 
+import "strconv"
+
+// Define severity levels for row errors
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
 // Define struct to hold error information
 type RowError struct {
-	Row int
-	Field string
-	Message string
+	Row      int    `json:"row"`
+	Line     int    `json:"line,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Message  string `json:"message"`
+	Severity string `json:"severity,omitempty"` // Empty means error
+	Refs     []int  `json:"refs,omitempty"`     // Other rows involved in a conflict
+}
+
+// Define function to check if the error blocks a run
+func (e RowError) IsBlocking() bool {
+	return e.Severity == "" || e.Severity == SeverityError
 }
 
 // Define function to create a new RowError
 func (e RowError) Error() string {
+	loc := "row " + itoa(e.Row)
+	if e.Line > 0 {
+		loc += " (line " + itoa(e.Line) + ")"
+	}
 	if e.Field != "" {
-		return "row " + itoa(e.Row) + ": " + e.Message
+		loc += " [" + e.Field + "]"
+	}
+	if !e.IsBlocking() {
+		loc = e.Severity + ": " + loc
 	}
-	return "row " + itoa(e.Row) + " [" + e.Field + "]: " + e.Message
+	msg := loc + ": " + e.Message
+	if e.Value != "" {
+		msg += " (got " + strconv.Quote(e.Value) + ")"
+	}
+	if len(e.Refs) > 0 {
+		msg += " (see row"
+		if len(e.Refs) > 1 {
+			msg += "s"
+		}
+		for i, r := range e.Refs {
+			if i > 0 {
+				msg += ","
+			}
+			msg += " " + itoa(r)
+		}
+		msg += ")"
+	}
+	return msg
 }
 
 // Define helper function to convert int to string
 func itoa(n int) string {
-	if n == 0 {return "0"}
+	if n == 0 {
+		return "0"
+	}
 	sign := ""
-	if n < 0 { sign = "-"; n = -n}
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
 	buf := make([]byte, 0, 16)
 	for n > 0 {
 		d := byte(n % 10)
@@ -27,4 +74,4 @@ func itoa(n int) string {
 		n /= 10
 	}
 	return sign + string(buf)
-}
\ No newline at end of file
+}
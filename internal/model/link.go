@@ -1,53 +1,223 @@
 package model
 
-// Define link input contract data
+// Define link input contract data. JSON names match the CSV columns.
 type LinkInput struct {
 	// Identifiers
-	LinkID string
-	Scenario string
+	LinkID   string `json:"link_id"`
+	Scenario string `json:"scenario"`
+
+	// Source data row and line in the input file (0 when not read from a file)
+	Row  int `json:"-"`
+	Line int `json:"-"`
 
 	// Transmitter and receiver parameters
-	TXPowerDbm float64
-	RXSensitivityDbm float64
-	SystemMarginDb float64
+	TXPowerDbm       float64 `json:"tx_power_dbm"`
+	RXSensitivityDbm float64 `json:"rx_sensitivity_dbm"`
+	SystemMarginDb   float64 `json:"system_margin_db"`
 
 	// Fiber and component parameters
-	FiberLengthKm float64
-	FiberAttDbPerKm float64
+	FiberLengthKm   float64 `json:"fiber_length_km"`
+	FiberAttDbPerKm float64 `json:"fiber_att_db_per_km"`
 
 	// Component losses and counts
-	NSplice int
-	SpliceLossDb float64
-	NConnectors int
-	ConnectorLossDb float64
-	SplitterLossDb float64
-	OtherLossDb float64
+	NSplice         int     `json:"n_splice"`
+	SpliceLossDb    float64 `json:"splice_loss_db"`
+	NConnectors     int     `json:"n_connector"`
+	ConnectorLossDb float64 `json:"connector_loss_db"`
+	SplitterLossDb  float64 `json:"splitter_loss_db"`
+	OtherLossDb     float64 `json:"other_loss_db"`
+
+	// Optional fiber description
+	WavelengthNm float64 `json:"wavelength_nm,omitempty"`
+	FiberType    string  `json:"fiber_type,omitempty"`
+
+	// Optional splitter assignment (1:SplitRatio splitter, port 1..SplitRatio)
+	SplitterID   string `json:"splitter_id,omitempty"`
+	SplitterPort int    `json:"splitter_port,omitempty"`
+	SplitRatio   int    `json:"split_ratio,omitempty"`
+
+	// Optional amplifier chain and the OSNR the receiver needs
+	Amplifiers     []Amplifier `json:"amplifiers,omitempty"`
+	RequiredOSNRDb float64     `json:"required_osnr_db,omitempty"`
+
+	// Optional receiver BER model: rx_sensitivity_dbm holds at SensitivityBER,
+	// Q scales with received power at QSlope (1 thermal, 0.5 shot noise)
+	SensitivityBER float64 `json:"sensitivity_ber,omitempty"`
+	QSlope         float64 `json:"q_slope,omitempty"`
+	FEC            string  `json:"fec,omitempty"`
+	TargetBER      float64 `json:"target_ber,omitempty"`
+
+	// Optional power penalty parameters; a *_penalty_db value greater than zero
+	// is used as given instead of being computed
+	DispersionPsNmKm    float64 `json:"dispersion_ps_nm_km,omitempty"` // Empty uses the fiber type at wavelength_nm
+	SpectralWidthNm     float64 `json:"spectral_width_nm,omitempty"`
+	ExtinctionRatioDb   float64 `json:"extinction_ratio_db,omitempty"`
+	RINDbHz             float64 `json:"rin_db_hz,omitempty"`
+	MPNFactor           float64 `json:"mpn_k,omitempty"`
+	DispersionPenaltyDb float64 `json:"dispersion_penalty_db,omitempty"`
+	ExtinctionPenaltyDb float64 `json:"er_penalty_db,omitempty"`
+	RINPenaltyDb        float64 `json:"rin_penalty_db,omitempty"`
+	MPNPenaltyDb        float64 `json:"mpn_penalty_db,omitempty"`
+	ReflectionPenaltyDb float64 `json:"reflection_penalty_db,omitempty"`
+	ChirpPenaltyDb      float64 `json:"chirp_penalty_db,omitempty"`
+
+	// Optional PMD coefficient in ps/√km; empty uses the declared fiber type
+	PMDPsSqrtKm float64 `json:"pmd_ps_sqrt_km,omitempty"`
+
+	// Optional line rate of the link for the rise time, penalty and PMD checks;
+	// empty uses the lane rate of a declared phy, else the run-wide bitrate
+	BitrateGbps float64 `json:"bitrate_gbps,omitempty"`
+
+	// Optional WDM channel plan and per-port insertion losses of the mux,
+	// demux and each OADM passed through
+	Channels    []Channel `json:"channels,omitempty"`
+	MuxLossDb   float64   `json:"mux_loss_db,omitempty"`
+	DemuxLossDb float64   `json:"demux_loss_db,omitempty"`
+	NOADM       int       `json:"n_oadm,omitempty"`
+	OADMLossDb  float64   `json:"oadm_loss_db,omitempty"`
+
+	// Optional PON services sharing the ODN through coexistence elements;
+	// tx_power_dbm and rx_sensitivity_dbm then come from each service
+	Services []Service `json:"services,omitempty"`
+
+	// Optional reflectance budget: connector polish (pc, upc, apc) or explicit
+	// reflectances in dB (negative), and the ORL the transmitter tolerates
+	ConnectorType          string  `json:"connector_type,omitempty"`
+	ConnectorReflectanceDb float64 `json:"connector_reflectance_db,omitempty"`
+	SpliceReflectanceDb    float64 `json:"splice_reflectance_db,omitempty"` // Empty uses -70 dB (fusion)
+	ORLToleranceDb         float64 `json:"orl_tolerance_db,omitempty"`
+
+	// Optional margin allowances: a cable type (duct, direct-buried, aerial,
+	// indoor or one from the run configuration) and per-link overrides of its values
+	CableType          string  `json:"cable_type,omitempty"`
+	RepairSplicesPerKm float64 `json:"repair_splices_per_km,omitempty"`
+	TempDriftDbPerKm   float64 `json:"temp_drift_db_per_km,omitempty"`
+	AgingMarginDb      float64 `json:"aging_margin_db,omitempty"`
+	CableCutMarginDb   float64 `json:"cable_cut_margin_db,omitempty"`
+
+	// Optional nonlinear threshold check (SBS, SPM), evaluated when the source
+	// linewidth is known; the effective area defaults to the fiber type's
+	SourceLinewidthMHz float64 `json:"source_linewidth_mhz,omitempty"`
+	EffectiveAreaUm2   float64 `json:"effective_area_um2,omitempty"`
+
+	// Optional multimode Ethernet PHY (e.g. 10gbase-sr) checked against its
+	// IEEE 802.3 reach and channel loss over the declared fiber type
+	PHY string `json:"phy,omitempty"`
+}
+
+// Define function to resolve the row number reported for a link: its data row
+// in the input file, else its 1-based position in the list it came with
+func (l LinkInput) RowNumber(index int) int {
+	if l.Row > 0 {
+		return l.Row
+	}
+	return index + 1
 }
 
 // Define link output contract data
 type LinkOutput struct {
 	// Identifiers
-	LinkID string
-	Scenario string
+	LinkID   string `json:"link_id"`
+	Scenario string `json:"scenario"`
 
 	// Computed loss
-	FiberLossDb float64
-	SpliceTotalDb float64
-	ConnectorTotalDb float64
-	TotalLossDb float64
+	FiberLossDb      float64 `json:"fiber_loss_db"`
+	SpliceTotalDb    float64 `json:"splice_total_db"`
+	ConnectorTotalDb float64 `json:"connector_total_db"`
+	WDMLossDb        float64 `json:"wdm_loss_db,omitempty"` // Mux, demux and OADM ports
+	TotalLossDb      float64 `json:"total_loss_db"`
+
+	// Power penalties, subtracted from the margin alongside the system margin
+	DispersionPenaltyDb float64 `json:"dispersion_penalty_db"`
+	ExtinctionPenaltyDb float64 `json:"er_penalty_db"`
+	RINPenaltyDb        float64 `json:"rin_penalty_db"`
+	MPNPenaltyDb        float64 `json:"mpn_penalty_db"`
+	ReflectionPenaltyDb float64 `json:"reflection_penalty_db"`
+	ChirpPenaltyDb      float64 `json:"chirp_penalty_db"`
+	PenaltyTotalDb      float64 `json:"penalty_total_db"`
+	// Penalty terms with no finite value (noise above the decision threshold);
+	// they are reported as 0, left out of the margin and set LPBStatus UNBOUNDED
+	UnboundedPenalties []string `json:"unbounded_penalties,omitempty"`
 
 	// Link power budget
-	RxPowerDbm float64
-	MarginDb float64
-	LPBStatus string
+	RxPowerDbm float64 `json:"rx_power_dbm"`
+	MarginDb   float64 `json:"margin_db"`
+	LPBStatus  string  `json:"lpb_status"`
 
 	// Rise time budget
-	SystemRiseTimeNs float64
-	AllowedRiseTimeNs float64
-	RTBStatus bool
+	SystemRiseTimeNs  float64 `json:"system_rise_time_ns"`
+	AllowedRiseTimeNs float64 `json:"allowed_rise_time_ns"`
+	RTBStatus         bool    `json:"rtb_pass"`
+
+	// Polarization mode dispersion (empty status without a PMD coefficient or fiber type)
+	DGDPs     float64 `json:"dgd_ps,omitempty"` // Mean DGD = PMD · √L
+	MaxDGDPs  float64 `json:"max_dgd_ps,omitempty"`
+	PMDStatus string  `json:"pmd_status,omitempty"`
 
 	// Explainability
-	TopContributor1 string
-	TopContributor2 string
-	TopContributor3 string
-}
\ No newline at end of file
+	TopContributor1 string `json:"top_contributor_1"`
+	TopContributor2 string `json:"top_contributor_2"`
+	TopContributor3 string `json:"top_contributor_3"`
+
+	// Amplified links (empty status when the link has no amplifiers)
+	AmplifierGainDb float64 `json:"amplifier_gain_db,omitempty"` // Net gain of the chain
+	OSNRDb          float64 `json:"osnr_db,omitempty"`
+	OSNRStatus      string  `json:"osnr_status,omitempty"` // PASS, FAIL, or N/A without a required OSNR
+
+	// Multimode Ethernet PHY support (empty status without a phy)
+	ModalRiseTimeNs     float64 `json:"modal_rise_time_ns,omitempty"`
+	PHYReachM           float64 `json:"phy_reach_m,omitempty"`
+	PHYMaxChannelLossDb float64 `json:"phy_max_channel_loss_db,omitempty"`
+	PHYLimit            string  `json:"phy_limit,omitempty"` // fiber, reach, channel_loss
+	PHYStatus           string  `json:"phy_status,omitempty"`
+
+	// Nonlinear thresholds (empty status without a source linewidth)
+	EffectiveLengthKm float64 `json:"effective_length_km,omitempty"`
+	SBSThresholdDbm   float64 `json:"sbs_threshold_dbm,omitempty"`
+	SPMThresholdDbm   float64 `json:"spm_threshold_dbm,omitempty"`
+	NonlinearPhaseRad float64 `json:"nonlinear_phase_rad,omitempty"`
+	SBSPenaltyDb      float64 `json:"sbs_penalty_db,omitempty"`
+	SPMPenaltyDb      float64 `json:"spm_penalty_db,omitempty"`
+	NonlinearStatus   string  `json:"nonlinear_status,omitempty"`
+
+	// Margin allowances, reserved next to the system margin
+	RepairAllowanceDb      float64 `json:"repair_allowance_db,omitempty"`
+	TemperatureAllowanceDb float64 `json:"temperature_allowance_db,omitempty"`
+	AgingAllowanceDb       float64 `json:"aging_allowance_db,omitempty"`
+	CableCutAllowanceDb    float64 `json:"cable_cut_allowance_db,omitempty"`
+	AllowanceTotalDb       float64 `json:"allowance_total_db,omitempty"`
+
+	// Optical return loss (empty status without reflectance inputs)
+	ORLDb                float64 `json:"orl_db,omitempty"`
+	ReflectionStatus     string  `json:"reflection_status,omitempty"` // PASS, FAIL, or N/A without a tolerance
+	WorstReflectionEvent string  `json:"worst_reflection_event,omitempty"`
+	WorstReflectanceDb   float64 `json:"worst_reflectance_db,omitempty"`
+
+	// Bit error rate (empty status when the link has no BER model)
+	QFactor       float64 `json:"q_factor,omitempty"`
+	BER           float64 `json:"ber,omitempty"`
+	BERStatus     string  `json:"ber_status,omitempty"` // Pre-FEC BER against the FEC threshold, or the target without FEC
+	PostFECBER    float64 `json:"post_fec_ber,omitempty"`
+	PostFECStatus string  `json:"post_fec_status,omitempty"`
+
+	// WDM links report their worst channel above and every channel here
+	ChannelCount   int             `json:"channel_count,omitempty"`
+	WorstChannel   string          `json:"worst_channel,omitempty"`
+	WorstChannelNm float64         `json:"worst_channel_nm,omitempty"`
+	Channels       []ChannelOutput `json:"channels,omitempty"`
+
+	// Coexistence links report their limiting service above and every service here
+	LimitingService string          `json:"limiting_service,omitempty"` // e.g. "xgs-pon/up"
+	Services        []ServiceOutput `json:"services,omitempty"`
+
+	// Standards compliance (empty when no profile is selected)
+	ComplianceProfile string `json:"compliance_profile,omitempty"`
+	ComplianceStatus  string `json:"compliance_status,omitempty"`
+	ComplianceClause  string `json:"compliance_clause,omitempty"`
+}
+
+// Define function to report whether a result has no meaningful margin because
+// a penalty term is unbounded
+func (o LinkOutput) Unbounded() bool {
+	return len(o.UnboundedPenalties) > 0
+}
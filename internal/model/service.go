@@ -0,0 +1,22 @@
+package model
+
+// Define struct for a PON service carried on a link's ODN. Params override
+// the catalog values (cex, wdm1r, down_tx, down_rx, up_tx, ...).
+type Service struct {
+	Name   string             `json:"name"` // e.g. "gpon", "xgs-pon", "rf-video"
+	Params map[string]float64 `json:"params,omitempty"`
+}
+
+// Define struct for the evaluation of one direction of a service
+type ServiceOutput struct {
+	Service          string  `json:"service"`
+	Direction        string  `json:"direction"` // "down" or "up"
+	WavelengthNm     float64 `json:"wavelength_nm"`
+	FilterLossDb     float64 `json:"filter_loss_db"` // CEx plus WDM1r
+	TxPowerDbm       float64 `json:"tx_power_dbm"`
+	RxSensitivityDbm float64 `json:"rx_sensitivity_dbm"`
+	RxPowerDbm       float64 `json:"rx_power_dbm"`
+	MarginDb         float64 `json:"margin_db"`
+	OverloadMarginDb float64 `json:"overload_margin_db"` // Headroom below receiver overload
+	Status           string  `json:"status"`
+}
@@ -0,0 +1,95 @@
+package phy
+
+import (
+	"sort"
+	"strings"
+)
+
+// Define struct for the supported reach of a PHY over one fiber type
+type Reach struct {
+	MaxLengthM    float64
+	ChannelLossDb float64 // Maximum channel insertion loss, connections included
+}
+
+// Define struct for a multimode Ethernet PHY
+type PHY struct {
+	Name         string
+	Standard     string
+	WavelengthNm float64
+	LaneRateGBd  float64 // Signaling rate per lane
+	Lanes        int
+	Reach        map[string]Reach // Keyed by fiber type name (OM1–OM5)
+}
+
+// Define the built-in PHYs with their IEEE 802.3 operating ranges. OM5 is
+// specified like OM4 at 850 nm.
+var phys = map[string]PHY{
+	"1000base-sx": {Name: "1000BASE-SX", Standard: "IEEE 802.3 Clause 38", WavelengthNm: 850, LaneRateGBd: 1.25, Lanes: 1, Reach: map[string]Reach{
+		"OM1": {275, 2.60},
+		"OM2": {550, 3.56},
+		"OM3": {550, 3.56},
+		"OM4": {550, 3.56},
+		"OM5": {550, 3.56},
+	}},
+	"10gbase-sr": {Name: "10GBASE-SR", Standard: "IEEE 802.3 Clause 52", WavelengthNm: 850, LaneRateGBd: 10.3125, Lanes: 1, Reach: map[string]Reach{
+		"OM1": {33, 1.6},
+		"OM2": {82, 1.8},
+		"OM3": {300, 2.6},
+		"OM4": {400, 2.9},
+		"OM5": {400, 2.9},
+	}},
+	"25gbase-sr": {Name: "25GBASE-SR", Standard: "IEEE 802.3 Clause 112", WavelengthNm: 850, LaneRateGBd: 25.78125, Lanes: 1, Reach: map[string]Reach{
+		"OM3": {70, 1.8},
+		"OM4": {100, 1.9},
+		"OM5": {100, 1.9},
+	}},
+	"40gbase-sr4": {Name: "40GBASE-SR4", Standard: "IEEE 802.3 Clause 86", WavelengthNm: 850, LaneRateGBd: 10.3125, Lanes: 4, Reach: map[string]Reach{
+		"OM3": {100, 1.9},
+		"OM4": {150, 1.5},
+		"OM5": {150, 1.5},
+	}},
+	"100gbase-sr4": {Name: "100GBASE-SR4", Standard: "IEEE 802.3 Clause 95", WavelengthNm: 850, LaneRateGBd: 25.78125, Lanes: 4, Reach: map[string]Reach{
+		"OM3": {70, 1.8},
+		"OM4": {100, 1.9},
+		"OM5": {100, 1.9},
+	}},
+}
+
+// Define aliases for common spellings
+var aliases = map[string]string{
+	"1g-sx":    "1000base-sx",
+	"10g-sr":   "10gbase-sr",
+	"25g-sr":   "25gbase-sr",
+	"40g-sr4":  "40gbase-sr4",
+	"100g-sr4": "100gbase-sr4",
+}
+
+// Define function to look up a PHY by name (case-insensitive)
+func Lookup(name string) (PHY, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if a, ok := aliases[key]; ok {
+		key = a
+	}
+	p, ok := phys[key]
+	return p, ok
+}
+
+// Define function to list available PHY names
+func Names() []string {
+	names := make([]string, 0, len(phys))
+	for n := range phys {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Define function to list the fiber types a PHY is specified over
+func (p PHY) FiberTypes() []string {
+	names := make([]string, 0, len(p.Reach))
+	for n := range p.Reach {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,53 @@
+package phy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupReach(t *testing.T) {
+	// IEEE 802.3 operating ranges and channel insertion losses
+	tests := []struct {
+		name     string
+		fiber    string
+		lengthM  float64
+		lossDb   float64
+		standard string
+	}{
+		{"1000base-sx", "OM1", 275, 2.60, "IEEE 802.3 Clause 38"},
+		{"1000BASE-SX", "OM2", 550, 3.56, "IEEE 802.3 Clause 38"},
+		{"10gbase-sr", "OM1", 33, 1.6, "IEEE 802.3 Clause 52"},
+		{"10g-sr", "OM2", 82, 1.8, "IEEE 802.3 Clause 52"},
+		{"10gbase-sr", "OM3", 300, 2.6, "IEEE 802.3 Clause 52"},
+		{"10gbase-sr", "OM4", 400, 2.9, "IEEE 802.3 Clause 52"},
+		{"25gbase-sr", "OM3", 70, 1.8, "IEEE 802.3 Clause 112"},
+		{"25g-sr", "OM4", 100, 1.9, "IEEE 802.3 Clause 112"},
+		{"40gbase-sr4", "OM3", 100, 1.9, "IEEE 802.3 Clause 86"},
+		{" 40G-SR4 ", "OM4", 150, 1.5, "IEEE 802.3 Clause 86"},
+		{"100gbase-sr4", "OM3", 70, 1.8, "IEEE 802.3 Clause 95"},
+		{"100g-sr4", "OM5", 100, 1.9, "IEEE 802.3 Clause 95"},
+	}
+	for _, tt := range tests {
+		p, ok := Lookup(tt.name)
+		if !ok {
+			t.Errorf("%s not found", tt.name)
+			continue
+		}
+		if r := p.Reach[tt.fiber]; r.MaxLengthM != tt.lengthM || r.ChannelLossDb != tt.lossDb || p.Standard != tt.standard {
+			t.Errorf("%s over %s: %g m, %g dB (%s); want %g m, %g dB (%s)", tt.name, tt.fiber, r.MaxLengthM, r.ChannelLossDb, p.Standard, tt.lengthM, tt.lossDb, tt.standard)
+		}
+	}
+	if _, ok := Lookup("10gbase-lr"); ok {
+		t.Error("single-mode PHY found")
+	}
+}
+
+func TestFiberTypes(t *testing.T) {
+	p, _ := Lookup("25gbase-sr")
+	if got := p.FiberTypes(); !reflect.DeepEqual(got, []string{"OM3", "OM4", "OM5"}) {
+		t.Errorf("25GBASE-SR fiber types %v", got)
+	}
+	if got := Names(); len(got) != 5 || got[0] != "1000base-sx" {
+		t.Errorf("names %v", got)
+	}
+}
@@ -0,0 +1,166 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"sort"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define number of histogram bins, as in the Python analysis
+const histogramBins = 30
+
+// Define function to draw the margin histogram with a dashed line at 0 dB
+func marginHistogram(results []model.LinkOutput) template.HTML {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, r := range results {
+		lo, hi = math.Min(lo, r.MarginDb), math.Max(hi, r.MarginDb)
+	}
+	if hi <= lo {
+		lo, hi = lo-0.5, lo+0.5
+	}
+	width := (hi - lo) / histogramBins
+	counts := make([]int, histogramBins)
+	peak := 0
+	for _, r := range results {
+		i := min(int((r.MarginDb-lo)/width), histogramBins-1)
+		counts[i]++
+		peak = max(peak, counts[i])
+	}
+
+	c := newCanvas("Link Power Budget Margin Distribution", math.Min(lo, 0), math.Max(hi, 0), 0, float64(peak))
+	c.axes("Margin (dB)", "Number of Links", true, true)
+	for i, n := range counts {
+		x0, x1 := lo+float64(i)*width, lo+float64(i+1)*width
+		fmt.Fprintf(&c.b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" fill-opacity="0.7"><title>%s to %s dB: %d</title></rect>`,
+			c.x(x0), c.y(float64(n)), math.Max(c.x(x1)-c.x(x0)-1, 0.5), c.y(0)-c.y(float64(n)), colorBar, label(round(x0)), label(round(x1)), n)
+	}
+	fmt.Fprintf(&c.b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-dasharray="4 3"/>`, c.x(0), c.top, c.x(0), c.y(0), colorZero)
+	return c.html()
+}
+
+// Define function to draw PASS/FAIL counts, PASS and FAIL first then any other status
+func statusBars(results []model.LinkOutput) template.HTML {
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.LPBStatus]++
+	}
+	order := []string{}
+	for _, s := range []string{"PASS", "FAIL"} {
+		if counts[s] > 0 {
+			order = append(order, s)
+		}
+	}
+	var other []string
+	for s := range counts {
+		if s != "PASS" && s != "FAIL" {
+			other = append(other, s)
+		}
+	}
+	sort.Strings(other)
+	order = append(order, other...)
+	return barChart("Link Power Budget Pass or Fail Summary", "Status", order, counts, func(s string) string {
+		if s == "PASS" {
+			return colorPass
+		}
+		return colorFail
+	})
+}
+
+// Define function to draw vertical bars for labelled counts
+func barChart(title, xLabel string, labels []string, counts map[string]int, color func(string) string) template.HTML {
+	peak := 0
+	for _, l := range labels {
+		peak = max(peak, counts[l])
+	}
+	c := newCanvas(title, 0, float64(max(len(labels), 1)), 0, float64(peak))
+	c.axes(xLabel, "Number of Links", false, true)
+	for i, l := range labels {
+		n := float64(counts[l])
+		fmt.Fprintf(&c.b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %d</title></rect>`,
+			c.x(float64(i)+0.15), c.y(n), c.x(0.7)-c.x(0), c.y(0)-c.y(n), color(l), html.EscapeString(l), counts[l])
+		fmt.Fprintf(&c.b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`, c.x(float64(i)+0.5), c.height-c.bottom+16, html.EscapeString(l))
+	}
+	return c.html()
+}
+
+// Define function to draw margin against fiber length, colored by status
+func marginVsLength(results []model.LinkOutput, lengths map[string]float64) template.HTML {
+	type point struct {
+		x, y float64
+		pass bool
+		id   string
+	}
+	var points []point
+	xMax, yMin, yMax := 0.0, 0.0, 0.0
+	for _, r := range results {
+		l, ok := lengths[r.LinkID]
+		if !ok {
+			continue
+		}
+		points = append(points, point{l, r.MarginDb, r.LPBStatus == "PASS", r.LinkID})
+		xMax, yMin, yMax = math.Max(xMax, l), math.Min(yMin, r.MarginDb), math.Max(yMax, r.MarginDb)
+	}
+	if len(points) == 0 {
+		return ""
+	}
+	c := newCanvas("Margin vs Fiber Length", 0, xMax, yMin, yMax)
+	c.axes("Fiber Length (km)", "Margin (dB)", true, true)
+	fmt.Fprintf(&c.b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-dasharray="4 3"/>`, c.left, c.y(0), c.width-c.right, c.y(0), colorZero)
+	for _, p := range points {
+		color := colorFail
+		if p.pass {
+			color = colorPass
+		}
+		fmt.Fprintf(&c.b, `<circle cx="%.1f" cy="%.1f" r="2.5" fill="%s" fill-opacity="0.7"><title>%s: %s km, %s dB</title></circle>`,
+			c.x(p.x), c.y(p.y), color, html.EscapeString(p.id), label(round(p.x)), label(round(p.y)))
+	}
+	fmt.Fprintf(&c.b, `<circle cx="%.1f" cy="%.1f" r="4" fill="%s"/><text x="%.1f" y="%.1f">PASS</text>`, c.width-c.right-90, c.top+8, colorPass, c.width-c.right-82, c.top+12)
+	fmt.Fprintf(&c.b, `<circle cx="%.1f" cy="%.1f" r="4" fill="%s"/><text x="%.1f" y="%.1f">FAIL</text>`, c.width-c.right-44, c.top+8, colorFail, c.width-c.right-36, c.top+12)
+	return c.html()
+}
+
+// Define function to draw the ten most common first contributors as horizontal bars
+func topContributors(results []model.LinkOutput) template.HTML {
+	counts := make(map[string]int)
+	for _, r := range results {
+		if r.TopContributor1 != "" {
+			counts[r.TopContributor1]++
+		}
+	}
+	if len(counts) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(counts))
+	for n := range counts {
+		names = append(names, n)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > 10 {
+		names = names[:10]
+	}
+
+	c := newCanvas("Top Contributors", 0, float64(counts[names[0]]), 0, float64(len(names)))
+	c.left = 150
+	c.axes("Number of Links", "", true, false)
+	for i, n := range names {
+		row := float64(len(names) - i)
+		fmt.Fprintf(&c.b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %d</title></rect>`,
+			c.x(0), c.y(row-0.15), c.x(float64(counts[n]))-c.x(0), c.y(row-0.85)-c.y(row-0.15), colorBar, html.EscapeString(n), counts[n])
+		fmt.Fprintf(&c.b, `<text x="%.1f" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`, c.left-6, c.y(row-0.5), html.EscapeString(n))
+	}
+	return c.html()
+}
+
+// Define helper to round values shown in tooltips
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
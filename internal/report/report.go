@@ -0,0 +1,91 @@
+package report
+
+import (
+	"errors"
+	"html/template"
+	"io"
+	"os"
+	"time"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/analysis"
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define struct for report options
+type Options struct {
+	Title   string
+	Source  string             // Results file name shown in the header
+	Lengths map[string]float64 // Fiber length by link_id, enables the scatter chart
+}
+
+// Define struct for the values rendered by the template
+type page struct {
+	Title       string
+	Source      string
+	Generated   string
+	Summary     analysis.Summary
+	Results     []model.LinkOutput
+	Failing     []model.LinkOutput
+	Lengths     map[string]float64
+	Histogram   template.HTML
+	Status      template.HTML
+	Scatter     template.HTML
+	Contributor template.HTML
+}
+
+// Define function to write the report into an HTML file
+func WriteFile(path string, results []model.LinkOutput, opt Options) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.New("Failed to create report: " + err.Error())
+	}
+	defer file.Close()
+	return Write(file, results, opt)
+}
+
+// Define function to render a self-contained HTML report: charts are inline
+// SVG and the table sorting script is embedded, so no network access is needed
+func Write(w io.Writer, results []model.LinkOutput, opt Options) error {
+	if len(results) == 0 {
+		return errors.New("No results to report")
+	}
+	if opt.Title == "" {
+		opt.Title = "Fiber Optic Link Performance Report"
+	}
+	p := page{
+		Title:       opt.Title,
+		Source:      opt.Source,
+		Generated:   time.Now().Format(time.RFC3339),
+		Summary:     analysis.Summarize(results, 0),
+		Results:     results,
+		Lengths:     opt.Lengths,
+		Histogram:   marginHistogram(results),
+		Status:      statusBars(results),
+		Scatter:     marginVsLength(results, opt.Lengths),
+		Contributor: topContributors(results),
+	}
+	for _, r := range results {
+		if r.LPBStatus != "PASS" {
+			p.Failing = append(p.Failing, r)
+		}
+	}
+	if err := tmpl.Execute(w, p); err != nil {
+		return errors.New("An error has occurred while writing report: " + err.Error())
+	}
+	return nil
+}
+
+// Define template helpers
+var funcs = template.FuncMap{
+	"num":     foio.NumberFormatter(foio.CSVWriteOptions{Precision: 3}),
+	"percent": func(x float64) string { return foio.NumberFormatter(foio.CSVWriteOptions{Precision: 1})(x*100) + "%" },
+	"length": func(lengths map[string]float64, id string) string {
+		if l, ok := lengths[id]; ok {
+			return foio.NumberFormatter(foio.CSVWriteOptions{Precision: 2})(l)
+		}
+		return ""
+	},
+}
+
+var tmpl = template.Must(template.New("report").Funcs(funcs).Parse(reportTemplate))
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Define chart colors
+const (
+	colorPass = "#2e7d32"
+	colorFail = "#c62828"
+	colorBar  = "#1565c0"
+	colorZero = "#c62828"
+	colorGrid = "#e0e0e0"
+)
+
+// Define struct for a chart canvas with linear x and y axes
+type canvas struct {
+	b                        strings.Builder
+	width, height            float64
+	left, right, top, bottom float64
+	xMin, xMax, yMin, yMax   float64
+}
+
+// Define function to start a chart with padded plot area
+func newCanvas(title string, xMin, xMax, yMin, yMax float64) *canvas {
+	c := &canvas{width: 640, height: 360, left: 64, right: 16, top: 36, bottom: 48}
+	if xMax <= xMin {
+		xMin, xMax = xMin-1, xMin+1
+	}
+	if yMax <= yMin {
+		yMin, yMax = yMin-1, yMin+1
+	}
+	c.xMin, c.xMax, c.yMin, c.yMax = xMin, xMax, yMin, yMax
+	fmt.Fprintf(&c.b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %g %g" role="img" aria-label="%s">`,
+		c.width, c.height, html.EscapeString(title))
+	fmt.Fprintf(&c.b, `<text x="%g" y="20" text-anchor="middle" font-weight="bold">%s</text>`, c.width/2, html.EscapeString(title))
+	return c
+}
+
+// Define functions to map data coordinates to pixels
+func (c *canvas) x(v float64) float64 {
+	return c.left + (v-c.xMin)/(c.xMax-c.xMin)*(c.width-c.left-c.right)
+}
+
+func (c *canvas) y(v float64) float64 {
+	return c.height - c.bottom - (v-c.yMin)/(c.yMax-c.yMin)*(c.height-c.top-c.bottom)
+}
+
+// Define function to draw both axes with grid lines and labels
+func (c *canvas) axes(xLabel, yLabel string, xTicks, yTicks bool) {
+	for _, t := range ticks(c.yMin, c.yMax) {
+		if !yTicks {
+			break
+		}
+		fmt.Fprintf(&c.b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`, c.left, c.y(t), c.width-c.right, c.y(t), colorGrid)
+		fmt.Fprintf(&c.b, `<text x="%.1f" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`, c.left-6, c.y(t), label(t))
+	}
+	if xTicks {
+		for _, t := range ticks(c.xMin, c.xMax) {
+			fmt.Fprintf(&c.b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`, c.x(t), c.height-c.bottom+16, label(t))
+		}
+	}
+	fmt.Fprintf(&c.b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black"/>`, c.left, c.height-c.bottom, c.width-c.right, c.height-c.bottom)
+	fmt.Fprintf(&c.b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black"/>`, c.left, c.top, c.left, c.height-c.bottom)
+	fmt.Fprintf(&c.b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`, (c.left+c.width-c.right)/2, c.height-8, html.EscapeString(xLabel))
+	fmt.Fprintf(&c.b, `<text transform="translate(14 %.1f) rotate(-90)" text-anchor="middle">%s</text>`, (c.top+c.height-c.bottom)/2, html.EscapeString(yLabel))
+}
+
+// Define function to close the chart and return it as trusted HTML
+func (c *canvas) html() template.HTML {
+	c.b.WriteString(`</svg>`)
+	return template.HTML(c.b.String())
+}
+
+// Define function to pick about five round tick values within a range
+func ticks(lo, hi float64) []float64 {
+	span := hi - lo
+	step := math.Pow(10, math.Floor(math.Log10(span/5)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if span/(step*m) <= 6 {
+			step *= m
+			break
+		}
+	}
+	var out []float64
+	for t := math.Ceil(lo/step) * step; t <= hi+step*1e-9; t += step {
+		out = append(out, math.Round(t/step)*step)
+	}
+	return out
+}
+
+// Define helper to format tick labels
+func label(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
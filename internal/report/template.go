@@ -0,0 +1,99 @@
+package report
+
+// Define the report page. Tables with class "sortable" sort on header click;
+// cells carry data-v with the raw value so numbers sort numerically.
+const reportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 1100px; color: #212121; padding: 0 1rem; }
+h1 { margin-bottom: 0.2rem; }
+.meta { color: #616161; margin-top: 0; }
+.cards { display: flex; gap: 1rem; flex-wrap: wrap; }
+.card { border: 1px solid #e0e0e0; border-radius: 6px; padding: 0.8rem 1.2rem; min-width: 9rem; }
+.card b { display: block; font-size: 1.6rem; }
+.charts { display: grid; grid-template-columns: repeat(auto-fit, minmax(460px, 1fr)); gap: 1rem; }
+svg { width: 100%; height: auto; font-size: 11px; border: 1px solid #eeeeee; border-radius: 6px; }
+table { border-collapse: collapse; width: 100%; margin: 0.5rem 0 1.5rem; font-size: 0.85rem; }
+th, td { border-bottom: 1px solid #eeeeee; padding: 0.3rem 0.5rem; text-align: left; }
+td.n { text-align: right; font-variant-numeric: tabular-nums; }
+table.sortable th { cursor: pointer; user-select: none; background: #fafafa; }
+table.sortable th[aria-sort=ascending]::after { content: " \25B2"; }
+table.sortable th[aria-sort=descending]::after { content: " \25BC"; }
+.PASS { color: #2e7d32; font-weight: 600; }
+.FAIL { color: #c62828; font-weight: 600; }
+.scroll { max-height: 32rem; overflow: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">{{if .Source}}Source: {{.Source}} · {{end}}Generated {{.Generated}}</p>
+
+<div class="cards">
+<div class="card">Links<b>{{len .Results}}</b></div>
+<div class="card">Failing<b>{{len .Failing}}</b></div>
+<div class="card">Scenarios<b>{{len .Summary.Scenarios}}</b></div>
+</div>
+
+<h2>Summary by scenario</h2>
+<table class="sortable">
+<thead><tr><th>Scenario</th><th>Links</th><th>Pass rate</th><th>Fail rate</th><th>Mean margin (dB)</th><th>Median</th><th>P5</th><th>P95</th><th>Min</th><th>Max</th><th>Top contributor</th></tr></thead>
+<tbody>
+{{range .Summary.Scenarios}}<tr><td>{{.Scenario}}</td><td class="n" data-v="{{.NLinks}}">{{.NLinks}}</td><td class="n" data-v="{{.PassRate}}">{{percent .PassRate}}</td><td class="n" data-v="{{.FailRate}}">{{percent .FailRate}}</td><td class="n" data-v="{{with .MarginMeanDb}}{{.}}{{end}}">{{with .MarginMeanDb}}{{num .}}{{end}}</td><td class="n" data-v="{{with .MarginMedianDb}}{{.}}{{end}}">{{with .MarginMedianDb}}{{num .}}{{end}}</td><td class="n" data-v="{{with .MarginP05Db}}{{.}}{{end}}">{{with .MarginP05Db}}{{num .}}{{end}}</td><td class="n" data-v="{{with .MarginP95Db}}{{.}}{{end}}">{{with .MarginP95Db}}{{num .}}{{end}}</td><td class="n" data-v="{{with .MarginMinDb}}{{.}}{{end}}">{{with .MarginMinDb}}{{num .}}{{end}}</td><td class="n" data-v="{{with .MarginMaxDb}}{{.}}{{end}}">{{with .MarginMaxDb}}{{num .}}{{end}}</td><td>{{.TopContributor}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<h2>Charts</h2>
+<div class="charts">
+{{.Histogram}}
+{{.Status}}
+{{if .Scatter}}{{.Scatter}}{{end}}
+{{if .Contributor}}{{.Contributor}}{{end}}
+</div>
+{{if not .Scatter}}<p class="meta">Margin vs fiber length is not shown: pass the input links file to include fiber_length_km.</p>{{end}}
+
+<h2>Failing links ({{len .Failing}})</h2>
+{{if .Failing}}<div class="scroll"><table class="sortable">
+<thead><tr><th>Link</th><th>Scenario</th><th>Margin (dB)</th><th>Rx power (dBm)</th><th>Total loss (dB)</th><th>Contributors</th></tr></thead>
+<tbody>
+{{range .Failing}}<tr><td>{{.LinkID}}</td><td>{{.Scenario}}</td><td class="n" data-v="{{.MarginDb}}">{{num .MarginDb}}</td><td class="n" data-v="{{.RxPowerDbm}}">{{num .RxPowerDbm}}</td><td class="n" data-v="{{.TotalLossDb}}">{{num .TotalLossDb}}</td><td>{{.TopContributor1}}{{if .TopContributor2}}, {{.TopContributor2}}{{end}}{{if .TopContributor3}}, {{.TopContributor3}}{{end}}</td></tr>
+{{end}}</tbody>
+</table></div>{{else}}<p>Every link passes the power budget.</p>{{end}}
+
+<h2>All links</h2>
+<div class="scroll"><table class="sortable">
+<thead><tr><th>Link</th><th>Scenario</th>{{if .Lengths}}<th>Length (km)</th>{{end}}<th>Total loss (dB)</th><th>Rx power (dBm)</th><th>Margin (dB)</th><th>LPB</th><th>RTB</th><th>Top contributor</th>{{if (index .Results 0).ComplianceProfile}}<th>Compliance</th>{{end}}</tr></thead>
+<tbody>
+{{$lengths := .Lengths}}{{$compliance := (index .Results 0).ComplianceProfile}}{{range .Results}}<tr><td>{{.LinkID}}</td><td>{{.Scenario}}</td>{{if $lengths}}<td class="n" data-v="{{length $lengths .LinkID}}">{{length $lengths .LinkID}}</td>{{end}}<td class="n" data-v="{{.TotalLossDb}}">{{num .TotalLossDb}}</td><td class="n" data-v="{{.RxPowerDbm}}">{{num .RxPowerDbm}}</td><td class="n" data-v="{{.MarginDb}}">{{num .MarginDb}}</td><td class="{{.LPBStatus}}">{{.LPBStatus}}</td><td>{{if .RTBStatus}}pass{{else}}-{{end}}</td><td>{{.TopContributor1}}</td>{{if $compliance}}<td class="{{.ComplianceStatus}}">{{.ComplianceStatus}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table></div>
+
+<script>
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th").forEach(function (th, col) {
+    th.addEventListener("click", function () {
+      var asc = th.getAttribute("aria-sort") !== "ascending";
+      table.querySelectorAll("th").forEach(function (h) { h.removeAttribute("aria-sort"); });
+      th.setAttribute("aria-sort", asc ? "ascending" : "descending");
+      var body = table.tBodies[0];
+      var rows = Array.prototype.slice.call(body.rows);
+      var key = function (row) {
+        var cell = row.cells[col];
+        var v = cell.getAttribute("data-v");
+        return v !== null && v !== "" && !isNaN(v) ? parseFloat(v) : cell.textContent;
+      };
+      rows.sort(function (a, b) {
+        var x = key(a), y = key(b);
+        var c = typeof x === "number" && typeof y === "number" ? x - y : String(x).localeCompare(String(y));
+        return asc ? c : -c;
+      });
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+});
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,333 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/cable"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/rpc/fov1"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/sweep"
+)
+
+// Define function to convert a protobuf link into the model contract
+func linkFromProto(p *fov1.LinkInput) model.LinkInput {
+	return model.LinkInput{
+		LinkID:           p.GetLinkId(),
+		Scenario:         p.GetScenario(),
+		TXPowerDbm:       p.GetTxPowerDbm(),
+		RXSensitivityDbm: p.GetRxSensitivityDbm(),
+		SystemMarginDb:   p.GetSystemMarginDb(),
+		FiberLengthKm:    p.GetFiberLengthKm(),
+		FiberAttDbPerKm:  p.GetFiberAttDbPerKm(),
+		NSplice:          int(p.GetNSplice()),
+		SpliceLossDb:     p.GetSpliceLossDb(),
+		NConnectors:      int(p.GetNConnector()),
+		ConnectorLossDb:  p.GetConnectorLossDb(),
+		SplitterLossDb:   p.GetSplitterLossDb(),
+		OtherLossDb:      p.GetOtherLossDb(),
+		WavelengthNm:     p.GetWavelengthNm(),
+		FiberType:        p.GetFiberType(),
+		SplitterID:       p.GetSplitterId(),
+		SplitterPort:     int(p.GetSplitterPort()),
+		SplitRatio:       int(p.GetSplitRatio()),
+		Amplifiers:       amplifiersFromProto(p.GetAmplifiers()),
+		RequiredOSNRDb:   p.GetRequiredOsnrDb(),
+		SensitivityBER:   p.GetSensitivityBer(),
+		QSlope:           p.GetQSlope(),
+		FEC:              p.GetFec(),
+		TargetBER:        p.GetTargetBer(),
+
+		DispersionPsNmKm:    p.GetDispersionPsNmKm(),
+		SpectralWidthNm:     p.GetSpectralWidthNm(),
+		ExtinctionRatioDb:   p.GetExtinctionRatioDb(),
+		RINDbHz:             p.GetRinDbHz(),
+		MPNFactor:           p.GetMpnK(),
+		DispersionPenaltyDb: p.GetDispersionPenaltyDb(),
+		ExtinctionPenaltyDb: p.GetErPenaltyDb(),
+		RINPenaltyDb:        p.GetRinPenaltyDb(),
+		MPNPenaltyDb:        p.GetMpnPenaltyDb(),
+		ReflectionPenaltyDb: p.GetReflectionPenaltyDb(),
+		ChirpPenaltyDb:      p.GetChirpPenaltyDb(),
+
+		PMDPsSqrtKm: p.GetPmdPsSqrtKm(),
+		BitrateGbps: p.GetBitrateGbps(),
+
+		Channels:    channelsFromProto(p.GetChannels()),
+		MuxLossDb:   p.GetMuxLossDb(),
+		DemuxLossDb: p.GetDemuxLossDb(),
+		NOADM:       int(p.GetNOadm()),
+		OADMLossDb:  p.GetOadmLossDb(),
+
+		Services: servicesFromProto(p.GetServices()),
+
+		ConnectorType:          p.GetConnectorType(),
+		ConnectorReflectanceDb: p.GetConnectorReflectanceDb(),
+		SpliceReflectanceDb:    p.GetSpliceReflectanceDb(),
+		ORLToleranceDb:         p.GetOrlToleranceDb(),
+
+		CableType:          p.GetCableType(),
+		RepairSplicesPerKm: p.GetRepairSplicesPerKm(),
+		TempDriftDbPerKm:   p.GetTempDriftDbPerKm(),
+		AgingMarginDb:      p.GetAgingMarginDb(),
+		CableCutMarginDb:   p.GetCableCutMarginDb(),
+
+		SourceLinewidthMHz: p.GetSourceLinewidthMhz(),
+		EffectiveAreaUm2:   p.GetEffectiveAreaUm2(),
+
+		PHY: p.GetPhy(),
+	}
+}
+
+// Define function to convert an amplifier chain
+func amplifiersFromProto(p []*fov1.Amplifier) []model.Amplifier {
+	if len(p) == 0 {
+		return nil
+	}
+	out := make([]model.Amplifier, len(p))
+	for i, a := range p {
+		out[i] = model.Amplifier{
+			Type:          a.GetType(),
+			PositionKm:    a.GetPositionKm(),
+			GainDb:        a.GetGainDb(),
+			NoiseFigureDb: a.GetNoiseFigureDb(),
+			PsatDbm:       a.GetPsatDbm(),
+		}
+	}
+	return out
+}
+
+// Define function to convert a WDM channel plan
+func channelsFromProto(p []*fov1.Channel) []model.Channel {
+	if len(p) == 0 {
+		return nil
+	}
+	out := make([]model.Channel, len(p))
+	for i, c := range p {
+		out[i] = model.Channel{Label: c.GetLabel(), WavelengthNm: c.GetWavelengthNm()}
+	}
+	return out
+}
+
+// Define function to convert the services of a coexistence link
+func servicesFromProto(p []*fov1.Service) []model.Service {
+	if len(p) == 0 {
+		return nil
+	}
+	out := make([]model.Service, len(p))
+	for i, s := range p {
+		out[i] = model.Service{Name: s.GetName()}
+		if len(s.GetParams()) > 0 {
+			out[i].Params = make(map[string]float64, len(s.GetParams()))
+			for k, v := range s.GetParams() {
+				out[i].Params[k] = v
+			}
+		}
+	}
+	return out
+}
+
+// Define function to convert a result into its protobuf form
+func outputToProto(o model.LinkOutput) *fov1.LinkOutput {
+	return &fov1.LinkOutput{
+		LinkId:            o.LinkID,
+		Scenario:          o.Scenario,
+		FiberLossDb:       o.FiberLossDb,
+		SpliceTotalDb:     o.SpliceTotalDb,
+		ConnectorTotalDb:  o.ConnectorTotalDb,
+		TotalLossDb:       o.TotalLossDb,
+		RxPowerDbm:        o.RxPowerDbm,
+		MarginDb:          o.MarginDb,
+		LpbStatus:         o.LPBStatus,
+		SystemRiseTimeNs:  o.SystemRiseTimeNs,
+		AllowedRiseTimeNs: o.AllowedRiseTimeNs,
+		RtbPass:           o.RTBStatus,
+		TopContributor_1:  o.TopContributor1,
+		TopContributor_2:  o.TopContributor2,
+		TopContributor_3:  o.TopContributor3,
+		ComplianceProfile: o.ComplianceProfile,
+		ComplianceStatus:  o.ComplianceStatus,
+		ComplianceClause:  o.ComplianceClause,
+		AmplifierGainDb:   o.AmplifierGainDb,
+		OsnrDb:            o.OSNRDb,
+		OsnrStatus:        o.OSNRStatus,
+		QFactor:           o.QFactor,
+		Ber:               o.BER,
+		BerStatus:         o.BERStatus,
+		PostFecBer:        o.PostFECBER,
+		PostFecStatus:     o.PostFECStatus,
+
+		DispersionPenaltyDb: o.DispersionPenaltyDb,
+		ErPenaltyDb:         o.ExtinctionPenaltyDb,
+		RinPenaltyDb:        o.RINPenaltyDb,
+		MpnPenaltyDb:        o.MPNPenaltyDb,
+		ReflectionPenaltyDb: o.ReflectionPenaltyDb,
+		ChirpPenaltyDb:      o.ChirpPenaltyDb,
+		PenaltyTotalDb:      o.PenaltyTotalDb,
+		UnboundedPenalties:  o.UnboundedPenalties,
+
+		DgdPs:     o.DGDPs,
+		MaxDgdPs:  o.MaxDGDPs,
+		PmdStatus: o.PMDStatus,
+
+		WdmLossDb:      o.WDMLossDb,
+		ChannelCount:   int32(o.ChannelCount),
+		WorstChannel:   o.WorstChannel,
+		WorstChannelNm: o.WorstChannelNm,
+		Channels:       channelsToProto(o.Channels),
+
+		LimitingService: o.LimitingService,
+		Services:        servicesToProto(o.Services),
+
+		OrlDb:                o.ORLDb,
+		ReflectionStatus:     o.ReflectionStatus,
+		WorstReflectionEvent: o.WorstReflectionEvent,
+		WorstReflectanceDb:   o.WorstReflectanceDb,
+
+		RepairAllowanceDb:      o.RepairAllowanceDb,
+		TemperatureAllowanceDb: o.TemperatureAllowanceDb,
+		AgingAllowanceDb:       o.AgingAllowanceDb,
+		CableCutAllowanceDb:    o.CableCutAllowanceDb,
+		AllowanceTotalDb:       o.AllowanceTotalDb,
+
+		EffectiveLengthKm: o.EffectiveLengthKm,
+		SbsThresholdDbm:   o.SBSThresholdDbm,
+		SpmThresholdDbm:   o.SPMThresholdDbm,
+		NonlinearPhaseRad: o.NonlinearPhaseRad,
+		SbsPenaltyDb:      o.SBSPenaltyDb,
+		SpmPenaltyDb:      o.SPMPenaltyDb,
+		NonlinearStatus:   o.NonlinearStatus,
+
+		ModalRiseTimeNs:     o.ModalRiseTimeNs,
+		PhyReachM:           o.PHYReachM,
+		PhyMaxChannelLossDb: o.PHYMaxChannelLossDb,
+		PhyLimit:            o.PHYLimit,
+		PhyStatus:           o.PHYStatus,
+	}
+}
+
+// Define function to convert per-channel results
+func channelsToProto(c []model.ChannelOutput) []*fov1.ChannelOutput {
+	if len(c) == 0 {
+		return nil
+	}
+	out := make([]*fov1.ChannelOutput, len(c))
+	for i, ch := range c {
+		out[i] = &fov1.ChannelOutput{
+			Label:            ch.Label,
+			WavelengthNm:     ch.WavelengthNm,
+			FiberAttDbPerKm:  ch.FiberAttDbPerKm,
+			DispersionPsNmKm: ch.DispersionPsNmKm,
+			TotalLossDb:      ch.TotalLossDb,
+			PenaltyTotalDb:   ch.PenaltyTotalDb,
+			RxPowerDbm:       ch.RxPowerDbm,
+			MarginDb:         ch.MarginDb,
+			LpbStatus:        ch.LPBStatus,
+		}
+	}
+	return out
+}
+
+// Define function to convert per-service results
+func servicesToProto(s []model.ServiceOutput) []*fov1.ServiceOutput {
+	if len(s) == 0 {
+		return nil
+	}
+	out := make([]*fov1.ServiceOutput, len(s))
+	for i, svc := range s {
+		out[i] = &fov1.ServiceOutput{
+			Service:          svc.Service,
+			Direction:        svc.Direction,
+			WavelengthNm:     svc.WavelengthNm,
+			FilterLossDb:     svc.FilterLossDb,
+			TxPowerDbm:       svc.TxPowerDbm,
+			RxSensitivityDbm: svc.RxSensitivityDbm,
+			RxPowerDbm:       svc.RxPowerDbm,
+			MarginDb:         svc.MarginDb,
+			OverloadMarginDb: svc.OverloadMarginDb,
+			Status:           svc.Status,
+		}
+	}
+	return out
+}
+
+// Define function to merge runner options over the defaults: fields the
+// request leaves unset, and options the schema does not carry, keep def. The
+// result is not passed through WithDefaults, so later merges start from what
+// was asked for rather than from filled-in defaults.
+func optionsFromProto(p *fov1.RunnerOptions, def calc.RunnerOptions) (calc.RunnerOptions, error) {
+	opt := def
+	if p == nil {
+		return opt, nil
+	}
+	if p.EnableRtb != nil {
+		opt.EnableRTB = p.GetEnableRtb()
+	}
+	if p.BitrateGbps != nil {
+		opt.BitrateGbps = p.GetBitrateGbps()
+	}
+	if p.TxRiseTimeNs != nil {
+		opt.TxRiseTimeNs = p.GetTxRiseTimeNs()
+	}
+	if p.RxRiseTimeNs != nil {
+		opt.RxRiseTimeNs = p.GetRxRiseTimeNs()
+	}
+	if p.DispersionNsPerKm != nil {
+		opt.DispersionPerKm = p.GetDispersionNsPerKm()
+	}
+	if p.Fec != nil {
+		if _, ok := calc.LookupFEC(p.GetFec()); !ok {
+			return def, errors.New("Invalid fec: " + p.GetFec())
+		}
+		opt.FEC = p.GetFec()
+	}
+	if p.TargetBer != nil {
+		opt.TargetBER = p.GetTargetBer()
+	}
+	if p.PmdBitFraction != nil {
+		opt.PMDBitFraction = p.GetPmdBitFraction()
+	}
+	if len(p.GetCables()) > 0 {
+		// Copy so the request never writes into the server defaults
+		cables := make(map[string]cable.Allowances, len(def.Cables)+len(p.GetCables()))
+		for name, a := range def.Cables {
+			cables[name] = a
+		}
+		for name, a := range p.GetCables() {
+			cables[name] = cable.Allowances{
+				RepairSplicesPerKm: a.GetRepairSplicesPerKm(),
+				TempDriftDbPerKm:   a.GetTempDriftDbPerKm(),
+				AgingDb:            a.GetAgingDb(),
+				CableCutDb:         a.GetCableCutDb(),
+			}
+		}
+		opt.Cables = cables
+	}
+	return opt, nil
+}
+
+// Define function to convert a sweep variation
+func variationFromProto(p *fov1.Variation) sweep.Variation {
+	return sweep.Variation{Field: p.GetField(), Values: append([]float64(nil), p.GetValues()...)}
+}
+
+// Define function to convert row errors into their protobuf form
+func errorsToProto(errs []model.RowError) []*fov1.RowError {
+	out := make([]*fov1.RowError, 0, len(errs))
+	for _, e := range errs {
+		refs := make([]int32, len(e.Refs))
+		for i, r := range e.Refs {
+			refs[i] = int32(r)
+		}
+		out = append(out, &fov1.RowError{
+			Row:      int32(e.Row),
+			Line:     int32(e.Line),
+			Field:    e.Field,
+			Value:    e.Value,
+			Message:  e.Message,
+			Severity: e.Severity,
+			Refs:     refs,
+		})
+	}
+	return out
+}
@@ -0,0 +1,2500 @@
+// Protobuf contract for the fiber optic performance engine.
+// Field names follow the CSV columns used by the CLI.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: fo/v1/engine.proto
+
+package fov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Link input contract data
+type LinkInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifiers
+	LinkId   string `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	Scenario string `protobuf:"bytes,2,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	// Transmitter and receiver parameters
+	TxPowerDbm       float64 `protobuf:"fixed64,3,opt,name=tx_power_dbm,json=txPowerDbm,proto3" json:"tx_power_dbm,omitempty"`
+	RxSensitivityDbm float64 `protobuf:"fixed64,4,opt,name=rx_sensitivity_dbm,json=rxSensitivityDbm,proto3" json:"rx_sensitivity_dbm,omitempty"`
+	SystemMarginDb   float64 `protobuf:"fixed64,5,opt,name=system_margin_db,json=systemMarginDb,proto3" json:"system_margin_db,omitempty"`
+	// Fiber and component parameters
+	FiberLengthKm   float64 `protobuf:"fixed64,6,opt,name=fiber_length_km,json=fiberLengthKm,proto3" json:"fiber_length_km,omitempty"`
+	FiberAttDbPerKm float64 `protobuf:"fixed64,7,opt,name=fiber_att_db_per_km,json=fiberAttDbPerKm,proto3" json:"fiber_att_db_per_km,omitempty"`
+	// Component losses and counts
+	NSplice         int32   `protobuf:"varint,8,opt,name=n_splice,json=nSplice,proto3" json:"n_splice,omitempty"`
+	SpliceLossDb    float64 `protobuf:"fixed64,9,opt,name=splice_loss_db,json=spliceLossDb,proto3" json:"splice_loss_db,omitempty"`
+	NConnector      int32   `protobuf:"varint,10,opt,name=n_connector,json=nConnector,proto3" json:"n_connector,omitempty"`
+	ConnectorLossDb float64 `protobuf:"fixed64,11,opt,name=connector_loss_db,json=connectorLossDb,proto3" json:"connector_loss_db,omitempty"`
+	SplitterLossDb  float64 `protobuf:"fixed64,12,opt,name=splitter_loss_db,json=splitterLossDb,proto3" json:"splitter_loss_db,omitempty"`
+	OtherLossDb     float64 `protobuf:"fixed64,13,opt,name=other_loss_db,json=otherLossDb,proto3" json:"other_loss_db,omitempty"`
+	// Optional fiber description
+	WavelengthNm float64 `protobuf:"fixed64,14,opt,name=wavelength_nm,json=wavelengthNm,proto3" json:"wavelength_nm,omitempty"`
+	FiberType    string  `protobuf:"bytes,15,opt,name=fiber_type,json=fiberType,proto3" json:"fiber_type,omitempty"`
+	// Optional splitter assignment
+	SplitterId   string `protobuf:"bytes,16,opt,name=splitter_id,json=splitterId,proto3" json:"splitter_id,omitempty"`
+	SplitterPort int32  `protobuf:"varint,17,opt,name=splitter_port,json=splitterPort,proto3" json:"splitter_port,omitempty"`
+	SplitRatio   int32  `protobuf:"varint,18,opt,name=split_ratio,json=splitRatio,proto3" json:"split_ratio,omitempty"`
+	// Optional amplifier chain and the OSNR the receiver needs
+	Amplifiers     []*Amplifier `protobuf:"bytes,19,rep,name=amplifiers,proto3" json:"amplifiers,omitempty"`
+	RequiredOsnrDb float64      `protobuf:"fixed64,20,opt,name=required_osnr_db,json=requiredOsnrDb,proto3" json:"required_osnr_db,omitempty"`
+	// Optional receiver BER model: rx_sensitivity_dbm holds at sensitivity_ber,
+	// Q scales with received power at q_slope (1 thermal, 0.5 shot noise)
+	SensitivityBer float64 `protobuf:"fixed64,21,opt,name=sensitivity_ber,json=sensitivityBer,proto3" json:"sensitivity_ber,omitempty"`
+	QSlope         float64 `protobuf:"fixed64,22,opt,name=q_slope,json=qSlope,proto3" json:"q_slope,omitempty"`
+	Fec            string  `protobuf:"bytes,23,opt,name=fec,proto3" json:"fec,omitempty"`
+	TargetBer      float64 `protobuf:"fixed64,24,opt,name=target_ber,json=targetBer,proto3" json:"target_ber,omitempty"`
+	// Optional power penalty parameters; a *_penalty_db value greater than zero
+	// is used as given instead of being computed
+	DispersionPsNmKm    float64 `protobuf:"fixed64,25,opt,name=dispersion_ps_nm_km,json=dispersionPsNmKm,proto3" json:"dispersion_ps_nm_km,omitempty"` // 0 uses the fiber type at wavelength_nm
+	SpectralWidthNm     float64 `protobuf:"fixed64,26,opt,name=spectral_width_nm,json=spectralWidthNm,proto3" json:"spectral_width_nm,omitempty"`
+	ExtinctionRatioDb   float64 `protobuf:"fixed64,27,opt,name=extinction_ratio_db,json=extinctionRatioDb,proto3" json:"extinction_ratio_db,omitempty"`
+	RinDbHz             float64 `protobuf:"fixed64,28,opt,name=rin_db_hz,json=rinDbHz,proto3" json:"rin_db_hz,omitempty"`
+	MpnK                float64 `protobuf:"fixed64,29,opt,name=mpn_k,json=mpnK,proto3" json:"mpn_k,omitempty"`
+	DispersionPenaltyDb float64 `protobuf:"fixed64,30,opt,name=dispersion_penalty_db,json=dispersionPenaltyDb,proto3" json:"dispersion_penalty_db,omitempty"`
+	ErPenaltyDb         float64 `protobuf:"fixed64,31,opt,name=er_penalty_db,json=erPenaltyDb,proto3" json:"er_penalty_db,omitempty"`
+	RinPenaltyDb        float64 `protobuf:"fixed64,32,opt,name=rin_penalty_db,json=rinPenaltyDb,proto3" json:"rin_penalty_db,omitempty"`
+	MpnPenaltyDb        float64 `protobuf:"fixed64,33,opt,name=mpn_penalty_db,json=mpnPenaltyDb,proto3" json:"mpn_penalty_db,omitempty"`
+	ReflectionPenaltyDb float64 `protobuf:"fixed64,34,opt,name=reflection_penalty_db,json=reflectionPenaltyDb,proto3" json:"reflection_penalty_db,omitempty"`
+	ChirpPenaltyDb      float64 `protobuf:"fixed64,35,opt,name=chirp_penalty_db,json=chirpPenaltyDb,proto3" json:"chirp_penalty_db,omitempty"`
+	// Optional PMD coefficient in ps/sqrt(km); 0 uses the declared fiber type
+	PmdPsSqrtKm float64 `protobuf:"fixed64,36,opt,name=pmd_ps_sqrt_km,json=pmdPsSqrtKm,proto3" json:"pmd_ps_sqrt_km,omitempty"`
+	// Optional line rate of the link for the rise time, penalty and PMD checks;
+	// 0 uses the lane rate of a declared phy, else the run-wide bitrate
+	BitrateGbps float64 `protobuf:"fixed64,37,opt,name=bitrate_gbps,json=bitrateGbps,proto3" json:"bitrate_gbps,omitempty"`
+	// Optional WDM channel plan and per-port insertion losses of the mux,
+	// demux and each OADM passed through
+	Channels    []*Channel `protobuf:"bytes,38,rep,name=channels,proto3" json:"channels,omitempty"`
+	MuxLossDb   float64    `protobuf:"fixed64,39,opt,name=mux_loss_db,json=muxLossDb,proto3" json:"mux_loss_db,omitempty"`
+	DemuxLossDb float64    `protobuf:"fixed64,40,opt,name=demux_loss_db,json=demuxLossDb,proto3" json:"demux_loss_db,omitempty"`
+	NOadm       int32      `protobuf:"varint,41,opt,name=n_oadm,json=nOadm,proto3" json:"n_oadm,omitempty"`
+	OadmLossDb  float64    `protobuf:"fixed64,42,opt,name=oadm_loss_db,json=oadmLossDb,proto3" json:"oadm_loss_db,omitempty"`
+	// Optional PON services sharing the ODN through coexistence elements;
+	// tx_power_dbm and rx_sensitivity_dbm then come from each service
+	Services []*Service `protobuf:"bytes,43,rep,name=services,proto3" json:"services,omitempty"`
+	// Optional reflectance budget: connector polish (pc, upc, apc) or explicit
+	// reflectances in dB (negative), and the ORL the transmitter tolerates
+	ConnectorType          string  `protobuf:"bytes,44,opt,name=connector_type,json=connectorType,proto3" json:"connector_type,omitempty"`
+	ConnectorReflectanceDb float64 `protobuf:"fixed64,45,opt,name=connector_reflectance_db,json=connectorReflectanceDb,proto3" json:"connector_reflectance_db,omitempty"`
+	SpliceReflectanceDb    float64 `protobuf:"fixed64,46,opt,name=splice_reflectance_db,json=spliceReflectanceDb,proto3" json:"splice_reflectance_db,omitempty"` // 0 uses -70 dB (fusion)
+	OrlToleranceDb         float64 `protobuf:"fixed64,47,opt,name=orl_tolerance_db,json=orlToleranceDb,proto3" json:"orl_tolerance_db,omitempty"`
+	// Optional margin allowances: a cable type (duct, direct-buried, aerial,
+	// indoor or one from the runner options) and per-link overrides of its values
+	CableType          string  `protobuf:"bytes,48,opt,name=cable_type,json=cableType,proto3" json:"cable_type,omitempty"`
+	RepairSplicesPerKm float64 `protobuf:"fixed64,49,opt,name=repair_splices_per_km,json=repairSplicesPerKm,proto3" json:"repair_splices_per_km,omitempty"`
+	TempDriftDbPerKm   float64 `protobuf:"fixed64,50,opt,name=temp_drift_db_per_km,json=tempDriftDbPerKm,proto3" json:"temp_drift_db_per_km,omitempty"`
+	AgingMarginDb      float64 `protobuf:"fixed64,51,opt,name=aging_margin_db,json=agingMarginDb,proto3" json:"aging_margin_db,omitempty"`
+	CableCutMarginDb   float64 `protobuf:"fixed64,52,opt,name=cable_cut_margin_db,json=cableCutMarginDb,proto3" json:"cable_cut_margin_db,omitempty"`
+	// Optional nonlinear threshold check (SBS, SPM), evaluated when the source
+	// linewidth is known; the effective area defaults to the fiber type's
+	SourceLinewidthMhz float64 `protobuf:"fixed64,53,opt,name=source_linewidth_mhz,json=sourceLinewidthMhz,proto3" json:"source_linewidth_mhz,omitempty"`
+	EffectiveAreaUm2   float64 `protobuf:"fixed64,54,opt,name=effective_area_um2,json=effectiveAreaUm2,proto3" json:"effective_area_um2,omitempty"`
+	// Optional multimode Ethernet PHY (e.g. 10gbase-sr) checked against its
+	// IEEE 802.3 reach and channel loss over the declared fiber type
+	Phy           string `protobuf:"bytes,55,opt,name=phy,proto3" json:"phy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkInput) Reset() {
+	*x = LinkInput{}
+	mi := &file_fo_v1_engine_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkInput) ProtoMessage() {}
+
+func (x *LinkInput) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkInput.ProtoReflect.Descriptor instead.
+func (*LinkInput) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LinkInput) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *LinkInput) GetScenario() string {
+	if x != nil {
+		return x.Scenario
+	}
+	return ""
+}
+
+func (x *LinkInput) GetTxPowerDbm() float64 {
+	if x != nil {
+		return x.TxPowerDbm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetRxSensitivityDbm() float64 {
+	if x != nil {
+		return x.RxSensitivityDbm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetSystemMarginDb() float64 {
+	if x != nil {
+		return x.SystemMarginDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetFiberLengthKm() float64 {
+	if x != nil {
+		return x.FiberLengthKm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetFiberAttDbPerKm() float64 {
+	if x != nil {
+		return x.FiberAttDbPerKm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetNSplice() int32 {
+	if x != nil {
+		return x.NSplice
+	}
+	return 0
+}
+
+func (x *LinkInput) GetSpliceLossDb() float64 {
+	if x != nil {
+		return x.SpliceLossDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetNConnector() int32 {
+	if x != nil {
+		return x.NConnector
+	}
+	return 0
+}
+
+func (x *LinkInput) GetConnectorLossDb() float64 {
+	if x != nil {
+		return x.ConnectorLossDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetSplitterLossDb() float64 {
+	if x != nil {
+		return x.SplitterLossDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetOtherLossDb() float64 {
+	if x != nil {
+		return x.OtherLossDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetWavelengthNm() float64 {
+	if x != nil {
+		return x.WavelengthNm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetFiberType() string {
+	if x != nil {
+		return x.FiberType
+	}
+	return ""
+}
+
+func (x *LinkInput) GetSplitterId() string {
+	if x != nil {
+		return x.SplitterId
+	}
+	return ""
+}
+
+func (x *LinkInput) GetSplitterPort() int32 {
+	if x != nil {
+		return x.SplitterPort
+	}
+	return 0
+}
+
+func (x *LinkInput) GetSplitRatio() int32 {
+	if x != nil {
+		return x.SplitRatio
+	}
+	return 0
+}
+
+func (x *LinkInput) GetAmplifiers() []*Amplifier {
+	if x != nil {
+		return x.Amplifiers
+	}
+	return nil
+}
+
+func (x *LinkInput) GetRequiredOsnrDb() float64 {
+	if x != nil {
+		return x.RequiredOsnrDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetSensitivityBer() float64 {
+	if x != nil {
+		return x.SensitivityBer
+	}
+	return 0
+}
+
+func (x *LinkInput) GetQSlope() float64 {
+	if x != nil {
+		return x.QSlope
+	}
+	return 0
+}
+
+func (x *LinkInput) GetFec() string {
+	if x != nil {
+		return x.Fec
+	}
+	return ""
+}
+
+func (x *LinkInput) GetTargetBer() float64 {
+	if x != nil {
+		return x.TargetBer
+	}
+	return 0
+}
+
+func (x *LinkInput) GetDispersionPsNmKm() float64 {
+	if x != nil {
+		return x.DispersionPsNmKm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetSpectralWidthNm() float64 {
+	if x != nil {
+		return x.SpectralWidthNm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetExtinctionRatioDb() float64 {
+	if x != nil {
+		return x.ExtinctionRatioDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetRinDbHz() float64 {
+	if x != nil {
+		return x.RinDbHz
+	}
+	return 0
+}
+
+func (x *LinkInput) GetMpnK() float64 {
+	if x != nil {
+		return x.MpnK
+	}
+	return 0
+}
+
+func (x *LinkInput) GetDispersionPenaltyDb() float64 {
+	if x != nil {
+		return x.DispersionPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetErPenaltyDb() float64 {
+	if x != nil {
+		return x.ErPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetRinPenaltyDb() float64 {
+	if x != nil {
+		return x.RinPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetMpnPenaltyDb() float64 {
+	if x != nil {
+		return x.MpnPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetReflectionPenaltyDb() float64 {
+	if x != nil {
+		return x.ReflectionPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetChirpPenaltyDb() float64 {
+	if x != nil {
+		return x.ChirpPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetPmdPsSqrtKm() float64 {
+	if x != nil {
+		return x.PmdPsSqrtKm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetBitrateGbps() float64 {
+	if x != nil {
+		return x.BitrateGbps
+	}
+	return 0
+}
+
+func (x *LinkInput) GetChannels() []*Channel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *LinkInput) GetMuxLossDb() float64 {
+	if x != nil {
+		return x.MuxLossDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetDemuxLossDb() float64 {
+	if x != nil {
+		return x.DemuxLossDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetNOadm() int32 {
+	if x != nil {
+		return x.NOadm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetOadmLossDb() float64 {
+	if x != nil {
+		return x.OadmLossDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *LinkInput) GetConnectorType() string {
+	if x != nil {
+		return x.ConnectorType
+	}
+	return ""
+}
+
+func (x *LinkInput) GetConnectorReflectanceDb() float64 {
+	if x != nil {
+		return x.ConnectorReflectanceDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetSpliceReflectanceDb() float64 {
+	if x != nil {
+		return x.SpliceReflectanceDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetOrlToleranceDb() float64 {
+	if x != nil {
+		return x.OrlToleranceDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetCableType() string {
+	if x != nil {
+		return x.CableType
+	}
+	return ""
+}
+
+func (x *LinkInput) GetRepairSplicesPerKm() float64 {
+	if x != nil {
+		return x.RepairSplicesPerKm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetTempDriftDbPerKm() float64 {
+	if x != nil {
+		return x.TempDriftDbPerKm
+	}
+	return 0
+}
+
+func (x *LinkInput) GetAgingMarginDb() float64 {
+	if x != nil {
+		return x.AgingMarginDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetCableCutMarginDb() float64 {
+	if x != nil {
+		return x.CableCutMarginDb
+	}
+	return 0
+}
+
+func (x *LinkInput) GetSourceLinewidthMhz() float64 {
+	if x != nil {
+		return x.SourceLinewidthMhz
+	}
+	return 0
+}
+
+func (x *LinkInput) GetEffectiveAreaUm2() float64 {
+	if x != nil {
+		return x.EffectiveAreaUm2
+	}
+	return 0
+}
+
+func (x *LinkInput) GetPhy() string {
+	if x != nil {
+		return x.Phy
+	}
+	return ""
+}
+
+// Inline optical amplifier
+type Amplifier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "edfa" or "soa"
+	PositionKm    float64                `protobuf:"fixed64,2,opt,name=position_km,json=positionKm,proto3" json:"position_km,omitempty"`
+	GainDb        float64                `protobuf:"fixed64,3,opt,name=gain_db,json=gainDb,proto3" json:"gain_db,omitempty"`
+	NoiseFigureDb float64                `protobuf:"fixed64,4,opt,name=noise_figure_db,json=noiseFigureDb,proto3" json:"noise_figure_db,omitempty"`
+	PsatDbm       float64                `protobuf:"fixed64,5,opt,name=psat_dbm,json=psatDbm,proto3" json:"psat_dbm,omitempty"` // 0 means unlimited
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Amplifier) Reset() {
+	*x = Amplifier{}
+	mi := &file_fo_v1_engine_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Amplifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Amplifier) ProtoMessage() {}
+
+func (x *Amplifier) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Amplifier.ProtoReflect.Descriptor instead.
+func (*Amplifier) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Amplifier) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Amplifier) GetPositionKm() float64 {
+	if x != nil {
+		return x.PositionKm
+	}
+	return 0
+}
+
+func (x *Amplifier) GetGainDb() float64 {
+	if x != nil {
+		return x.GainDb
+	}
+	return 0
+}
+
+func (x *Amplifier) GetNoiseFigureDb() float64 {
+	if x != nil {
+		return x.NoiseFigureDb
+	}
+	return 0
+}
+
+func (x *Amplifier) GetPsatDbm() float64 {
+	if x != nil {
+		return x.PsatDbm
+	}
+	return 0
+}
+
+// WDM channel of a link
+type Channel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"` // Grid name, e.g. "C21" or "1471"
+	WavelengthNm  float64                `protobuf:"fixed64,2,opt,name=wavelength_nm,json=wavelengthNm,proto3" json:"wavelength_nm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Channel) Reset() {
+	*x = Channel{}
+	mi := &file_fo_v1_engine_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Channel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Channel) ProtoMessage() {}
+
+func (x *Channel) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Channel.ProtoReflect.Descriptor instead.
+func (*Channel) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Channel) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Channel) GetWavelengthNm() float64 {
+	if x != nil {
+		return x.WavelengthNm
+	}
+	return 0
+}
+
+// Evaluation of one WDM channel
+type ChannelOutput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Label            string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	WavelengthNm     float64                `protobuf:"fixed64,2,opt,name=wavelength_nm,json=wavelengthNm,proto3" json:"wavelength_nm,omitempty"`
+	FiberAttDbPerKm  float64                `protobuf:"fixed64,3,opt,name=fiber_att_db_per_km,json=fiberAttDbPerKm,proto3" json:"fiber_att_db_per_km,omitempty"`  // Attenuation at the channel wavelength
+	DispersionPsNmKm float64                `protobuf:"fixed64,4,opt,name=dispersion_ps_nm_km,json=dispersionPsNmKm,proto3" json:"dispersion_ps_nm_km,omitempty"` // Chromatic dispersion at the channel wavelength
+	TotalLossDb      float64                `protobuf:"fixed64,5,opt,name=total_loss_db,json=totalLossDb,proto3" json:"total_loss_db,omitempty"`
+	PenaltyTotalDb   float64                `protobuf:"fixed64,6,opt,name=penalty_total_db,json=penaltyTotalDb,proto3" json:"penalty_total_db,omitempty"`
+	RxPowerDbm       float64                `protobuf:"fixed64,7,opt,name=rx_power_dbm,json=rxPowerDbm,proto3" json:"rx_power_dbm,omitempty"`
+	MarginDb         float64                `protobuf:"fixed64,8,opt,name=margin_db,json=marginDb,proto3" json:"margin_db,omitempty"`
+	LpbStatus        string                 `protobuf:"bytes,9,opt,name=lpb_status,json=lpbStatus,proto3" json:"lpb_status,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ChannelOutput) Reset() {
+	*x = ChannelOutput{}
+	mi := &file_fo_v1_engine_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelOutput) ProtoMessage() {}
+
+func (x *ChannelOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelOutput.ProtoReflect.Descriptor instead.
+func (*ChannelOutput) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChannelOutput) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ChannelOutput) GetWavelengthNm() float64 {
+	if x != nil {
+		return x.WavelengthNm
+	}
+	return 0
+}
+
+func (x *ChannelOutput) GetFiberAttDbPerKm() float64 {
+	if x != nil {
+		return x.FiberAttDbPerKm
+	}
+	return 0
+}
+
+func (x *ChannelOutput) GetDispersionPsNmKm() float64 {
+	if x != nil {
+		return x.DispersionPsNmKm
+	}
+	return 0
+}
+
+func (x *ChannelOutput) GetTotalLossDb() float64 {
+	if x != nil {
+		return x.TotalLossDb
+	}
+	return 0
+}
+
+func (x *ChannelOutput) GetPenaltyTotalDb() float64 {
+	if x != nil {
+		return x.PenaltyTotalDb
+	}
+	return 0
+}
+
+func (x *ChannelOutput) GetRxPowerDbm() float64 {
+	if x != nil {
+		return x.RxPowerDbm
+	}
+	return 0
+}
+
+func (x *ChannelOutput) GetMarginDb() float64 {
+	if x != nil {
+		return x.MarginDb
+	}
+	return 0
+}
+
+func (x *ChannelOutput) GetLpbStatus() string {
+	if x != nil {
+		return x.LpbStatus
+	}
+	return ""
+}
+
+// PON service of a coexistence link, with optional parameter overrides
+// (e.g. up_tx, down_tx, wdm1r)
+type Service struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // e.g. "gpon", "xgs-pon", "rf-video"
+	Params        map[string]float64     `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	mi := &file_fo_v1_engine_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Service) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Service) GetParams() map[string]float64 {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// Evaluation of one direction of a service
+type ServiceOutput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Service          string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Direction        string                 `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"` // "down" or "up"
+	WavelengthNm     float64                `protobuf:"fixed64,3,opt,name=wavelength_nm,json=wavelengthNm,proto3" json:"wavelength_nm,omitempty"`
+	FilterLossDb     float64                `protobuf:"fixed64,4,opt,name=filter_loss_db,json=filterLossDb,proto3" json:"filter_loss_db,omitempty"` // CEx plus WDM1r
+	TxPowerDbm       float64                `protobuf:"fixed64,5,opt,name=tx_power_dbm,json=txPowerDbm,proto3" json:"tx_power_dbm,omitempty"`
+	RxSensitivityDbm float64                `protobuf:"fixed64,6,opt,name=rx_sensitivity_dbm,json=rxSensitivityDbm,proto3" json:"rx_sensitivity_dbm,omitempty"`
+	RxPowerDbm       float64                `protobuf:"fixed64,7,opt,name=rx_power_dbm,json=rxPowerDbm,proto3" json:"rx_power_dbm,omitempty"`
+	MarginDb         float64                `protobuf:"fixed64,8,opt,name=margin_db,json=marginDb,proto3" json:"margin_db,omitempty"`
+	OverloadMarginDb float64                `protobuf:"fixed64,9,opt,name=overload_margin_db,json=overloadMarginDb,proto3" json:"overload_margin_db,omitempty"` // Headroom below receiver overload
+	Status           string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ServiceOutput) Reset() {
+	*x = ServiceOutput{}
+	mi := &file_fo_v1_engine_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceOutput) ProtoMessage() {}
+
+func (x *ServiceOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceOutput.ProtoReflect.Descriptor instead.
+func (*ServiceOutput) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ServiceOutput) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *ServiceOutput) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ServiceOutput) GetWavelengthNm() float64 {
+	if x != nil {
+		return x.WavelengthNm
+	}
+	return 0
+}
+
+func (x *ServiceOutput) GetFilterLossDb() float64 {
+	if x != nil {
+		return x.FilterLossDb
+	}
+	return 0
+}
+
+func (x *ServiceOutput) GetTxPowerDbm() float64 {
+	if x != nil {
+		return x.TxPowerDbm
+	}
+	return 0
+}
+
+func (x *ServiceOutput) GetRxSensitivityDbm() float64 {
+	if x != nil {
+		return x.RxSensitivityDbm
+	}
+	return 0
+}
+
+func (x *ServiceOutput) GetRxPowerDbm() float64 {
+	if x != nil {
+		return x.RxPowerDbm
+	}
+	return 0
+}
+
+func (x *ServiceOutput) GetMarginDb() float64 {
+	if x != nil {
+		return x.MarginDb
+	}
+	return 0
+}
+
+func (x *ServiceOutput) GetOverloadMarginDb() float64 {
+	if x != nil {
+		return x.OverloadMarginDb
+	}
+	return 0
+}
+
+func (x *ServiceOutput) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Link output contract data
+type LinkOutput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifiers
+	LinkId   string `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	Scenario string `protobuf:"bytes,2,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	// Computed loss
+	FiberLossDb      float64 `protobuf:"fixed64,3,opt,name=fiber_loss_db,json=fiberLossDb,proto3" json:"fiber_loss_db,omitempty"`
+	SpliceTotalDb    float64 `protobuf:"fixed64,4,opt,name=splice_total_db,json=spliceTotalDb,proto3" json:"splice_total_db,omitempty"`
+	ConnectorTotalDb float64 `protobuf:"fixed64,5,opt,name=connector_total_db,json=connectorTotalDb,proto3" json:"connector_total_db,omitempty"`
+	TotalLossDb      float64 `protobuf:"fixed64,6,opt,name=total_loss_db,json=totalLossDb,proto3" json:"total_loss_db,omitempty"`
+	// Link power budget
+	RxPowerDbm float64 `protobuf:"fixed64,7,opt,name=rx_power_dbm,json=rxPowerDbm,proto3" json:"rx_power_dbm,omitempty"`
+	MarginDb   float64 `protobuf:"fixed64,8,opt,name=margin_db,json=marginDb,proto3" json:"margin_db,omitempty"`
+	LpbStatus  string  `protobuf:"bytes,9,opt,name=lpb_status,json=lpbStatus,proto3" json:"lpb_status,omitempty"`
+	// Rise time budget
+	SystemRiseTimeNs  float64 `protobuf:"fixed64,10,opt,name=system_rise_time_ns,json=systemRiseTimeNs,proto3" json:"system_rise_time_ns,omitempty"`
+	AllowedRiseTimeNs float64 `protobuf:"fixed64,11,opt,name=allowed_rise_time_ns,json=allowedRiseTimeNs,proto3" json:"allowed_rise_time_ns,omitempty"`
+	RtbPass           bool    `protobuf:"varint,12,opt,name=rtb_pass,json=rtbPass,proto3" json:"rtb_pass,omitempty"`
+	// Explainability
+	TopContributor_1 string `protobuf:"bytes,13,opt,name=top_contributor_1,json=topContributor1,proto3" json:"top_contributor_1,omitempty"`
+	TopContributor_2 string `protobuf:"bytes,14,opt,name=top_contributor_2,json=topContributor2,proto3" json:"top_contributor_2,omitempty"`
+	TopContributor_3 string `protobuf:"bytes,15,opt,name=top_contributor_3,json=topContributor3,proto3" json:"top_contributor_3,omitempty"`
+	// Standards compliance
+	ComplianceProfile string `protobuf:"bytes,16,opt,name=compliance_profile,json=complianceProfile,proto3" json:"compliance_profile,omitempty"`
+	ComplianceStatus  string `protobuf:"bytes,17,opt,name=compliance_status,json=complianceStatus,proto3" json:"compliance_status,omitempty"`
+	ComplianceClause  string `protobuf:"bytes,18,opt,name=compliance_clause,json=complianceClause,proto3" json:"compliance_clause,omitempty"`
+	// Amplified links (empty status when the link has no amplifiers)
+	AmplifierGainDb float64 `protobuf:"fixed64,19,opt,name=amplifier_gain_db,json=amplifierGainDb,proto3" json:"amplifier_gain_db,omitempty"`
+	OsnrDb          float64 `protobuf:"fixed64,20,opt,name=osnr_db,json=osnrDb,proto3" json:"osnr_db,omitempty"`
+	OsnrStatus      string  `protobuf:"bytes,21,opt,name=osnr_status,json=osnrStatus,proto3" json:"osnr_status,omitempty"`
+	// Bit error rate (empty status when the link has no BER model)
+	QFactor       float64 `protobuf:"fixed64,22,opt,name=q_factor,json=qFactor,proto3" json:"q_factor,omitempty"`
+	Ber           float64 `protobuf:"fixed64,23,opt,name=ber,proto3" json:"ber,omitempty"`
+	BerStatus     string  `protobuf:"bytes,24,opt,name=ber_status,json=berStatus,proto3" json:"ber_status,omitempty"`
+	PostFecBer    float64 `protobuf:"fixed64,25,opt,name=post_fec_ber,json=postFecBer,proto3" json:"post_fec_ber,omitempty"`
+	PostFecStatus string  `protobuf:"bytes,26,opt,name=post_fec_status,json=postFecStatus,proto3" json:"post_fec_status,omitempty"`
+	// Power penalties, subtracted from the margin alongside the system margin
+	DispersionPenaltyDb float64 `protobuf:"fixed64,27,opt,name=dispersion_penalty_db,json=dispersionPenaltyDb,proto3" json:"dispersion_penalty_db,omitempty"`
+	ErPenaltyDb         float64 `protobuf:"fixed64,28,opt,name=er_penalty_db,json=erPenaltyDb,proto3" json:"er_penalty_db,omitempty"`
+	RinPenaltyDb        float64 `protobuf:"fixed64,29,opt,name=rin_penalty_db,json=rinPenaltyDb,proto3" json:"rin_penalty_db,omitempty"`
+	MpnPenaltyDb        float64 `protobuf:"fixed64,30,opt,name=mpn_penalty_db,json=mpnPenaltyDb,proto3" json:"mpn_penalty_db,omitempty"`
+	ReflectionPenaltyDb float64 `protobuf:"fixed64,31,opt,name=reflection_penalty_db,json=reflectionPenaltyDb,proto3" json:"reflection_penalty_db,omitempty"`
+	ChirpPenaltyDb      float64 `protobuf:"fixed64,32,opt,name=chirp_penalty_db,json=chirpPenaltyDb,proto3" json:"chirp_penalty_db,omitempty"`
+	PenaltyTotalDb      float64 `protobuf:"fixed64,33,opt,name=penalty_total_db,json=penaltyTotalDb,proto3" json:"penalty_total_db,omitempty"`
+	// Terms with no finite value, reported as 0 and left out of the margin;
+	// lpb_status is UNBOUNDED when any is listed
+	UnboundedPenalties []string `protobuf:"bytes,34,rep,name=unbounded_penalties,json=unboundedPenalties,proto3" json:"unbounded_penalties,omitempty"`
+	// Polarization mode dispersion (empty status without a PMD coefficient or fiber type)
+	DgdPs     float64 `protobuf:"fixed64,35,opt,name=dgd_ps,json=dgdPs,proto3" json:"dgd_ps,omitempty"`
+	MaxDgdPs  float64 `protobuf:"fixed64,36,opt,name=max_dgd_ps,json=maxDgdPs,proto3" json:"max_dgd_ps,omitempty"`
+	PmdStatus string  `protobuf:"bytes,37,opt,name=pmd_status,json=pmdStatus,proto3" json:"pmd_status,omitempty"`
+	// WDM links report their worst channel above and every channel here
+	WdmLossDb      float64          `protobuf:"fixed64,38,opt,name=wdm_loss_db,json=wdmLossDb,proto3" json:"wdm_loss_db,omitempty"` // Mux, demux and OADM ports
+	ChannelCount   int32            `protobuf:"varint,39,opt,name=channel_count,json=channelCount,proto3" json:"channel_count,omitempty"`
+	WorstChannel   string           `protobuf:"bytes,40,opt,name=worst_channel,json=worstChannel,proto3" json:"worst_channel,omitempty"`
+	WorstChannelNm float64          `protobuf:"fixed64,41,opt,name=worst_channel_nm,json=worstChannelNm,proto3" json:"worst_channel_nm,omitempty"`
+	Channels       []*ChannelOutput `protobuf:"bytes,42,rep,name=channels,proto3" json:"channels,omitempty"`
+	// Coexistence links report their limiting service above and every service here
+	LimitingService string           `protobuf:"bytes,43,opt,name=limiting_service,json=limitingService,proto3" json:"limiting_service,omitempty"` // e.g. "xgs-pon/up"
+	Services        []*ServiceOutput `protobuf:"bytes,44,rep,name=services,proto3" json:"services,omitempty"`
+	// Optical return loss (empty status without reflectance inputs)
+	OrlDb                float64 `protobuf:"fixed64,45,opt,name=orl_db,json=orlDb,proto3" json:"orl_db,omitempty"`
+	ReflectionStatus     string  `protobuf:"bytes,46,opt,name=reflection_status,json=reflectionStatus,proto3" json:"reflection_status,omitempty"` // PASS, FAIL, or N/A without a tolerance
+	WorstReflectionEvent string  `protobuf:"bytes,47,opt,name=worst_reflection_event,json=worstReflectionEvent,proto3" json:"worst_reflection_event,omitempty"`
+	WorstReflectanceDb   float64 `protobuf:"fixed64,48,opt,name=worst_reflectance_db,json=worstReflectanceDb,proto3" json:"worst_reflectance_db,omitempty"`
+	// Margin allowances, reserved next to the system margin
+	RepairAllowanceDb      float64 `protobuf:"fixed64,49,opt,name=repair_allowance_db,json=repairAllowanceDb,proto3" json:"repair_allowance_db,omitempty"`
+	TemperatureAllowanceDb float64 `protobuf:"fixed64,50,opt,name=temperature_allowance_db,json=temperatureAllowanceDb,proto3" json:"temperature_allowance_db,omitempty"`
+	AgingAllowanceDb       float64 `protobuf:"fixed64,51,opt,name=aging_allowance_db,json=agingAllowanceDb,proto3" json:"aging_allowance_db,omitempty"`
+	CableCutAllowanceDb    float64 `protobuf:"fixed64,52,opt,name=cable_cut_allowance_db,json=cableCutAllowanceDb,proto3" json:"cable_cut_allowance_db,omitempty"`
+	AllowanceTotalDb       float64 `protobuf:"fixed64,53,opt,name=allowance_total_db,json=allowanceTotalDb,proto3" json:"allowance_total_db,omitempty"`
+	// Nonlinear thresholds (empty status without a source linewidth)
+	EffectiveLengthKm float64 `protobuf:"fixed64,54,opt,name=effective_length_km,json=effectiveLengthKm,proto3" json:"effective_length_km,omitempty"`
+	SbsThresholdDbm   float64 `protobuf:"fixed64,55,opt,name=sbs_threshold_dbm,json=sbsThresholdDbm,proto3" json:"sbs_threshold_dbm,omitempty"`
+	SpmThresholdDbm   float64 `protobuf:"fixed64,56,opt,name=spm_threshold_dbm,json=spmThresholdDbm,proto3" json:"spm_threshold_dbm,omitempty"`
+	NonlinearPhaseRad float64 `protobuf:"fixed64,57,opt,name=nonlinear_phase_rad,json=nonlinearPhaseRad,proto3" json:"nonlinear_phase_rad,omitempty"`
+	SbsPenaltyDb      float64 `protobuf:"fixed64,58,opt,name=sbs_penalty_db,json=sbsPenaltyDb,proto3" json:"sbs_penalty_db,omitempty"`
+	SpmPenaltyDb      float64 `protobuf:"fixed64,59,opt,name=spm_penalty_db,json=spmPenaltyDb,proto3" json:"spm_penalty_db,omitempty"`
+	NonlinearStatus   string  `protobuf:"bytes,60,opt,name=nonlinear_status,json=nonlinearStatus,proto3" json:"nonlinear_status,omitempty"`
+	// Multimode Ethernet PHY support (empty status without a phy)
+	ModalRiseTimeNs     float64 `protobuf:"fixed64,61,opt,name=modal_rise_time_ns,json=modalRiseTimeNs,proto3" json:"modal_rise_time_ns,omitempty"`
+	PhyReachM           float64 `protobuf:"fixed64,62,opt,name=phy_reach_m,json=phyReachM,proto3" json:"phy_reach_m,omitempty"`
+	PhyMaxChannelLossDb float64 `protobuf:"fixed64,63,opt,name=phy_max_channel_loss_db,json=phyMaxChannelLossDb,proto3" json:"phy_max_channel_loss_db,omitempty"`
+	PhyLimit            string  `protobuf:"bytes,64,opt,name=phy_limit,json=phyLimit,proto3" json:"phy_limit,omitempty"` // fiber, reach, channel_loss
+	PhyStatus           string  `protobuf:"bytes,65,opt,name=phy_status,json=phyStatus,proto3" json:"phy_status,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *LinkOutput) Reset() {
+	*x = LinkOutput{}
+	mi := &file_fo_v1_engine_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkOutput) ProtoMessage() {}
+
+func (x *LinkOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkOutput.ProtoReflect.Descriptor instead.
+func (*LinkOutput) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LinkOutput) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetScenario() string {
+	if x != nil {
+		return x.Scenario
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetFiberLossDb() float64 {
+	if x != nil {
+		return x.FiberLossDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetSpliceTotalDb() float64 {
+	if x != nil {
+		return x.SpliceTotalDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetConnectorTotalDb() float64 {
+	if x != nil {
+		return x.ConnectorTotalDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetTotalLossDb() float64 {
+	if x != nil {
+		return x.TotalLossDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetRxPowerDbm() float64 {
+	if x != nil {
+		return x.RxPowerDbm
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetMarginDb() float64 {
+	if x != nil {
+		return x.MarginDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetLpbStatus() string {
+	if x != nil {
+		return x.LpbStatus
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetSystemRiseTimeNs() float64 {
+	if x != nil {
+		return x.SystemRiseTimeNs
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetAllowedRiseTimeNs() float64 {
+	if x != nil {
+		return x.AllowedRiseTimeNs
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetRtbPass() bool {
+	if x != nil {
+		return x.RtbPass
+	}
+	return false
+}
+
+func (x *LinkOutput) GetTopContributor_1() string {
+	if x != nil {
+		return x.TopContributor_1
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetTopContributor_2() string {
+	if x != nil {
+		return x.TopContributor_2
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetTopContributor_3() string {
+	if x != nil {
+		return x.TopContributor_3
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetComplianceProfile() string {
+	if x != nil {
+		return x.ComplianceProfile
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetComplianceStatus() string {
+	if x != nil {
+		return x.ComplianceStatus
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetComplianceClause() string {
+	if x != nil {
+		return x.ComplianceClause
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetAmplifierGainDb() float64 {
+	if x != nil {
+		return x.AmplifierGainDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetOsnrDb() float64 {
+	if x != nil {
+		return x.OsnrDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetOsnrStatus() string {
+	if x != nil {
+		return x.OsnrStatus
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetQFactor() float64 {
+	if x != nil {
+		return x.QFactor
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetBer() float64 {
+	if x != nil {
+		return x.Ber
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetBerStatus() string {
+	if x != nil {
+		return x.BerStatus
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetPostFecBer() float64 {
+	if x != nil {
+		return x.PostFecBer
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetPostFecStatus() string {
+	if x != nil {
+		return x.PostFecStatus
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetDispersionPenaltyDb() float64 {
+	if x != nil {
+		return x.DispersionPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetErPenaltyDb() float64 {
+	if x != nil {
+		return x.ErPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetRinPenaltyDb() float64 {
+	if x != nil {
+		return x.RinPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetMpnPenaltyDb() float64 {
+	if x != nil {
+		return x.MpnPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetReflectionPenaltyDb() float64 {
+	if x != nil {
+		return x.ReflectionPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetChirpPenaltyDb() float64 {
+	if x != nil {
+		return x.ChirpPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetPenaltyTotalDb() float64 {
+	if x != nil {
+		return x.PenaltyTotalDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetUnboundedPenalties() []string {
+	if x != nil {
+		return x.UnboundedPenalties
+	}
+	return nil
+}
+
+func (x *LinkOutput) GetDgdPs() float64 {
+	if x != nil {
+		return x.DgdPs
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetMaxDgdPs() float64 {
+	if x != nil {
+		return x.MaxDgdPs
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetPmdStatus() string {
+	if x != nil {
+		return x.PmdStatus
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetWdmLossDb() float64 {
+	if x != nil {
+		return x.WdmLossDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetChannelCount() int32 {
+	if x != nil {
+		return x.ChannelCount
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetWorstChannel() string {
+	if x != nil {
+		return x.WorstChannel
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetWorstChannelNm() float64 {
+	if x != nil {
+		return x.WorstChannelNm
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetChannels() []*ChannelOutput {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *LinkOutput) GetLimitingService() string {
+	if x != nil {
+		return x.LimitingService
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetServices() []*ServiceOutput {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *LinkOutput) GetOrlDb() float64 {
+	if x != nil {
+		return x.OrlDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetReflectionStatus() string {
+	if x != nil {
+		return x.ReflectionStatus
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetWorstReflectionEvent() string {
+	if x != nil {
+		return x.WorstReflectionEvent
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetWorstReflectanceDb() float64 {
+	if x != nil {
+		return x.WorstReflectanceDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetRepairAllowanceDb() float64 {
+	if x != nil {
+		return x.RepairAllowanceDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetTemperatureAllowanceDb() float64 {
+	if x != nil {
+		return x.TemperatureAllowanceDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetAgingAllowanceDb() float64 {
+	if x != nil {
+		return x.AgingAllowanceDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetCableCutAllowanceDb() float64 {
+	if x != nil {
+		return x.CableCutAllowanceDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetAllowanceTotalDb() float64 {
+	if x != nil {
+		return x.AllowanceTotalDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetEffectiveLengthKm() float64 {
+	if x != nil {
+		return x.EffectiveLengthKm
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetSbsThresholdDbm() float64 {
+	if x != nil {
+		return x.SbsThresholdDbm
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetSpmThresholdDbm() float64 {
+	if x != nil {
+		return x.SpmThresholdDbm
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetNonlinearPhaseRad() float64 {
+	if x != nil {
+		return x.NonlinearPhaseRad
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetSbsPenaltyDb() float64 {
+	if x != nil {
+		return x.SbsPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetSpmPenaltyDb() float64 {
+	if x != nil {
+		return x.SpmPenaltyDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetNonlinearStatus() string {
+	if x != nil {
+		return x.NonlinearStatus
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetModalRiseTimeNs() float64 {
+	if x != nil {
+		return x.ModalRiseTimeNs
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetPhyReachM() float64 {
+	if x != nil {
+		return x.PhyReachM
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetPhyMaxChannelLossDb() float64 {
+	if x != nil {
+		return x.PhyMaxChannelLossDb
+	}
+	return 0
+}
+
+func (x *LinkOutput) GetPhyLimit() string {
+	if x != nil {
+		return x.PhyLimit
+	}
+	return ""
+}
+
+func (x *LinkOutput) GetPhyStatus() string {
+	if x != nil {
+		return x.PhyStatus
+	}
+	return ""
+}
+
+// Calculation runner options. Fields left unset keep the server defaults.
+type RunnerOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	EnableRtb         *bool                  `protobuf:"varint,1,opt,name=enable_rtb,json=enableRtb,proto3,oneof" json:"enable_rtb,omitempty"`
+	BitrateGbps       *float64               `protobuf:"fixed64,2,opt,name=bitrate_gbps,json=bitrateGbps,proto3,oneof" json:"bitrate_gbps,omitempty"`
+	TxRiseTimeNs      *float64               `protobuf:"fixed64,3,opt,name=tx_rise_time_ns,json=txRiseTimeNs,proto3,oneof" json:"tx_rise_time_ns,omitempty"`
+	RxRiseTimeNs      *float64               `protobuf:"fixed64,4,opt,name=rx_rise_time_ns,json=rxRiseTimeNs,proto3,oneof" json:"rx_rise_time_ns,omitempty"`
+	DispersionNsPerKm *float64               `protobuf:"fixed64,5,opt,name=dispersion_ns_per_km,json=dispersionNsPerKm,proto3,oneof" json:"dispersion_ns_per_km,omitempty"`
+	// BER defaults for links that leave fec or target_ber empty
+	Fec       *string  `protobuf:"bytes,6,opt,name=fec,proto3,oneof" json:"fec,omitempty"`
+	TargetBer *float64 `protobuf:"fixed64,7,opt,name=target_ber,json=targetBer,proto3,oneof" json:"target_ber,omitempty"`
+	// PMD limit as a share of the bit period (0 uses 0.1)
+	PmdBitFraction *float64 `protobuf:"fixed64,8,opt,name=pmd_bit_fraction,json=pmdBitFraction,proto3,oneof" json:"pmd_bit_fraction,omitempty"`
+	// Cable types added to or replacing the server's, by name
+	Cables        map[string]*CableAllowances `protobuf:"bytes,9,rep,name=cables,proto3" json:"cables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunnerOptions) Reset() {
+	*x = RunnerOptions{}
+	mi := &file_fo_v1_engine_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunnerOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunnerOptions) ProtoMessage() {}
+
+func (x *RunnerOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunnerOptions.ProtoReflect.Descriptor instead.
+func (*RunnerOptions) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RunnerOptions) GetEnableRtb() bool {
+	if x != nil && x.EnableRtb != nil {
+		return *x.EnableRtb
+	}
+	return false
+}
+
+func (x *RunnerOptions) GetBitrateGbps() float64 {
+	if x != nil && x.BitrateGbps != nil {
+		return *x.BitrateGbps
+	}
+	return 0
+}
+
+func (x *RunnerOptions) GetTxRiseTimeNs() float64 {
+	if x != nil && x.TxRiseTimeNs != nil {
+		return *x.TxRiseTimeNs
+	}
+	return 0
+}
+
+func (x *RunnerOptions) GetRxRiseTimeNs() float64 {
+	if x != nil && x.RxRiseTimeNs != nil {
+		return *x.RxRiseTimeNs
+	}
+	return 0
+}
+
+func (x *RunnerOptions) GetDispersionNsPerKm() float64 {
+	if x != nil && x.DispersionNsPerKm != nil {
+		return *x.DispersionNsPerKm
+	}
+	return 0
+}
+
+func (x *RunnerOptions) GetFec() string {
+	if x != nil && x.Fec != nil {
+		return *x.Fec
+	}
+	return ""
+}
+
+func (x *RunnerOptions) GetTargetBer() float64 {
+	if x != nil && x.TargetBer != nil {
+		return *x.TargetBer
+	}
+	return 0
+}
+
+func (x *RunnerOptions) GetPmdBitFraction() float64 {
+	if x != nil && x.PmdBitFraction != nil {
+		return *x.PmdBitFraction
+	}
+	return 0
+}
+
+func (x *RunnerOptions) GetCables() map[string]*CableAllowances {
+	if x != nil {
+		return x.Cables
+	}
+	return nil
+}
+
+// Margin allowances of a cable type
+type CableAllowances struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	RepairSplicesPerKm float64                `protobuf:"fixed64,1,opt,name=repair_splices_per_km,json=repairSplicesPerKm,proto3" json:"repair_splices_per_km,omitempty"` // Expected repair splices over the design life
+	TempDriftDbPerKm   float64                `protobuf:"fixed64,2,opt,name=temp_drift_db_per_km,json=tempDriftDbPerKm,proto3" json:"temp_drift_db_per_km,omitempty"`     // Attenuation increase at the temperature extremes
+	AgingDb            float64                `protobuf:"fixed64,3,opt,name=aging_db,json=agingDb,proto3" json:"aging_db,omitempty"`                                      // Transmitter and component aging
+	CableCutDb         float64                `protobuf:"fixed64,4,opt,name=cable_cut_db,json=cableCutDb,proto3" json:"cable_cut_db,omitempty"`                           // Emergency restoration of a cut
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CableAllowances) Reset() {
+	*x = CableAllowances{}
+	mi := &file_fo_v1_engine_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CableAllowances) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CableAllowances) ProtoMessage() {}
+
+func (x *CableAllowances) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CableAllowances.ProtoReflect.Descriptor instead.
+func (*CableAllowances) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CableAllowances) GetRepairSplicesPerKm() float64 {
+	if x != nil {
+		return x.RepairSplicesPerKm
+	}
+	return 0
+}
+
+func (x *CableAllowances) GetTempDriftDbPerKm() float64 {
+	if x != nil {
+		return x.TempDriftDbPerKm
+	}
+	return 0
+}
+
+func (x *CableAllowances) GetAgingDb() float64 {
+	if x != nil {
+		return x.AgingDb
+	}
+	return 0
+}
+
+func (x *CableAllowances) GetCableCutDb() float64 {
+	if x != nil {
+		return x.CableCutDb
+	}
+	return 0
+}
+
+// Sweep variation of one field
+type Variation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Values        []float64              `protobuf:"fixed64,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Variation) Reset() {
+	*x = Variation{}
+	mi := &file_fo_v1_engine_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Variation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variation) ProtoMessage() {}
+
+func (x *Variation) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variation.ProtoReflect.Descriptor instead.
+func (*Variation) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Variation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Variation) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Row-level validation or calculation error
+type RowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Field         string                 `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Severity      string                 `protobuf:"bytes,6,opt,name=severity,proto3" json:"severity,omitempty"`
+	Refs          []int32                `protobuf:"varint,7,rep,packed,name=refs,proto3" json:"refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RowError) Reset() {
+	*x = RowError{}
+	mi := &file_fo_v1_engine_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RowError) ProtoMessage() {}
+
+func (x *RowError) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RowError.ProtoReflect.Descriptor instead.
+func (*RowError) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RowError) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *RowError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *RowError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *RowError) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RowError) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *RowError) GetRefs() []int32 {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+type ComputeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *LinkInput             `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	Options       *RunnerOptions         `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComputeRequest) Reset() {
+	*x = ComputeRequest{}
+	mi := &file_fo_v1_engine_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComputeRequest) ProtoMessage() {}
+
+func (x *ComputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComputeRequest.ProtoReflect.Descriptor instead.
+func (*ComputeRequest) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ComputeRequest) GetLink() *LinkInput {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+func (x *ComputeRequest) GetOptions() *RunnerOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ComputeResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Result *LinkOutput            `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// Validation findings; when any is blocking, result is unset
+	Errors        []*RowError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComputeResponse) Reset() {
+	*x = ComputeResponse{}
+	mi := &file_fo_v1_engine_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComputeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComputeResponse) ProtoMessage() {}
+
+func (x *ComputeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComputeResponse.ProtoReflect.Descriptor instead.
+func (*ComputeResponse) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ComputeResponse) GetResult() *LinkOutput {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ComputeResponse) GetErrors() []*RowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type SweepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*LinkInput           `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	Variations    []*Variation           `protobuf:"bytes,2,rep,name=variations,proto3" json:"variations,omitempty"`
+	Options       *RunnerOptions         `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SweepRequest) Reset() {
+	*x = SweepRequest{}
+	mi := &file_fo_v1_engine_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SweepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SweepRequest) ProtoMessage() {}
+
+func (x *SweepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SweepRequest.ProtoReflect.Descriptor instead.
+func (*SweepRequest) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SweepRequest) GetLinks() []*LinkInput {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *SweepRequest) GetVariations() []*Variation {
+	if x != nil {
+		return x.Variations
+	}
+	return nil
+}
+
+func (x *SweepRequest) GetOptions() *RunnerOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Bulk run stream message: send options first (optional), then links
+type RunRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*RunRequest_Options
+	//	*RunRequest_Link
+	Payload       isRunRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunRequest) Reset() {
+	*x = RunRequest{}
+	mi := &file_fo_v1_engine_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunRequest) ProtoMessage() {}
+
+func (x *RunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunRequest.ProtoReflect.Descriptor instead.
+func (*RunRequest) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RunRequest) GetPayload() isRunRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *RunRequest) GetOptions() *RunnerOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*RunRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *RunRequest) GetLink() *LinkInput {
+	if x != nil {
+		if x, ok := x.Payload.(*RunRequest_Link); ok {
+			return x.Link
+		}
+	}
+	return nil
+}
+
+type isRunRequest_Payload interface {
+	isRunRequest_Payload()
+}
+
+type RunRequest_Options struct {
+	Options *RunnerOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type RunRequest_Link struct {
+	Link *LinkInput `protobuf:"bytes,2,opt,name=link,proto3,oneof"`
+}
+
+func (*RunRequest_Options) isRunRequest_Payload() {}
+
+func (*RunRequest_Link) isRunRequest_Payload() {}
+
+// Bulk run reply: one per link, either a result or its errors
+type RunResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Result        *LinkOutput            `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Errors        []*RowError            `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunResponse) Reset() {
+	*x = RunResponse{}
+	mi := &file_fo_v1_engine_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunResponse) ProtoMessage() {}
+
+func (x *RunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fo_v1_engine_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunResponse.ProtoReflect.Descriptor instead.
+func (*RunResponse) Descriptor() ([]byte, []int) {
+	return file_fo_v1_engine_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RunResponse) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *RunResponse) GetResult() *LinkOutput {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *RunResponse) GetErrors() []*RowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+var File_fo_v1_engine_proto protoreflect.FileDescriptor
+
+const file_fo_v1_engine_proto_rawDesc = "" +
+	"\n" +
+	"\x12fo/v1/engine.proto\x12\x05fo.v1\"\xcb\x10\n" +
+	"\tLinkInput\x12\x17\n" +
+	"\alink_id\x18\x01 \x01(\tR\x06linkId\x12\x1a\n" +
+	"\bscenario\x18\x02 \x01(\tR\bscenario\x12 \n" +
+	"\ftx_power_dbm\x18\x03 \x01(\x01R\n" +
+	"txPowerDbm\x12,\n" +
+	"\x12rx_sensitivity_dbm\x18\x04 \x01(\x01R\x10rxSensitivityDbm\x12(\n" +
+	"\x10system_margin_db\x18\x05 \x01(\x01R\x0esystemMarginDb\x12&\n" +
+	"\x0ffiber_length_km\x18\x06 \x01(\x01R\rfiberLengthKm\x12,\n" +
+	"\x13fiber_att_db_per_km\x18\a \x01(\x01R\x0ffiberAttDbPerKm\x12\x19\n" +
+	"\bn_splice\x18\b \x01(\x05R\anSplice\x12$\n" +
+	"\x0esplice_loss_db\x18\t \x01(\x01R\fspliceLossDb\x12\x1f\n" +
+	"\vn_connector\x18\n" +
+	" \x01(\x05R\n" +
+	"nConnector\x12*\n" +
+	"\x11connector_loss_db\x18\v \x01(\x01R\x0fconnectorLossDb\x12(\n" +
+	"\x10splitter_loss_db\x18\f \x01(\x01R\x0esplitterLossDb\x12\"\n" +
+	"\rother_loss_db\x18\r \x01(\x01R\votherLossDb\x12#\n" +
+	"\rwavelength_nm\x18\x0e \x01(\x01R\fwavelengthNm\x12\x1d\n" +
+	"\n" +
+	"fiber_type\x18\x0f \x01(\tR\tfiberType\x12\x1f\n" +
+	"\vsplitter_id\x18\x10 \x01(\tR\n" +
+	"splitterId\x12#\n" +
+	"\rsplitter_port\x18\x11 \x01(\x05R\fsplitterPort\x12\x1f\n" +
+	"\vsplit_ratio\x18\x12 \x01(\x05R\n" +
+	"splitRatio\x120\n" +
+	"\n" +
+	"amplifiers\x18\x13 \x03(\v2\x10.fo.v1.AmplifierR\n" +
+	"amplifiers\x12(\n" +
+	"\x10required_osnr_db\x18\x14 \x01(\x01R\x0erequiredOsnrDb\x12'\n" +
+	"\x0fsensitivity_ber\x18\x15 \x01(\x01R\x0esensitivityBer\x12\x17\n" +
+	"\aq_slope\x18\x16 \x01(\x01R\x06qSlope\x12\x10\n" +
+	"\x03fec\x18\x17 \x01(\tR\x03fec\x12\x1d\n" +
+	"\n" +
+	"target_ber\x18\x18 \x01(\x01R\ttargetBer\x12-\n" +
+	"\x13dispersion_ps_nm_km\x18\x19 \x01(\x01R\x10dispersionPsNmKm\x12*\n" +
+	"\x11spectral_width_nm\x18\x1a \x01(\x01R\x0fspectralWidthNm\x12.\n" +
+	"\x13extinction_ratio_db\x18\x1b \x01(\x01R\x11extinctionRatioDb\x12\x1a\n" +
+	"\trin_db_hz\x18\x1c \x01(\x01R\arinDbHz\x12\x13\n" +
+	"\x05mpn_k\x18\x1d \x01(\x01R\x04mpnK\x122\n" +
+	"\x15dispersion_penalty_db\x18\x1e \x01(\x01R\x13dispersionPenaltyDb\x12\"\n" +
+	"\rer_penalty_db\x18\x1f \x01(\x01R\verPenaltyDb\x12$\n" +
+	"\x0erin_penalty_db\x18  \x01(\x01R\frinPenaltyDb\x12$\n" +
+	"\x0empn_penalty_db\x18! \x01(\x01R\fmpnPenaltyDb\x122\n" +
+	"\x15reflection_penalty_db\x18\" \x01(\x01R\x13reflectionPenaltyDb\x12(\n" +
+	"\x10chirp_penalty_db\x18# \x01(\x01R\x0echirpPenaltyDb\x12#\n" +
+	"\x0epmd_ps_sqrt_km\x18$ \x01(\x01R\vpmdPsSqrtKm\x12!\n" +
+	"\fbitrate_gbps\x18% \x01(\x01R\vbitrateGbps\x12*\n" +
+	"\bchannels\x18& \x03(\v2\x0e.fo.v1.ChannelR\bchannels\x12\x1e\n" +
+	"\vmux_loss_db\x18' \x01(\x01R\tmuxLossDb\x12\"\n" +
+	"\rdemux_loss_db\x18( \x01(\x01R\vdemuxLossDb\x12\x15\n" +
+	"\x06n_oadm\x18) \x01(\x05R\x05nOadm\x12 \n" +
+	"\foadm_loss_db\x18* \x01(\x01R\n" +
+	"oadmLossDb\x12*\n" +
+	"\bservices\x18+ \x03(\v2\x0e.fo.v1.ServiceR\bservices\x12%\n" +
+	"\x0econnector_type\x18, \x01(\tR\rconnectorType\x128\n" +
+	"\x18connector_reflectance_db\x18- \x01(\x01R\x16connectorReflectanceDb\x122\n" +
+	"\x15splice_reflectance_db\x18. \x01(\x01R\x13spliceReflectanceDb\x12(\n" +
+	"\x10orl_tolerance_db\x18/ \x01(\x01R\x0eorlToleranceDb\x12\x1d\n" +
+	"\n" +
+	"cable_type\x180 \x01(\tR\tcableType\x121\n" +
+	"\x15repair_splices_per_km\x181 \x01(\x01R\x12repairSplicesPerKm\x12.\n" +
+	"\x14temp_drift_db_per_km\x182 \x01(\x01R\x10tempDriftDbPerKm\x12&\n" +
+	"\x0faging_margin_db\x183 \x01(\x01R\ragingMarginDb\x12-\n" +
+	"\x13cable_cut_margin_db\x184 \x01(\x01R\x10cableCutMarginDb\x120\n" +
+	"\x14source_linewidth_mhz\x185 \x01(\x01R\x12sourceLinewidthMhz\x12,\n" +
+	"\x12effective_area_um2\x186 \x01(\x01R\x10effectiveAreaUm2\x12\x10\n" +
+	"\x03phy\x187 \x01(\tR\x03phy\"\x9c\x01\n" +
+	"\tAmplifier\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1f\n" +
+	"\vposition_km\x18\x02 \x01(\x01R\n" +
+	"positionKm\x12\x17\n" +
+	"\again_db\x18\x03 \x01(\x01R\x06gainDb\x12&\n" +
+	"\x0fnoise_figure_db\x18\x04 \x01(\x01R\rnoiseFigureDb\x12\x19\n" +
+	"\bpsat_dbm\x18\x05 \x01(\x01R\apsatDbm\"D\n" +
+	"\aChannel\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12#\n" +
+	"\rwavelength_nm\x18\x02 \x01(\x01R\fwavelengthNm\"\xd3\x02\n" +
+	"\rChannelOutput\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12#\n" +
+	"\rwavelength_nm\x18\x02 \x01(\x01R\fwavelengthNm\x12,\n" +
+	"\x13fiber_att_db_per_km\x18\x03 \x01(\x01R\x0ffiberAttDbPerKm\x12-\n" +
+	"\x13dispersion_ps_nm_km\x18\x04 \x01(\x01R\x10dispersionPsNmKm\x12\"\n" +
+	"\rtotal_loss_db\x18\x05 \x01(\x01R\vtotalLossDb\x12(\n" +
+	"\x10penalty_total_db\x18\x06 \x01(\x01R\x0epenaltyTotalDb\x12 \n" +
+	"\frx_power_dbm\x18\a \x01(\x01R\n" +
+	"rxPowerDbm\x12\x1b\n" +
+	"\tmargin_db\x18\b \x01(\x01R\bmarginDb\x12\x1d\n" +
+	"\n" +
+	"lpb_status\x18\t \x01(\tR\tlpbStatus\"\x8c\x01\n" +
+	"\aService\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x122\n" +
+	"\x06params\x18\x02 \x03(\v2\x1a.fo.v1.Service.ParamsEntryR\x06params\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xe7\x02\n" +
+	"\rServiceOutput\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12#\n" +
+	"\rwavelength_nm\x18\x03 \x01(\x01R\fwavelengthNm\x12$\n" +
+	"\x0efilter_loss_db\x18\x04 \x01(\x01R\ffilterLossDb\x12 \n" +
+	"\ftx_power_dbm\x18\x05 \x01(\x01R\n" +
+	"txPowerDbm\x12,\n" +
+	"\x12rx_sensitivity_dbm\x18\x06 \x01(\x01R\x10rxSensitivityDbm\x12 \n" +
+	"\frx_power_dbm\x18\a \x01(\x01R\n" +
+	"rxPowerDbm\x12\x1b\n" +
+	"\tmargin_db\x18\b \x01(\x01R\bmarginDb\x12,\n" +
+	"\x12overload_margin_db\x18\t \x01(\x01R\x10overloadMarginDb\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\"\xaa\x14\n" +
+	"\n" +
+	"LinkOutput\x12\x17\n" +
+	"\alink_id\x18\x01 \x01(\tR\x06linkId\x12\x1a\n" +
+	"\bscenario\x18\x02 \x01(\tR\bscenario\x12\"\n" +
+	"\rfiber_loss_db\x18\x03 \x01(\x01R\vfiberLossDb\x12&\n" +
+	"\x0fsplice_total_db\x18\x04 \x01(\x01R\rspliceTotalDb\x12,\n" +
+	"\x12connector_total_db\x18\x05 \x01(\x01R\x10connectorTotalDb\x12\"\n" +
+	"\rtotal_loss_db\x18\x06 \x01(\x01R\vtotalLossDb\x12 \n" +
+	"\frx_power_dbm\x18\a \x01(\x01R\n" +
+	"rxPowerDbm\x12\x1b\n" +
+	"\tmargin_db\x18\b \x01(\x01R\bmarginDb\x12\x1d\n" +
+	"\n" +
+	"lpb_status\x18\t \x01(\tR\tlpbStatus\x12-\n" +
+	"\x13system_rise_time_ns\x18\n" +
+	" \x01(\x01R\x10systemRiseTimeNs\x12/\n" +
+	"\x14allowed_rise_time_ns\x18\v \x01(\x01R\x11allowedRiseTimeNs\x12\x19\n" +
+	"\brtb_pass\x18\f \x01(\bR\artbPass\x12*\n" +
+	"\x11top_contributor_1\x18\r \x01(\tR\x0ftopContributor1\x12*\n" +
+	"\x11top_contributor_2\x18\x0e \x01(\tR\x0ftopContributor2\x12*\n" +
+	"\x11top_contributor_3\x18\x0f \x01(\tR\x0ftopContributor3\x12-\n" +
+	"\x12compliance_profile\x18\x10 \x01(\tR\x11complianceProfile\x12+\n" +
+	"\x11compliance_status\x18\x11 \x01(\tR\x10complianceStatus\x12+\n" +
+	"\x11compliance_clause\x18\x12 \x01(\tR\x10complianceClause\x12*\n" +
+	"\x11amplifier_gain_db\x18\x13 \x01(\x01R\x0famplifierGainDb\x12\x17\n" +
+	"\aosnr_db\x18\x14 \x01(\x01R\x06osnrDb\x12\x1f\n" +
+	"\vosnr_status\x18\x15 \x01(\tR\n" +
+	"osnrStatus\x12\x19\n" +
+	"\bq_factor\x18\x16 \x01(\x01R\aqFactor\x12\x10\n" +
+	"\x03ber\x18\x17 \x01(\x01R\x03ber\x12\x1d\n" +
+	"\n" +
+	"ber_status\x18\x18 \x01(\tR\tberStatus\x12 \n" +
+	"\fpost_fec_ber\x18\x19 \x01(\x01R\n" +
+	"postFecBer\x12&\n" +
+	"\x0fpost_fec_status\x18\x1a \x01(\tR\rpostFecStatus\x122\n" +
+	"\x15dispersion_penalty_db\x18\x1b \x01(\x01R\x13dispersionPenaltyDb\x12\"\n" +
+	"\rer_penalty_db\x18\x1c \x01(\x01R\verPenaltyDb\x12$\n" +
+	"\x0erin_penalty_db\x18\x1d \x01(\x01R\frinPenaltyDb\x12$\n" +
+	"\x0empn_penalty_db\x18\x1e \x01(\x01R\fmpnPenaltyDb\x122\n" +
+	"\x15reflection_penalty_db\x18\x1f \x01(\x01R\x13reflectionPenaltyDb\x12(\n" +
+	"\x10chirp_penalty_db\x18  \x01(\x01R\x0echirpPenaltyDb\x12(\n" +
+	"\x10penalty_total_db\x18! \x01(\x01R\x0epenaltyTotalDb\x12/\n" +
+	"\x13unbounded_penalties\x18\" \x03(\tR\x12unboundedPenalties\x12\x15\n" +
+	"\x06dgd_ps\x18# \x01(\x01R\x05dgdPs\x12\x1c\n" +
+	"\n" +
+	"max_dgd_ps\x18$ \x01(\x01R\bmaxDgdPs\x12\x1d\n" +
+	"\n" +
+	"pmd_status\x18% \x01(\tR\tpmdStatus\x12\x1e\n" +
+	"\vwdm_loss_db\x18& \x01(\x01R\twdmLossDb\x12#\n" +
+	"\rchannel_count\x18' \x01(\x05R\fchannelCount\x12#\n" +
+	"\rworst_channel\x18( \x01(\tR\fworstChannel\x12(\n" +
+	"\x10worst_channel_nm\x18) \x01(\x01R\x0eworstChannelNm\x120\n" +
+	"\bchannels\x18* \x03(\v2\x14.fo.v1.ChannelOutputR\bchannels\x12)\n" +
+	"\x10limiting_service\x18+ \x01(\tR\x0flimitingService\x120\n" +
+	"\bservices\x18, \x03(\v2\x14.fo.v1.ServiceOutputR\bservices\x12\x15\n" +
+	"\x06orl_db\x18- \x01(\x01R\x05orlDb\x12+\n" +
+	"\x11reflection_status\x18. \x01(\tR\x10reflectionStatus\x124\n" +
+	"\x16worst_reflection_event\x18/ \x01(\tR\x14worstReflectionEvent\x120\n" +
+	"\x14worst_reflectance_db\x180 \x01(\x01R\x12worstReflectanceDb\x12.\n" +
+	"\x13repair_allowance_db\x181 \x01(\x01R\x11repairAllowanceDb\x128\n" +
+	"\x18temperature_allowance_db\x182 \x01(\x01R\x16temperatureAllowanceDb\x12,\n" +
+	"\x12aging_allowance_db\x183 \x01(\x01R\x10agingAllowanceDb\x123\n" +
+	"\x16cable_cut_allowance_db\x184 \x01(\x01R\x13cableCutAllowanceDb\x12,\n" +
+	"\x12allowance_total_db\x185 \x01(\x01R\x10allowanceTotalDb\x12.\n" +
+	"\x13effective_length_km\x186 \x01(\x01R\x11effectiveLengthKm\x12*\n" +
+	"\x11sbs_threshold_dbm\x187 \x01(\x01R\x0fsbsThresholdDbm\x12*\n" +
+	"\x11spm_threshold_dbm\x188 \x01(\x01R\x0fspmThresholdDbm\x12.\n" +
+	"\x13nonlinear_phase_rad\x189 \x01(\x01R\x11nonlinearPhaseRad\x12$\n" +
+	"\x0esbs_penalty_db\x18: \x01(\x01R\fsbsPenaltyDb\x12$\n" +
+	"\x0espm_penalty_db\x18; \x01(\x01R\fspmPenaltyDb\x12)\n" +
+	"\x10nonlinear_status\x18< \x01(\tR\x0fnonlinearStatus\x12+\n" +
+	"\x12modal_rise_time_ns\x18= \x01(\x01R\x0fmodalRiseTimeNs\x12\x1e\n" +
+	"\vphy_reach_m\x18> \x01(\x01R\tphyReachM\x124\n" +
+	"\x17phy_max_channel_loss_db\x18? \x01(\x01R\x13phyMaxChannelLossDb\x12\x1b\n" +
+	"\tphy_limit\x18@ \x01(\tR\bphyLimit\x12\x1d\n" +
+	"\n" +
+	"phy_status\x18A \x01(\tR\tphyStatus\"\xed\x04\n" +
+	"\rRunnerOptions\x12\"\n" +
+	"\n" +
+	"enable_rtb\x18\x01 \x01(\bH\x00R\tenableRtb\x88\x01\x01\x12&\n" +
+	"\fbitrate_gbps\x18\x02 \x01(\x01H\x01R\vbitrateGbps\x88\x01\x01\x12*\n" +
+	"\x0ftx_rise_time_ns\x18\x03 \x01(\x01H\x02R\ftxRiseTimeNs\x88\x01\x01\x12*\n" +
+	"\x0frx_rise_time_ns\x18\x04 \x01(\x01H\x03R\frxRiseTimeNs\x88\x01\x01\x124\n" +
+	"\x14dispersion_ns_per_km\x18\x05 \x01(\x01H\x04R\x11dispersionNsPerKm\x88\x01\x01\x12\x15\n" +
+	"\x03fec\x18\x06 \x01(\tH\x05R\x03fec\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"target_ber\x18\a \x01(\x01H\x06R\ttargetBer\x88\x01\x01\x12-\n" +
+	"\x10pmd_bit_fraction\x18\b \x01(\x01H\aR\x0epmdBitFraction\x88\x01\x01\x128\n" +
+	"\x06cables\x18\t \x03(\v2 .fo.v1.RunnerOptions.CablesEntryR\x06cables\x1aQ\n" +
+	"\vCablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.fo.v1.CableAllowancesR\x05value:\x028\x01B\r\n" +
+	"\v_enable_rtbB\x0f\n" +
+	"\r_bitrate_gbpsB\x12\n" +
+	"\x10_tx_rise_time_nsB\x12\n" +
+	"\x10_rx_rise_time_nsB\x17\n" +
+	"\x15_dispersion_ns_per_kmB\x06\n" +
+	"\x04_fecB\r\n" +
+	"\v_target_berB\x13\n" +
+	"\x11_pmd_bit_fraction\"\xb1\x01\n" +
+	"\x0fCableAllowances\x121\n" +
+	"\x15repair_splices_per_km\x18\x01 \x01(\x01R\x12repairSplicesPerKm\x12.\n" +
+	"\x14temp_drift_db_per_km\x18\x02 \x01(\x01R\x10tempDriftDbPerKm\x12\x19\n" +
+	"\baging_db\x18\x03 \x01(\x01R\aagingDb\x12 \n" +
+	"\fcable_cut_db\x18\x04 \x01(\x01R\n" +
+	"cableCutDb\"9\n" +
+	"\tVariation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\x01R\x06values\"\xa6\x01\n" +
+	"\bRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x14\n" +
+	"\x05field\x18\x03 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1a\n" +
+	"\bseverity\x18\x06 \x01(\tR\bseverity\x12\x12\n" +
+	"\x04refs\x18\a \x03(\x05R\x04refs\"f\n" +
+	"\x0eComputeRequest\x12$\n" +
+	"\x04link\x18\x01 \x01(\v2\x10.fo.v1.LinkInputR\x04link\x12.\n" +
+	"\aoptions\x18\x02 \x01(\v2\x14.fo.v1.RunnerOptionsR\aoptions\"e\n" +
+	"\x0fComputeResponse\x12)\n" +
+	"\x06result\x18\x01 \x01(\v2\x11.fo.v1.LinkOutputR\x06result\x12'\n" +
+	"\x06errors\x18\x02 \x03(\v2\x0f.fo.v1.RowErrorR\x06errors\"\x98\x01\n" +
+	"\fSweepRequest\x12&\n" +
+	"\x05links\x18\x01 \x03(\v2\x10.fo.v1.LinkInputR\x05links\x120\n" +
+	"\n" +
+	"variations\x18\x02 \x03(\v2\x10.fo.v1.VariationR\n" +
+	"variations\x12.\n" +
+	"\aoptions\x18\x03 \x01(\v2\x14.fo.v1.RunnerOptionsR\aoptions\"q\n" +
+	"\n" +
+	"RunRequest\x120\n" +
+	"\aoptions\x18\x01 \x01(\v2\x14.fo.v1.RunnerOptionsH\x00R\aoptions\x12&\n" +
+	"\x04link\x18\x02 \x01(\v2\x10.fo.v1.LinkInputH\x00R\x04linkB\t\n" +
+	"\apayload\"s\n" +
+	"\vRunResponse\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12)\n" +
+	"\x06result\x18\x02 \x01(\v2\x11.fo.v1.LinkOutputR\x06result\x12'\n" +
+	"\x06errors\x18\x03 \x03(\v2\x0f.fo.v1.RowErrorR\x06errors2\xa7\x01\n" +
+	"\x06Engine\x128\n" +
+	"\aCompute\x12\x15.fo.v1.ComputeRequest\x1a\x16.fo.v1.ComputeResponse\x121\n" +
+	"\x05Sweep\x12\x13.fo.v1.SweepRequest\x1a\x11.fo.v1.LinkOutput0\x01\x120\n" +
+	"\x03Run\x12\x11.fo.v1.RunRequest\x1a\x12.fo.v1.RunResponse(\x010\x01BHZFgithub.com/fadeldnswr/fo-performance-engine.git/internal/rpc/fov1;fov1b\x06proto3"
+
+var (
+	file_fo_v1_engine_proto_rawDescOnce sync.Once
+	file_fo_v1_engine_proto_rawDescData []byte
+)
+
+func file_fo_v1_engine_proto_rawDescGZIP() []byte {
+	file_fo_v1_engine_proto_rawDescOnce.Do(func() {
+		file_fo_v1_engine_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fo_v1_engine_proto_rawDesc), len(file_fo_v1_engine_proto_rawDesc)))
+	})
+	return file_fo_v1_engine_proto_rawDescData
+}
+
+var file_fo_v1_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_fo_v1_engine_proto_goTypes = []any{
+	(*LinkInput)(nil),       // 0: fo.v1.LinkInput
+	(*Amplifier)(nil),       // 1: fo.v1.Amplifier
+	(*Channel)(nil),         // 2: fo.v1.Channel
+	(*ChannelOutput)(nil),   // 3: fo.v1.ChannelOutput
+	(*Service)(nil),         // 4: fo.v1.Service
+	(*ServiceOutput)(nil),   // 5: fo.v1.ServiceOutput
+	(*LinkOutput)(nil),      // 6: fo.v1.LinkOutput
+	(*RunnerOptions)(nil),   // 7: fo.v1.RunnerOptions
+	(*CableAllowances)(nil), // 8: fo.v1.CableAllowances
+	(*Variation)(nil),       // 9: fo.v1.Variation
+	(*RowError)(nil),        // 10: fo.v1.RowError
+	(*ComputeRequest)(nil),  // 11: fo.v1.ComputeRequest
+	(*ComputeResponse)(nil), // 12: fo.v1.ComputeResponse
+	(*SweepRequest)(nil),    // 13: fo.v1.SweepRequest
+	(*RunRequest)(nil),      // 14: fo.v1.RunRequest
+	(*RunResponse)(nil),     // 15: fo.v1.RunResponse
+	nil,                     // 16: fo.v1.Service.ParamsEntry
+	nil,                     // 17: fo.v1.RunnerOptions.CablesEntry
+}
+var file_fo_v1_engine_proto_depIdxs = []int32{
+	1,  // 0: fo.v1.LinkInput.amplifiers:type_name -> fo.v1.Amplifier
+	2,  // 1: fo.v1.LinkInput.channels:type_name -> fo.v1.Channel
+	4,  // 2: fo.v1.LinkInput.services:type_name -> fo.v1.Service
+	16, // 3: fo.v1.Service.params:type_name -> fo.v1.Service.ParamsEntry
+	3,  // 4: fo.v1.LinkOutput.channels:type_name -> fo.v1.ChannelOutput
+	5,  // 5: fo.v1.LinkOutput.services:type_name -> fo.v1.ServiceOutput
+	17, // 6: fo.v1.RunnerOptions.cables:type_name -> fo.v1.RunnerOptions.CablesEntry
+	0,  // 7: fo.v1.ComputeRequest.link:type_name -> fo.v1.LinkInput
+	7,  // 8: fo.v1.ComputeRequest.options:type_name -> fo.v1.RunnerOptions
+	6,  // 9: fo.v1.ComputeResponse.result:type_name -> fo.v1.LinkOutput
+	10, // 10: fo.v1.ComputeResponse.errors:type_name -> fo.v1.RowError
+	0,  // 11: fo.v1.SweepRequest.links:type_name -> fo.v1.LinkInput
+	9,  // 12: fo.v1.SweepRequest.variations:type_name -> fo.v1.Variation
+	7,  // 13: fo.v1.SweepRequest.options:type_name -> fo.v1.RunnerOptions
+	7,  // 14: fo.v1.RunRequest.options:type_name -> fo.v1.RunnerOptions
+	0,  // 15: fo.v1.RunRequest.link:type_name -> fo.v1.LinkInput
+	6,  // 16: fo.v1.RunResponse.result:type_name -> fo.v1.LinkOutput
+	10, // 17: fo.v1.RunResponse.errors:type_name -> fo.v1.RowError
+	8,  // 18: fo.v1.RunnerOptions.CablesEntry.value:type_name -> fo.v1.CableAllowances
+	11, // 19: fo.v1.Engine.Compute:input_type -> fo.v1.ComputeRequest
+	13, // 20: fo.v1.Engine.Sweep:input_type -> fo.v1.SweepRequest
+	14, // 21: fo.v1.Engine.Run:input_type -> fo.v1.RunRequest
+	12, // 22: fo.v1.Engine.Compute:output_type -> fo.v1.ComputeResponse
+	6,  // 23: fo.v1.Engine.Sweep:output_type -> fo.v1.LinkOutput
+	15, // 24: fo.v1.Engine.Run:output_type -> fo.v1.RunResponse
+	22, // [22:25] is the sub-list for method output_type
+	19, // [19:22] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_fo_v1_engine_proto_init() }
+func file_fo_v1_engine_proto_init() {
+	if File_fo_v1_engine_proto != nil {
+		return
+	}
+	file_fo_v1_engine_proto_msgTypes[7].OneofWrappers = []any{}
+	file_fo_v1_engine_proto_msgTypes[14].OneofWrappers = []any{
+		(*RunRequest_Options)(nil),
+		(*RunRequest_Link)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fo_v1_engine_proto_rawDesc), len(file_fo_v1_engine_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fo_v1_engine_proto_goTypes,
+		DependencyIndexes: file_fo_v1_engine_proto_depIdxs,
+		MessageInfos:      file_fo_v1_engine_proto_msgTypes,
+	}.Build()
+	File_fo_v1_engine_proto = out.File
+	file_fo_v1_engine_proto_goTypes = nil
+	file_fo_v1_engine_proto_depIdxs = nil
+}
@@ -0,0 +1,180 @@
+package rpc
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/fadeldnswr/fo-performance-engine.git --go-grpc_out=../.. --go-grpc_opt=module=github.com/fadeldnswr/fo-performance-engine.git fo/v1/engine.proto
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/rpc/fov1"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/sweep"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/validate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Define struct for gRPC service options
+type Options struct {
+	Runner     calc.RunnerOptions // Defaults under request options, before WithDefaults
+	Validation validate.ValidationOptions
+}
+
+// Define struct implementing the Engine service
+type Server struct {
+	fov1.UnimplementedEngineServer
+	opt Options
+}
+
+// Define function to create a new Engine service
+func NewServer(opt Options) *Server {
+	return &Server{opt: opt}
+}
+
+// Define function to compute one link
+func (s *Server) Compute(ctx context.Context, req *fov1.ComputeRequest) (*fov1.ComputeResponse, error) {
+	if req.GetLink() == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing link")
+	}
+	opt, err := optionsFromProto(req.GetOptions(), s.opt.Runner)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	link := linkFromProto(req.GetLink())
+	res, errs, err := s.computeOne(link, 1, opt.WithDefaults())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &fov1.ComputeResponse{Result: res, Errors: errorsToProto(errs)}, nil
+}
+
+// Define function to sweep links over variations and stream the results
+func (s *Server) Sweep(req *fov1.SweepRequest, stream grpc.ServerStreamingServer[fov1.LinkOutput]) error {
+	links := make([]model.LinkInput, len(req.GetLinks()))
+	for i, l := range req.GetLinks() {
+		links[i] = linkFromProto(l)
+	}
+	opt, err := optionsFromProto(req.GetOptions(), s.opt.Runner)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	opt = opt.WithDefaults()
+	if errs := validate.ValidateLink(links, s.validation(opt)); validate.HasBlocking(errs) {
+		return status.Error(codes.InvalidArgument, firstBlocking(errs).Error())
+	}
+
+	// RunSweep panics on unknown fields, so reject them up front
+	vars := make([]sweep.Variation, len(req.GetVariations()))
+	for i, v := range req.GetVariations() {
+		vars[i] = variationFromProto(v)
+		if _, err := sweep.ApplyVariations(model.LinkInput{}, vars[i], 0); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if len(vars) == 0 {
+		return status.Error(codes.InvalidArgument, "missing variations")
+	}
+
+	results := sweep.RunSweep(links, vars, sweep.SweepOptions{Runner: opt})
+	for _, res := range results {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(outputToProto(res)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Define function to run a bulk stream of links. An options message changes
+// the runner options for the links that follow it; its unset fields keep the
+// options in effect before it. Defaults are filled per link, so a later
+// message turning RTB off does not keep the RTB bitrate fallback.
+func (s *Server) Run(stream grpc.BidiStreamingServer[fov1.RunRequest, fov1.RunResponse]) error {
+	opt := s.opt.Runner
+	row := 0
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if o := req.GetOptions(); o != nil {
+			if opt, err = optionsFromProto(o, opt); err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			continue
+		}
+		if req.GetLink() == nil {
+			return status.Error(codes.InvalidArgument, "empty run request")
+		}
+		row++
+		res, errs, err := s.computeOne(linkFromProto(req.GetLink()), row, opt.WithDefaults())
+		if err != nil {
+			errs = append(errs, model.RowError{Row: row, Message: err.Error()})
+		}
+		if err := stream.Send(&fov1.RunResponse{Row: int32(row), Result: res, Errors: errorsToProto(errs)}); err != nil {
+			return err
+		}
+	}
+}
+
+// Define function to validate and compute a single link. The result is nil
+// when validation finds a blocking error.
+func (s *Server) computeOne(link model.LinkInput, row int, opt calc.RunnerOptions) (*fov1.LinkOutput, []model.RowError, error) {
+	errs := validate.ValidateLink([]model.LinkInput{link}, s.validation(opt))
+	for i := range errs {
+		errs[i].Row = row
+	}
+	if validate.HasBlocking(errs) {
+		return nil, errs, nil
+	}
+	res, err := calc.Compute(link, opt)
+	if err != nil {
+		return nil, errs, err
+	}
+	return outputToProto(res), errs, nil
+}
+
+// Define helper to validate against the cable types the runner options carry,
+// including those a request added
+func (s *Server) validation(opt calc.RunnerOptions) validate.ValidationOptions {
+	v := s.opt.Validation
+	if opt.Cables != nil {
+		v.Cables = opt.Cables
+	}
+	return v
+}
+
+// Define helper to pick the first blocking error
+func firstBlocking(errs []model.RowError) model.RowError {
+	for _, e := range errs {
+		if e.IsBlocking() {
+			return e
+		}
+	}
+	return model.RowError{}
+}
+
+// Define function to serve the Engine service until the context is cancelled
+func Serve(ctx context.Context, lis net.Listener, opt Options) error {
+	srv := grpc.NewServer()
+	fov1.RegisterEngineServer(srv, NewServer(opt))
+
+	// Stop gracefully when the context is done
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+	if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
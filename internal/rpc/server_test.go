@@ -0,0 +1,529 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/cable"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/rpc/fov1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// Define helper to serve the Engine service on an in-memory listener and
+// return a client connected to it
+func newTestClient(t *testing.T, opt Options) fov1.EngineClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- Serve(ctx, lis, opt) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	})
+	return fov1.NewEngineClient(conn)
+}
+
+// Define helper for a passing link: 8.4 dB of loss, 19.6 dB of margin
+func testLink(id string) *fov1.LinkInput {
+	return &fov1.LinkInput{
+		LinkId: id, Scenario: "base",
+		TxPowerDbm: 3, RxSensitivityDbm: -28, SystemMarginDb: 3,
+		FiberLengthKm: 20, FiberAttDbPerKm: 0.35,
+		NSplice: 4, SpliceLossDb: 0.1, NConnector: 2, ConnectorLossDb: 0.5,
+	}
+}
+
+// Define helper to compare floats computed along different paths
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestComputeWithoutOptionsUsesServerDefaults(t *testing.T) {
+	client := newTestClient(t, Options{Runner: calc.RunnerOptions{DispersionPerKm: 0.01}})
+	resp, err := client.Compute(context.Background(), &fov1.ComputeRequest{Link: testLink("L1")})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	res := resp.GetResult()
+	if res.GetLpbStatus() != "PASS" || !near(res.GetTotalLossDb(), 8.4) || !near(res.GetMarginDb(), 19.6) {
+		t.Errorf("got %s, loss %v, margin %v; want PASS, 8.4, 19.6", res.GetLpbStatus(), res.GetTotalLossDb(), res.GetMarginDb())
+	}
+	if res.GetAllowedRiseTimeNs() != 0 {
+		t.Errorf("RTB evaluated without enable_rtb: allowed %v ns", res.GetAllowedRiseTimeNs())
+	}
+}
+
+func TestComputeMergesOptionsOverServerDefaults(t *testing.T) {
+	client := newTestClient(t, Options{Runner: calc.RunnerOptions{DispersionPerKm: 0.01, TxRiseTimeNs: 0.1}})
+	resp, err := client.Compute(context.Background(), &fov1.ComputeRequest{
+		Link:    testLink("L1"),
+		Options: &fov1.RunnerOptions{EnableRtb: proto.Bool(true), RxRiseTimeNs: proto.Float64(0.1)},
+	})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+
+	// Bitrate falls back to the RTB default, dispersion and Tx rise time come from the server
+	res := resp.GetResult()
+	if want := 0.7 / calc.DefaultRTBBitrateGbps * 1000; !near(res.GetAllowedRiseTimeNs(), want) {
+		t.Errorf("allowed rise time %v ns, want %v", res.GetAllowedRiseTimeNs(), want)
+	}
+	if want := 0.1 + 0.1 + 0.2; !near(res.GetSystemRiseTimeNs(), want) {
+		t.Errorf("system rise time %v ns, want %v", res.GetSystemRiseTimeNs(), want)
+	}
+	if !res.GetRtbPass() {
+		t.Error("RTB failed, want pass")
+	}
+}
+
+func TestComputeReturnsBlockingErrorsWithoutResult(t *testing.T) {
+	client := newTestClient(t, Options{})
+	link := testLink("L1")
+	link.FiberLengthKm = -1
+	resp, err := client.Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	if resp.GetResult() != nil {
+		t.Error("result set despite a blocking error")
+	}
+	if len(resp.GetErrors()) == 0 || resp.GetErrors()[0].GetField() != "fiber_length_km" {
+		t.Errorf("errors %v, want one on fiber_length_km", resp.GetErrors())
+	}
+}
+
+func TestComputeRejectsMissingLink(t *testing.T) {
+	client := newTestClient(t, Options{})
+	_, err := client.Compute(context.Background(), &fov1.ComputeRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got %v, want InvalidArgument", err)
+	}
+}
+
+func TestSweepStreamsEveryLinkAndValue(t *testing.T) {
+	client := newTestClient(t, Options{})
+	stream, err := client.Sweep(context.Background(), &fov1.SweepRequest{
+		Links:      []*fov1.LinkInput{testLink("L1"), testLink("L2")},
+		Variations: []*fov1.Variation{{Field: "system_margin_db", Values: []float64{3, 6}}},
+	})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	margins := make(map[string][]float64)
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		margins[res.GetLinkId()] = append(margins[res.GetLinkId()], res.GetMarginDb())
+	}
+	for _, id := range []string{"L1", "L2"} {
+		m := margins[id]
+		if len(m) != 2 || !near(m[0]+m[1], 19.6+16.6) {
+			t.Errorf("%s margins %v, want 19.6 and 16.6", id, m)
+		}
+	}
+}
+
+func TestSweepRejectsUnknownField(t *testing.T) {
+	client := newTestClient(t, Options{})
+	stream, err := client.Sweep(context.Background(), &fov1.SweepRequest{
+		Links:      []*fov1.LinkInput{testLink("L1")},
+		Variations: []*fov1.Variation{{Field: "no_such_field", Values: []float64{1}}},
+	})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got %v, want InvalidArgument", err)
+	}
+}
+
+func TestRunAppliesOptionsToFollowingLinks(t *testing.T) {
+	client := newTestClient(t, Options{Runner: calc.RunnerOptions{DispersionPerKm: 0.01}})
+	stream, err := client.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	invalid := testLink("L3")
+	invalid.FiberAttDbPerKm = -0.2
+	requests := []*fov1.RunRequest{
+		{Payload: &fov1.RunRequest_Link{Link: testLink("L1")}},
+		{Payload: &fov1.RunRequest_Options{Options: &fov1.RunnerOptions{EnableRtb: proto.Bool(true)}}},
+		{Payload: &fov1.RunRequest_Link{Link: testLink("L2")}},
+		{Payload: &fov1.RunRequest_Link{Link: invalid}},
+	}
+	for _, req := range requests {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+
+	var replies []*fov1.RunResponse
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		replies = append(replies, resp)
+	}
+	if len(replies) != 3 {
+		t.Fatalf("got %d replies, want 3", len(replies))
+	}
+	for i, r := range replies {
+		if int(r.GetRow()) != i+1 {
+			t.Errorf("reply %d has row %d", i, r.GetRow())
+		}
+	}
+	if replies[0].GetResult().GetAllowedRiseTimeNs() != 0 {
+		t.Error("RTB evaluated before the options message")
+	}
+	if !replies[1].GetResult().GetRtbPass() || replies[1].GetResult().GetAllowedRiseTimeNs() == 0 {
+		t.Error("RTB not evaluated after the options message")
+	}
+	if replies[2].GetResult() != nil || len(replies[2].GetErrors()) == 0 {
+		t.Errorf("invalid link: result %v, errors %v; want only errors", replies[2].GetResult(), replies[2].GetErrors())
+	}
+}
+
+func TestRunTurningRTBOffDropsTheRTBBitrate(t *testing.T) {
+	// A server started with --rtb and no bitrate
+	client := newTestClient(t, Options{Runner: calc.RunnerOptions{EnableRTB: true}})
+	stream, err := client.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	link := testLink("L1")
+	link.PmdPsSqrtKm = 0.5
+	requests := []*fov1.RunRequest{
+		{Payload: &fov1.RunRequest_Link{Link: link}},
+		{Payload: &fov1.RunRequest_Options{Options: &fov1.RunnerOptions{EnableRtb: proto.Bool(false)}}},
+		{Payload: &fov1.RunRequest_Link{Link: link}},
+	}
+	for _, req := range requests {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+
+	// The 2.5 Gbps fallback drives PMD only while RTB is on
+	want := []string{"PASS", ""}
+	for i := range want {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if got := resp.GetResult().GetPmdStatus(); got != want[i] {
+			t.Errorf("row %d: PMD status %q; want %q", resp.GetRow(), got, want[i])
+		}
+	}
+}
+
+func TestComputeAmplifiedLink(t *testing.T) {
+	client := newTestClient(t, Options{})
+	link := &fov1.LinkInput{
+		LinkId: "A2", Scenario: "base",
+		TxPowerDbm: 0, RxSensitivityDbm: -18, SystemMarginDb: 3,
+		FiberLengthKm: 160, FiberAttDbPerKm: 0.22,
+		NSplice: 40, SpliceLossDb: 0.05, NConnector: 4, ConnectorLossDb: 0.3, WavelengthNm: 1550,
+		Amplifiers: []*fov1.Amplifier{
+			{Type: "edfa", PositionKm: 80, GainDb: 20, NoiseFigureDb: 5, PsatDbm: 17},
+			{Type: "edfa", PositionKm: 120, GainDb: 10, NoiseFigureDb: 5.5, PsatDbm: 17},
+		},
+		RequiredOsnrDb: 20,
+	}
+	resp, err := client.Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	res := resp.GetResult()
+	if !near(res.GetAmplifierGainDb(), 30) || !near(res.GetRxPowerDbm(), -8.4) || res.GetOsnrStatus() != "PASS" || res.GetOsnrDb() < 20 {
+		t.Errorf("gain %v dB, rx %v dBm, OSNR %v dB %s; want 30, -8.4, above 20 PASS",
+			res.GetAmplifierGainDb(), res.GetRxPowerDbm(), res.GetOsnrDb(), res.GetOsnrStatus())
+	}
+}
+
+func TestComputeBERWithFECFromOptions(t *testing.T) {
+	client := newTestClient(t, Options{})
+
+	// Received power 0.4 dB below the 1e-10 reference point: fails 1e-12 before FEC
+	link := testLink("B1")
+	link.RxSensitivityDbm, link.SensitivityBer, link.QSlope = -5, 1e-10, 1
+	resp, err := client.Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	if res := resp.GetResult(); res.GetBerStatus() != "FAIL" || res.GetPostFecStatus() != "FAIL" || res.GetBer() != res.GetPostFecBer() {
+		t.Errorf("without FEC: BER %v %s, post-FEC %v %s; want FAIL and unchanged", res.GetBer(), res.GetBerStatus(), res.GetPostFecBer(), res.GetPostFecStatus())
+	}
+
+	resp, err = client.Compute(context.Background(), &fov1.ComputeRequest{
+		Link:    link,
+		Options: &fov1.RunnerOptions{Fec: proto.String("rs-255-239")},
+	})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	// The pre-FEC BER is now judged against the 1.8e-4 the code corrects
+	if res := resp.GetResult(); res.GetBerStatus() != "PASS" || res.GetPostFecStatus() != "PASS" {
+		t.Errorf("with RS(255,239): BER %s, post-FEC %s; want PASS, PASS", res.GetBerStatus(), res.GetPostFecStatus())
+	}
+
+	_, err = client.Compute(context.Background(), &fov1.ComputeRequest{
+		Link:    link,
+		Options: &fov1.RunnerOptions{Fec: proto.String("no-such-fec")},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unknown FEC: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestComputePenalties(t *testing.T) {
+	client := newTestClient(t, Options{Runner: calc.RunnerOptions{BitrateGbps: 2.5}})
+
+	// A supplied penalty comes off the margin
+	link := testLink("P1")
+	link.ReflectionPenaltyDb = 0.5
+	resp, err := client.Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	if res := resp.GetResult(); !near(res.GetPenaltyTotalDb(), 0.5) || !near(res.GetMarginDb(), 19.1) {
+		t.Errorf("penalty %v dB, margin %v dB; want 0.5, 19.1", res.GetPenaltyTotalDb(), res.GetMarginDb())
+	}
+
+	// 80 km of G.652 at 1550 nm with a 1 nm source has no finite dispersion penalty
+	link = testLink("P4")
+	link.FiberLengthKm, link.FiberAttDbPerKm, link.WavelengthNm, link.FiberType, link.SpectralWidthNm = 80, 0.22, 1550, "G.652", 1
+	resp, err = client.Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	res := resp.GetResult()
+	if res.GetLpbStatus() != "UNBOUNDED" || len(res.GetUnboundedPenalties()) != 1 || res.GetUnboundedPenalties()[0] != "dispersion_penalty_db" {
+		t.Errorf("status %s, unbounded %v; want UNBOUNDED on dispersion_penalty_db", res.GetLpbStatus(), res.GetUnboundedPenalties())
+	}
+	if res.GetDispersionPenaltyDb() != 0 || res.GetPenaltyTotalDb() != 0 {
+		t.Errorf("dispersion penalty %v dB, total %v dB; want both 0", res.GetDispersionPenaltyDb(), res.GetPenaltyTotalDb())
+	}
+}
+
+func TestComputePMDUsesLinkBitrateAndBitFraction(t *testing.T) {
+	link := testLink("M2")
+	link.PmdPsSqrtKm, link.BitrateGbps = 0.8, 40
+
+	// DGD 0.8 · √20 ≈ 3.58 ps against 0.1 · 25 ps
+	resp, err := newTestClient(t, Options{}).Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	res := resp.GetResult()
+	if !near(res.GetDgdPs(), 0.8*math.Sqrt(20)) || !near(res.GetMaxDgdPs(), 2.5) || res.GetPmdStatus() != "FAIL" {
+		t.Errorf("DGD %v ps, max %v ps, status %s; want %v, 2.5, FAIL", res.GetDgdPs(), res.GetMaxDgdPs(), res.GetPmdStatus(), 0.8*math.Sqrt(20))
+	}
+
+	// A looser bit fraction from the request lets it pass
+	resp, err = newTestClient(t, Options{}).Compute(context.Background(), &fov1.ComputeRequest{
+		Link:    link,
+		Options: &fov1.RunnerOptions{PmdBitFraction: proto.Float64(0.2)},
+	})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	if res := resp.GetResult(); !near(res.GetMaxDgdPs(), 5) || res.GetPmdStatus() != "PASS" {
+		t.Errorf("max %v ps, status %s; want 5, PASS", res.GetMaxDgdPs(), res.GetPmdStatus())
+	}
+}
+
+func TestComputeWDMChannels(t *testing.T) {
+	link := testLink("W1")
+	link.FiberType, link.MuxLossDb, link.DemuxLossDb = "G.652.D", 2, 2
+	link.Channels = []*fov1.Channel{{Label: "1471", WavelengthNm: 1471}, {Label: "1611", WavelengthNm: 1611}}
+
+	resp, err := newTestClient(t, Options{}).Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	res := resp.GetResult()
+	if !near(res.GetWdmLossDb(), 4) || res.GetChannelCount() != 2 || len(res.GetChannels()) != 2 {
+		t.Fatalf("WDM loss %v dB, %d channels (%d listed); want 4, 2", res.GetWdmLossDb(), res.GetChannelCount(), len(res.GetChannels()))
+	}
+
+	// The link reports its worst channel
+	worst := res.GetChannels()[0]
+	for _, ch := range res.GetChannels()[1:] {
+		if ch.GetMarginDb() < worst.GetMarginDb() {
+			worst = ch
+		}
+	}
+	if res.GetWorstChannel() != worst.GetLabel() || res.GetWorstChannelNm() != worst.GetWavelengthNm() || !near(res.GetMarginDb(), worst.GetMarginDb()) {
+		t.Errorf("worst channel %s (%v nm, margin %v dB); want %s (%v nm, margin %v dB)",
+			res.GetWorstChannel(), res.GetWorstChannelNm(), res.GetMarginDb(), worst.GetLabel(), worst.GetWavelengthNm(), worst.GetMarginDb())
+	}
+}
+
+func TestComputeCoexistenceServices(t *testing.T) {
+	link := testLink("D02")
+	link.FiberType, link.SplitterLossDb = "G.652.D", 17.5
+	link.Services = []*fov1.Service{{Name: "gpon"}, {Name: "xgs-pon", Params: map[string]float64{"up_tx": 5}}}
+
+	resp, err := newTestClient(t, Options{}).Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	res := resp.GetResult()
+	if len(res.GetServices()) == 0 {
+		t.Fatal("no services in the result")
+	}
+
+	// Parameters override the catalog, and the link reports its limiting service
+	worst := res.GetServices()[0]
+	for _, svc := range res.GetServices() {
+		if svc.GetService() == "xgs-pon" && svc.GetDirection() == "up" && svc.GetTxPowerDbm() != 5 {
+			t.Errorf("xgs-pon/up Tx %v dBm; want 5", svc.GetTxPowerDbm())
+		}
+		if svc.GetMarginDb() < worst.GetMarginDb() {
+			worst = svc
+		}
+	}
+	if want := worst.GetService() + "/" + worst.GetDirection(); res.GetLimitingService() != want || !near(res.GetMarginDb(), worst.GetMarginDb()) {
+		t.Errorf("limiting service %s (margin %v dB); want %s (margin %v dB)", res.GetLimitingService(), res.GetMarginDb(), want, worst.GetMarginDb())
+	}
+}
+
+func TestComputeReflectanceBudget(t *testing.T) {
+	link := testLink("R2")
+	link.ConnectorType, link.OrlToleranceDb = "pc", 45
+
+	resp, err := newTestClient(t, Options{}).Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+
+	// A -40 dB PC connector alone keeps the ORL under 40 dB
+	res := resp.GetResult()
+	if res.GetOrlDb() <= 0 || res.GetOrlDb() > 40 || res.GetReflectionStatus() != "FAIL" {
+		t.Errorf("ORL %v dB, status %s; want at most 40 dB, FAIL", res.GetOrlDb(), res.GetReflectionStatus())
+	}
+	if res.GetWorstReflectanceDb() != -40 || res.GetWorstReflectionEvent() != "connector 1 (0 km)" {
+		t.Errorf("worst event %q at %v dB; want connector 1 (0 km) at -40 dB", res.GetWorstReflectionEvent(), res.GetWorstReflectanceDb())
+	}
+}
+
+func TestComputeAllowancesMergeCablesOverServerDefaults(t *testing.T) {
+	client := newTestClient(t, Options{Runner: calc.RunnerOptions{
+		Cables: map[string]cable.Allowances{"campus": {AgingDb: 1}},
+	}})
+	opts := &fov1.RunnerOptions{Cables: map[string]*fov1.CableAllowances{
+		"aerial": {RepairSplicesPerKm: 0.4, TempDriftDbPerKm: 0.03, AgingDb: 0.5, CableCutDb: 1},
+	}}
+
+	// The request replaces the built-in aerial allowances: 0.4 · 20 · 0.1 + 0.03 · 20 + 0.5 + 1
+	link := testLink("A2")
+	link.CableType = "aerial"
+	resp, err := client.Compute(context.Background(), &fov1.ComputeRequest{Link: link, Options: opts})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	res := resp.GetResult()
+	if !near(res.GetRepairAllowanceDb(), 0.8) || !near(res.GetTemperatureAllowanceDb(), 0.6) || !near(res.GetAllowanceTotalDb(), 2.9) {
+		t.Errorf("repair %v dB, temperature %v dB, total %v dB; want 0.8, 0.6, 2.9",
+			res.GetRepairAllowanceDb(), res.GetTemperatureAllowanceDb(), res.GetAllowanceTotalDb())
+	}
+	if !near(res.GetMarginDb(), 19.6-2.9) {
+		t.Errorf("margin %v dB; want %v", res.GetMarginDb(), 19.6-2.9)
+	}
+
+	// Cable types configured on the server stay available
+	link.CableType = "campus"
+	resp, err = client.Compute(context.Background(), &fov1.ComputeRequest{Link: link, Options: opts})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	if res := resp.GetResult(); !near(res.GetAgingAllowanceDb(), 1) || !near(res.GetAllowanceTotalDb(), 1) {
+		t.Errorf("aging %v dB, total %v dB; want 1, 1", res.GetAgingAllowanceDb(), res.GetAllowanceTotalDb())
+	}
+}
+
+func TestComputeNonlinearThresholds(t *testing.T) {
+	// 10 dBm of a 10 MHz source over 40 km of G.652 is above the SBS threshold
+	link := testLink("N1")
+	link.TxPowerDbm, link.FiberLengthKm, link.FiberAttDbPerKm, link.WavelengthNm, link.FiberType = 10, 40, 0.22, 1550, "G.652"
+	link.SourceLinewidthMhz = 10
+
+	resp, err := newTestClient(t, Options{}).Compute(context.Background(), &fov1.ComputeRequest{Link: link})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+	res := resp.GetResult()
+	if res.GetNonlinearStatus() != "FAIL" || res.GetEffectiveLengthKm() <= 0 || res.GetSbsThresholdDbm() >= 10 {
+		t.Fatalf("status %s, effective length %v km, SBS threshold %v dBm; want FAIL below 10 dBm",
+			res.GetNonlinearStatus(), res.GetEffectiveLengthKm(), res.GetSbsThresholdDbm())
+	}
+	if !near(res.GetSbsPenaltyDb(), 10-res.GetSbsThresholdDbm()) {
+		t.Errorf("SBS penalty %v dB; want %v", res.GetSbsPenaltyDb(), 10-res.GetSbsThresholdDbm())
+	}
+}
+
+func TestComputeMultimodePHY(t *testing.T) {
+	link := &fov1.LinkInput{
+		LinkId: "M4", TxPowerDbm: -1, RxSensitivityDbm: -9.2, SystemMarginDb: 0.5,
+		FiberLengthKm: 0.12, FiberAttDbPerKm: 3, NConnector: 2, ConnectorLossDb: 0.35,
+		WavelengthNm: 850, FiberType: "OM4", Phy: "100gbase-sr4",
+	}
+	resp, err := newTestClient(t, Options{}).Compute(context.Background(), &fov1.ComputeRequest{
+		Link:    link,
+		Options: &fov1.RunnerOptions{EnableRtb: proto.Bool(true)},
+	})
+	if err != nil {
+		t.Fatalf("Compute: %v", err)
+	}
+
+	// 120 m of OM4 is past the 100 m reach of 100GBASE-SR4
+	res := resp.GetResult()
+	if res.GetPhyStatus() != "FAIL" || res.GetPhyLimit() != "reach" || res.GetPhyReachM() != 100 {
+		t.Errorf("PHY %s on %s, reach %v m; want FAIL on reach, 100 m", res.GetPhyStatus(), res.GetPhyLimit(), res.GetPhyReachM())
+	}
+
+	// The rise time budget runs at the PHY lane rate and adds modal dispersion
+	if want := 0.7 / 25.78125 * 1000; !near(res.GetAllowedRiseTimeNs(), want) {
+		t.Errorf("allowed %v ns; want %v", res.GetAllowedRiseTimeNs(), want)
+	}
+	if res.GetModalRiseTimeNs() <= 0 || !near(res.GetSystemRiseTimeNs(), res.GetModalRiseTimeNs()) {
+		t.Errorf("system %v ns, modal %v ns; want the modal term alone", res.GetSystemRiseTimeNs(), res.GetModalRiseTimeNs())
+	}
+}
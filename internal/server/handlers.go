@@ -0,0 +1,278 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/sweep"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/validate"
+)
+
+// Define struct for JSON request bodies. CSV bodies carry the links and take
+// the remaining fields from query parameters.
+type request struct {
+	Links      []model.LinkInput   `json:"links"`
+	Options    *calc.RunnerOptions `json:"options,omitempty"`
+	Variations []sweep.Variation   `json:"variations,omitempty"`
+	Field      string              `json:"field,omitempty"`
+}
+
+// Define response bodies
+type validateResponse struct {
+	Valid  bool             `json:"valid"`
+	Errors []model.RowError `json:"errors"`
+}
+
+type computeResponse struct {
+	Results []model.LinkOutput `json:"results"`
+	Errors  []model.RowError   `json:"errors"`
+}
+
+type solveResponse struct {
+	Solutions []sweep.Solution `json:"solutions"`
+	Errors    []model.RowError `json:"errors"`
+}
+
+// Define handler for POST /validate
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	req, rowErrs, err := s.decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	errs := append(rowErrs, validate.ValidateLink(req.Links, s.opt.Validation)...)
+	writeJSON(w, http.StatusOK, validateResponse{Valid: !validate.HasBlocking(errs), Errors: nonNil(errs)})
+}
+
+// Define handler for POST /compute
+func (s *Server) handleCompute(w http.ResponseWriter, r *http.Request) {
+	req, errs, ok := s.decodeValid(w, r)
+	if !ok {
+		return
+	}
+	results := make([]model.LinkOutput, 0, len(req.Links))
+	for i, link := range req.Links {
+		res, err := calc.Compute(link, *req.Options)
+		if err != nil {
+			errs = append(errs, model.RowError{Row: link.RowNumber(i), Line: link.Line, Message: err.Error()})
+			continue
+		}
+		results = append(results, res)
+	}
+	s.writeResults(w, r, results, errs)
+}
+
+// Define handler for POST /sweep
+func (s *Server) handleSweep(w http.ResponseWriter, r *http.Request) {
+	req, errs, ok := s.decodeValid(w, r)
+	if !ok {
+		return
+	}
+	if len(req.Variations) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("Missing variations"))
+		return
+	}
+	// RunSweep panics on unknown fields, so reject them up front
+	for _, v := range req.Variations {
+		if _, err := sweep.ApplyVariations(model.LinkInput{}, v, 0); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	results := sweep.RunSweep(req.Links, req.Variations, sweep.SweepOptions{Runner: *req.Options})
+	s.writeResults(w, r, results, errs)
+}
+
+// Define handler for POST /solve
+func (s *Server) handleSolve(w http.ResponseWriter, r *http.Request) {
+	req, errs, ok := s.decodeValid(w, r)
+	if !ok {
+		return
+	}
+	if req.Field == "" {
+		writeError(w, http.StatusBadRequest, errors.New("Missing field"))
+		return
+	}
+	solutions := make([]sweep.Solution, 0, len(req.Links))
+	for i, link := range req.Links {
+		sol, err := sweep.Solve(link, req.Field, *req.Options)
+		if err != nil {
+			errs = append(errs, model.RowError{Row: link.RowNumber(i), Line: link.Line, Field: req.Field, Message: err.Error()})
+			continue
+		}
+		solutions = append(solutions, sol)
+	}
+	writeJSON(w, http.StatusOK, solveResponse{Solutions: solutions, Errors: nonNil(errs)})
+}
+
+// Define function to decode and validate a request, writing the error response itself.
+// Non-blocking validation findings are returned so they reach the client.
+func (s *Server) decodeValid(w http.ResponseWriter, r *http.Request) (request, []model.RowError, bool) {
+	req, rowErrs, err := s.decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return req, nil, false
+	}
+	errs := append(rowErrs, validate.ValidateLink(req.Links, s.opt.Validation)...)
+	if validate.HasBlocking(errs) {
+		writeJSON(w, http.StatusUnprocessableEntity, computeResponse{Results: []model.LinkOutput{}, Errors: errs})
+		return req, nil, false
+	}
+	return req, errs, true
+}
+
+// Define function to decode a JSON or CSV request body
+func (s *Server) decode(r *http.Request) (request, []model.RowError, error) {
+	var req request
+	// Read the whole body first so an oversized body surfaces as *http.MaxBytesError
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return req, nil, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		q := r.URL.Query()
+		readOpt := foio.CSVReadOptions{DecimalComma: q.Get("decimal_comma") == "true"}
+		if readOpt.Delimiter, err = foio.ParseDelimiter(q.Get("delimiter")); err != nil {
+			return req, nil, err
+		}
+		links, rowErrs, err := foio.ReadLinks(bytes.NewReader(body), readOpt)
+		if err != nil {
+			if len(rowErrs) > 0 {
+				return req, nil, errors.New(err.Error() + ": " + rowErrs[0].Error())
+			}
+			return req, nil, err
+		}
+		req.Links = links
+		opt, err := s.optionsFromQuery(q)
+		if err != nil {
+			return req, nil, err
+		}
+		req.Options = &opt
+		req.Field = q.Get("field")
+		for _, spec := range q["vary"] {
+			v, err := sweep.ParseVariations(spec)
+			if err != nil {
+				return req, nil, err
+			}
+			req.Variations = append(req.Variations, v)
+		}
+		return req, rowErrs, nil
+	case "application/json", "":
+		// Options sent in the body are merged over the server defaults, and the
+		// defaults of enabled checks are filled only after the merge
+		opt := s.opt.Runner
+		req.Options = &opt
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			return req, nil, errors.New("Invalid JSON body: " + err.Error())
+		}
+		if req.Options == nil { // "options": null
+			req.Options = &opt
+		}
+		*req.Options = req.Options.WithDefaults()
+		return req, nil, nil
+	default:
+		return req, nil, errors.New("Unsupported content type " + strconv.Quote(mediaType) + " (use application/json or text/csv)")
+	}
+}
+
+// Define function to read runner options from query parameters over the server defaults
+func (s *Server) optionsFromQuery(q url.Values) (calc.RunnerOptions, error) {
+	opt := s.opt.Runner
+	if v := q.Get("rtb"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return opt, errors.New("Invalid rtb: " + v)
+		}
+		opt.EnableRTB = b
+	}
+	if v := q.Get("fec"); v != "" {
+		if _, ok := calc.LookupFEC(v); !ok {
+			return opt, errors.New("Invalid fec: " + v)
+		}
+		opt.FEC = v
+	}
+	floats := []struct {
+		name string
+		dst  *float64
+	}{
+		{"bitrate_gbps", &opt.BitrateGbps},
+		{"tx_rise_time_ns", &opt.TxRiseTimeNs},
+		{"rx_rise_time_ns", &opt.RxRiseTimeNs},
+		{"dispersion_ns_per_km", &opt.DispersionPerKm},
+		{"target_ber", &opt.TargetBER},
+		{"pmd_bit_fraction", &opt.PMDBitFraction},
+	}
+	for _, f := range floats {
+		if v := q.Get(f.name); v != "" {
+			x, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return opt, errors.New("Invalid " + f.name + ": " + v)
+			}
+			*f.dst = x
+		}
+	}
+	return opt.WithDefaults(), nil
+}
+
+// Define header carrying the row errors of a CSV response as a JSON array
+const RowErrorsHeader = "X-Row-Errors"
+
+// Define function to write results as JSON, or CSV when the client asks for it.
+// A CSV body cannot carry row errors: links that failed to compute turn the
+// response into a 422 JSON body, warnings go into the X-Row-Errors header.
+func (s *Server) writeResults(w http.ResponseWriter, r *http.Request, results []model.LinkOutput, errs []model.RowError) {
+	if strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		if validate.HasBlocking(errs) {
+			writeJSON(w, http.StatusUnprocessableEntity, computeResponse{Results: results, Errors: errs})
+			return
+		}
+		if len(errs) > 0 {
+			w.Header().Set(RowErrorsHeader, headerJSON(errs))
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		_ = foio.WriteResults(w, results, foio.CSVWriteOptions{Precision: foio.DefaultPrecision})
+		return
+	}
+	writeJSON(w, http.StatusOK, computeResponse{Results: results, Errors: nonNil(errs)})
+}
+
+// Define helper so empty error lists encode as [] rather than null
+func nonNil(errs []model.RowError) []model.RowError {
+	if errs == nil {
+		return []model.RowError{}
+	}
+	return errs
+}
+
+// Define helper to encode a value as JSON fit for a header: one line, with
+// non-ASCII characters escaped
+func headerJSON(v any) string {
+	b, _ := json.Marshal(v)
+	var sb strings.Builder
+	for _, r := range string(b) {
+		if r < utf8.RuneSelf {
+			sb.WriteRune(r)
+			continue
+		}
+		for _, u := range utf16.Encode([]rune{r}) {
+			sb.WriteString(`\u` + strconv.FormatUint(uint64(u)|0x10000, 16)[1:])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/config"
+)
+
+// Define helper to post a body and decode the compute response
+func postCompute(t *testing.T, h http.Handler, target, contentType, body string) (int, computeResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var resp computeResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	return rec.Code, resp
+}
+
+func TestComputeRequestOptionsSettleDefaultsAfterMerge(t *testing.T) {
+	// A server started with --rtb and no bitrate, wired as fo serve does
+	cfg := config.Default()
+	cfg.Runner.RTB = true
+	h := New(Options{Runner: cfg.RawRunnerOptions()}).Handler()
+	link := `{"link_id":"L1","scenario":"base","tx_power_dbm":3,"rx_sensitivity_dbm":-28,"system_margin_db":3,` +
+		`"fiber_length_km":20,"fiber_att_db_per_km":0.35,"pmd_ps_sqrt_km":0.5}`
+
+	tests := []struct {
+		name    string
+		options string
+		bitrate bool
+	}{
+		{"server defaults", ``, true},
+		{"rtb turned off", `,"options":{"enable_rtb":false}`, false},
+	}
+	for _, tt := range tests {
+		code, resp := postCompute(t, h, "/compute", "application/json", `{"links":[`+link+`]`+tt.options+`}`)
+		if code != http.StatusOK || len(resp.Results) != 1 {
+			t.Fatalf("%s: status %d, %d results", tt.name, code, len(resp.Results))
+		}
+		res := resp.Results[0]
+		if got := res.PMDStatus != ""; got != tt.bitrate {
+			t.Errorf("%s: PMD evaluated %v (status %q); want %v", tt.name, got, res.PMDStatus, tt.bitrate)
+		}
+		if got := res.AllowedRiseTimeNs > 0; got != tt.bitrate {
+			t.Errorf("%s: RTB evaluated %v; want %v", tt.name, got, tt.bitrate)
+		}
+	}
+}
+
+func TestComputeCSVDelimiterNames(t *testing.T) {
+	h := New(Options{}).Handler()
+	header := "link_id,scenario,tx_power_dbm,rx_sensitivity_dbm,system_margin_db,fiber_length_km,fiber_att_db_per_km," +
+		"n_splice,splice_loss_db,n_connector,connector_loss_db,splitter_loss_db,other_loss_db,wavelength_nm"
+	row := "L1,base,3,-28,3,20,0.35,4,0.1,2,0.5,0,0,1310"
+
+	tests := []struct {
+		delimiter string
+		sep       string
+		code      int
+	}{
+		{"semicolon", ";", http.StatusOK},
+		{"tab", "\t", http.StatusOK},
+		{";", ";", http.StatusOK},
+		{"semi", ";", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		body := strings.ReplaceAll(header+"\n"+row+"\n", ",", tt.sep)
+		code, resp := postCompute(t, h, "/compute?delimiter="+url.QueryEscape(tt.delimiter), "text/csv", body)
+		if code != tt.code {
+			t.Errorf("delimiter %q: status %d; want %d", tt.delimiter, code, tt.code)
+			continue
+		}
+		if code == http.StatusOK && (len(resp.Results) != 1 || resp.Results[0].LinkID != "L1") {
+			t.Errorf("delimiter %q: results %+v; want link L1", tt.delimiter, resp.Results)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/validate"
+)
+
+// Define struct for server options
+type Options struct {
+	Addr            string
+	MaxBodyBytes    int64
+	ShutdownTimeout time.Duration
+	Runner          calc.RunnerOptions // Defaults under request options, before WithDefaults
+	Validation      validate.ValidationOptions
+}
+
+// Define default limits
+const (
+	DefaultMaxBodyBytes    = 10 << 20 // 10 MiB
+	DefaultShutdownTimeout = 10 * time.Second
+)
+
+// Define struct for the HTTP server
+type Server struct {
+	opt  Options
+	http *http.Server
+}
+
+// Define function to create a new server
+func New(opt Options) *Server {
+	if opt.MaxBodyBytes <= 0 {
+		opt.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if opt.ShutdownTimeout <= 0 {
+		opt.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	s := &Server{opt: opt}
+	s.http = &http.Server{
+		Addr:              opt.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Define function to build the route table
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /validate", s.handleValidate)
+	mux.HandleFunc("POST /compute", s.handleCompute)
+	mux.HandleFunc("POST /sweep", s.handleSweep)
+	mux.HandleFunc("POST /solve", s.handleSolve)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return s.limitBody(mux)
+}
+
+// Define middleware to cap request body size
+func (s *Server) limitBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, s.opt.MaxBodyBytes)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Define function to serve until the context is cancelled, then shut down gracefully
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.http.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Let in-flight requests finish
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.opt.ShutdownTimeout)
+	defer cancel()
+	if err := s.http.Shutdown(shutdownCtx); err != nil {
+		return errors.New("Failed to shut down server: " + err.Error())
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Define helper to write a JSON response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Define helper to write an error response, mapping oversized bodies to 413
+func writeError(w http.ResponseWriter, status int, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		status = http.StatusRequestEntityTooLarge
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
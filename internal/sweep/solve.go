@@ -0,0 +1,121 @@
+package sweep
+
+import (
+	"errors"
+	"math"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define struct for a break-even solution of one field
+type Solution struct {
+	LinkID   string  `json:"link_id"`
+	Scenario string  `json:"scenario"`
+	Field    string  `json:"field"`
+	Current  float64 `json:"current"`
+	Limit    float64 `json:"limit"`    // Largest value that still keeps margin >= 0
+	Headroom float64 `json:"headroom"` // Limit - Current, negative when the link fails today
+}
+
+// Define solver tolerance and search bound
+const (
+	solveTolerance = 1e-6
+	solveMaxValue  = 1e6
+)
+
+// Define function to solve for the value of a variation field at which the
+// link margin reaches zero. Every sweepable field lowers margin as it grows,
+// so the limit is found by bracketing and bisection. Count fields are
+// truncated, so their largest whole value is floor(Limit).
+func Solve(link model.LinkInput, field string, opt calc.RunnerOptions) (Solution, error) {
+	v := Variation{Field: field}
+	current, err := fieldValue(link, v)
+	if err != nil {
+		return Solution{}, err
+	}
+	margin := func(x float64) (float64, error) {
+		mod, err := ApplyVariations(link, v, x)
+		if err != nil {
+			return 0, err
+		}
+		res, err := calc.Compute(mod, opt)
+		if err != nil {
+			return 0, err
+		}
+		// An unbounded penalty fails whatever the finite margin says
+		if res.Unbounded() {
+			return math.Inf(-1), nil
+		}
+		return res.MarginDb, nil
+	}
+
+	// Fails even at zero: no value of this field can save the link
+	m0, err := margin(0)
+	if err != nil {
+		return Solution{}, err
+	}
+	if m0 < 0 {
+		return Solution{}, errors.New("Link fails with " + field + " = 0, no break-even value")
+	}
+
+	// Grow the bracket until margin turns negative
+	lo, hi := 0.0, math.Max(1, current)
+	for {
+		m, err := margin(hi)
+		if err != nil {
+			return Solution{}, err
+		}
+		if m < 0 {
+			break
+		}
+		lo = hi
+		hi *= 2
+		if hi > solveMaxValue {
+			return Solution{}, errors.New("Margin does not depend on " + field)
+		}
+	}
+
+	// Bisect to the break-even point
+	for hi-lo > solveTolerance {
+		mid := (lo + hi) / 2
+		m, err := margin(mid)
+		if err != nil {
+			return Solution{}, err
+		}
+		if m >= 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return Solution{
+		LinkID: link.LinkID, Scenario: link.Scenario, Field: field,
+		Current: current, Limit: lo, Headroom: lo - current,
+	}, nil
+}
+
+// Define function to read the current value of a variation field
+func fieldValue(link model.LinkInput, v Variation) (float64, error) {
+	switch v.Field {
+	case "engineering_margin_db", "system_margin_db":
+		return link.SystemMarginDb, nil
+	case "fiber_length_km":
+		return link.FiberLengthKm, nil
+	case "fiber_att_db_per_km":
+		return link.FiberAttDbPerKm, nil
+	case "splitter_loss_db":
+		return link.SplitterLossDb, nil
+	case "other_loss_db":
+		return link.OtherLossDb, nil
+	case "splice_loss_db":
+		return link.SpliceLossDb, nil
+	case "connector_loss_db":
+		return link.ConnectorLossDb, nil
+	case "n_splice":
+		return float64(link.NSplice), nil
+	case "n_connector":
+		return float64(link.NConnectors), nil
+	}
+	return 0, errors.New("Unknown variation field: " + v.Field)
+}
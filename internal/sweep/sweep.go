@@ -26,6 +26,16 @@ func ApplyVariations(link model.LinkInput, v Variation, value float64) (model.Li
 		out.FiberAttDbPerKm = value
 	case "splitter_loss_db":
 		out.SplitterLossDb = value
+	case "other_loss_db":
+		out.OtherLossDb = value
+	case "splice_loss_db":
+		out.SpliceLossDb = value
+	case "connector_loss_db":
+		out.ConnectorLossDb = value
+	case "n_splice":
+		out.NSplice = int(value)
+	case "n_connector":
+		out.NConnectors = int(value)
 	default:
 		return link, errors.New("Unknown variation field: " + v.Field)
 	}
@@ -36,10 +46,24 @@ func ApplyVariations(link model.LinkInput, v Variation, value float64) (model.Li
 func RunSweep(base []model.LinkInput, vars []Variation, opt SweepOptions) ([]model.LinkOutput){
 	// Define slice to hold results
 	results := []model.LinkOutput{}
+	err := WalkSweep(base, vars, func(mod model.LinkInput) error {
+		finalRes, err := calc.Compute(mod, opt.Runner)
+		if err != nil { return nil }
+		results = append(results, finalRes)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return results
+}
 
+// Define function to visit every link of the sweep in order, named after its
+// variation values. It stops at the first error from fn or from a variation.
+func WalkSweep(base []model.LinkInput, vars []Variation, fn func(model.LinkInput) error) error {
 	// Recursive generator
-	var rec func(index int, current []float64)
-	rec = func(index int, current []float64) {
+	var rec func(index int, current []float64) error
+	rec = func(index int, current []float64) error {
 		// Check if index is equal to length of vars
 		if index == len(vars){
 			scName := "base"
@@ -51,22 +75,23 @@ func RunSweep(base []model.LinkInput, vars []Variation, opt SweepOptions) ([]mod
 				mod.Scenario = scName
 				var err error
 				for i, v := range vars {
-					mod, err = ApplyVariations(mod, v, current[i])
-					if err != nil { 
-						panic(err)
+					if mod, err = ApplyVariations(mod, v, current[i]); err != nil {
+						return err
 					}
 				}
-				finalRes, err := calc.Compute(mod, opt.Runner)
-				if err != nil { continue }
-				results = append(results, finalRes)
+				if err := fn(mod); err != nil {
+					return err
+				}
 			}
-			return
+			return nil
 		}
 		for _, val := range vars[index].Values {
 			next := append(current, val)
-			rec(index + 1, next)
+			if err := rec(index + 1, next); err != nil {
+				return err
+			}
 		}
+		return nil
 	}
-	rec(0, []float64{})
-	return results
-}
\ No newline at end of file
+	return rec(0, []float64{})
+}
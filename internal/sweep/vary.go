@@ -8,15 +8,15 @@ import (
 
 // Define struct to hold variation information
 type Variation struct {
-	Field  string
-	Values []float64
+	Field  string    `json:"field"`
+	Values []float64 `json:"values"`
 }
 
 // Define function to parse variations from a configuration (placeholder)
 func ParseVariations(spec string) (Variation, error) {
 	// Parse the specification string to extract field and values
 	parts := strings.SplitN(spec, "=", 2)
-	if len(parts) != 2 { return Variation{}, errors.New("")}
+	if len(parts) != 2 { return Variation{}, errors.New("Variation must look like field=v1,v2") }
 
 	// Separate key and value parts
 	key := strings.TrimSpace(parts[0])
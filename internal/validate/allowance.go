@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/cable"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define allowance plausibility limits
+const (
+	MaxPlausibleRepairSplicesPerKm = 1.0  // One repair per km over the design life
+	MaxPlausibleTempDriftDbPerKm   = 0.05 // Above the drift of aerial cable at -40 °C
+	MaxPlausibleAllowanceDb        = 3.0  // Above a typical aging or cable-cut allowance
+)
+
+// Define function to validate the margin allowances of a link against the
+// built-in cable types and those of the run configuration
+func validateAllowances(link model.LinkInput, row int, cables map[string]cable.Allowances) []model.RowError {
+	var errs []model.RowError
+	fail := func(field string, value float64, msg string) {
+		errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: field, Value: formatValue(value), Message: msg})
+	}
+	if link.CableType != "" {
+		if _, ok := cable.Lookup(link.CableType, cables); !ok {
+			errs = append(errs, model.RowError{
+				Row: row, Line: link.Line, Field: "cable_type", Value: link.CableType,
+				Message: "Unknown cable type (available: " + strings.Join(cable.Names(cables), ", ") + ")",
+			})
+		}
+	}
+	for _, a := range []struct {
+		name  string
+		value float64
+	}{
+		{"repair_splices_per_km", link.RepairSplicesPerKm},
+		{"temp_drift_db_per_km", link.TempDriftDbPerKm},
+		{"aging_margin_db", link.AgingMarginDb},
+		{"cable_cut_margin_db", link.CableCutMarginDb},
+	} {
+		if a.value < 0 {
+			fail(a.name, a.value, "Allowance has to be zero or greater")
+		}
+	}
+	return errs
+}
+
+// Define function to flag margin allowances that look suspicious
+func checkAllowancePlausibility(link model.LinkInput, warn func(field string, value float64, msg string)) {
+	if link.RepairSplicesPerKm > MaxPlausibleRepairSplicesPerKm {
+		warn("repair_splices_per_km", link.RepairSplicesPerKm, "More than one repair splice per km")
+	}
+	if link.TempDriftDbPerKm > MaxPlausibleTempDriftDbPerKm {
+		warn("temp_drift_db_per_km", link.TempDriftDbPerKm, "Temperature drift above 0.05 dB/km")
+	}
+	if link.AgingMarginDb > MaxPlausibleAllowanceDb {
+		warn("aging_margin_db", link.AgingMarginDb, "Aging allowance above 3 dB")
+	}
+	if link.CableCutMarginDb > MaxPlausibleAllowanceDb {
+		warn("cable_cut_margin_db", link.CableCutMarginDb, "Cable-cut allowance above 3 dB")
+	}
+
+	// Itemised allowances on top of a large lump-sum margin are likely counted twice
+	if link.CableType != "" && link.SystemMarginDb > MaxPlausibleAllowanceDb {
+		warn("system_margin_db", link.SystemMarginDb, "System margin above 3 dB on a link with itemised cable allowances")
+	}
+}
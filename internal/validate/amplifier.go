@@ -0,0 +1,61 @@
+package validate
+
+import (
+	"strconv"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define amplifier plausibility limits
+const (
+	MinPlausibleNoiseFigureDb = 3.0  // Quantum limit of a high-gain amplifier
+	MaxPlausibleAmpGainDb     = 40.0 // Above typical EDFA small-signal gain
+)
+
+// Define function to validate the amplifier chain of a link
+func validateAmplifiers(link model.LinkInput, row int) []model.RowError {
+	var errs []model.RowError
+	fail := func(i int, value float64, msg string) {
+		errs = append(errs, model.RowError{
+			Row: row, Line: link.Line, Field: "amplifiers",
+			Value: formatValue(value), Message: "Amplifier " + strconv.Itoa(i+1) + ": " + msg,
+		})
+	}
+	for i, amp := range link.Amplifiers {
+		if amp.Type != model.AmplifierEDFA && amp.Type != model.AmplifierSOA {
+			errs = append(errs, model.RowError{
+				Row: row, Line: link.Line, Field: "amplifiers", Value: amp.Type,
+				Message: "Amplifier " + strconv.Itoa(i+1) + ": type must be edfa or soa",
+			})
+		}
+		if amp.PositionKm < 0 || amp.PositionKm > link.FiberLengthKm {
+			fail(i, amp.PositionKm, "position must be within the fiber length")
+		}
+		if amp.GainDb < 0 {
+			fail(i, amp.GainDb, "gain has to be zero or greater")
+		}
+		if amp.NoiseFigureDb < 0 {
+			fail(i, amp.NoiseFigureDb, "noise figure has to be zero or greater")
+		}
+	}
+	if link.RequiredOSNRDb < 0 {
+		errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "required_osnr_db", Value: formatValue(link.RequiredOSNRDb), Message: "Required OSNR has to be zero or greater"})
+	}
+	return errs
+}
+
+// Define function to flag suspicious amplifier parameters as warnings
+func checkAmplifierPlausibility(link model.LinkInput, warn func(field string, value float64, msg string)) {
+	for i, amp := range link.Amplifiers {
+		n := strconv.Itoa(i + 1)
+		if amp.NoiseFigureDb > 0 && amp.NoiseFigureDb < MinPlausibleNoiseFigureDb {
+			warn("amplifiers", amp.NoiseFigureDb, "Amplifier "+n+": noise figure below the 3 dB quantum limit")
+		}
+		if amp.GainDb > MaxPlausibleAmpGainDb {
+			warn("amplifiers", amp.GainDb, "Amplifier "+n+": gain above 40 dB")
+		}
+	}
+	if link.RequiredOSNRDb > 0 && len(link.Amplifiers) == 0 {
+		warn("required_osnr_db", link.RequiredOSNRDb, "Required OSNR set on a link without amplifiers")
+	}
+}
@@ -0,0 +1,32 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define function to validate the receiver BER model of a link
+func validateBER(link model.LinkInput, row int) []model.RowError {
+	var errs []model.RowError
+	fail := func(field string, value float64, msg string) {
+		errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: field, Value: formatValue(value), Message: msg})
+	}
+	if link.SensitivityBER < 0 || link.SensitivityBER >= 0.5 {
+		fail("sensitivity_ber", link.SensitivityBER, "Reference BER must be between 0 and 0.5")
+	}
+	if link.TargetBER < 0 || link.TargetBER >= 0.5 {
+		fail("target_ber", link.TargetBER, "Target BER must be between 0 and 0.5")
+	}
+	if link.QSlope < 0 || link.QSlope > 2 {
+		fail("q_slope", link.QSlope, "Q slope must be between 0 and 2 (1 thermal, 0.5 shot noise)")
+	}
+	if _, ok := calc.LookupFEC(link.FEC); !ok {
+		errs = append(errs, model.RowError{
+			Row: row, Line: link.Line, Field: "fec", Value: link.FEC,
+			Message: "Unknown FEC code (available: " + strings.Join(calc.FECNames(), ", ") + ")",
+		})
+	}
+	return errs
+}
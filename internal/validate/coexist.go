@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/coexist"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define coexistence plausibility limits
+const MaxPlausibleFilterLossDb = 3.0 // Above a typical CEx or WDM1r insertion loss
+
+// Define function to validate the services a link carries
+func validateServices(link model.LinkInput, row int) []model.RowError {
+	var errs []model.RowError
+	fail := func(value, msg string) {
+		errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "services", Value: value, Message: msg})
+	}
+	if len(link.Services) > 0 && len(link.Channels) > 0 {
+		fail("", "A link carries either WDM channels or PON services, not both")
+	}
+	seen := make(map[string]bool, len(link.Services))
+	for _, entry := range link.Services {
+		svc, err := coexist.Resolve(entry)
+		if err != nil {
+			fail(entry.Name, err.Error())
+			continue
+		}
+		if seen[svc.Name] {
+			fail(entry.Name, "Service listed twice")
+		}
+		seen[svc.Name] = true
+		if svc.CExLossDb < 0 || svc.WDM1rLossDb < 0 {
+			fail(entry.Name, "Filter insertion loss has to be zero or greater")
+		}
+	}
+	return errs
+}
+
+// Define function to flag suspicious coexistence filter losses
+func checkServicePlausibility(link model.LinkInput, warn func(field string, value float64, msg string)) {
+	for _, entry := range link.Services {
+		svc, err := coexist.Resolve(entry)
+		if err != nil {
+			continue
+		}
+		if svc.CExLossDb+svc.WDM1rLossDb > MaxPlausibleFilterLossDb {
+			warn("services", svc.CExLossDb+svc.WDM1rLossDb, "Service "+svc.Name+": filter loss above 3 dB")
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package validate
+
+import (
+	"strconv"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define function to validate relations between rows: duplicate keys,
+// splitter port usage and declared scenario names
+func ValidateDataset(links []model.LinkInput, opt ValidationOptions) []model.RowError {
+	var errs []model.RowError
+	errs = append(errs, checkDuplicateKeys(links)...)
+	errs = append(errs, checkSplitterPorts(links)...)
+	if len(opt.Scenarios) > 0 {
+		errs = append(errs, checkScenarios(links, opt.Scenarios)...)
+	}
+	return errs
+}
+
+// Define function to flag repeated link_id + scenario pairs
+func checkDuplicateKeys(links []model.LinkInput) []model.RowError {
+	var errs []model.RowError
+	seen := make(map[[2]string][]int)
+	for i, link := range links {
+		key := [2]string{link.LinkID, link.Scenario}
+		if prev := seen[key]; len(prev) > 0 {
+			errs = append(errs, model.RowError{
+				Row: link.RowNumber(i), Line: link.Line, Field: "link_id", Value: link.LinkID,
+				Message: "Duplicate link_id and scenario " + strconv.Quote(link.Scenario),
+				Refs:    append([]int(nil), prev...),
+			})
+		}
+		seen[key] = append(seen[key], link.RowNumber(i))
+	}
+	return errs
+}
+
+// Define function to check links sharing a splitter against its ratio
+func checkSplitterPorts(links []model.LinkInput) []model.RowError {
+	var errs []model.RowError
+
+	// Group rows by scenario and splitter, since each scenario is its own design
+	type splitterKey struct{ scenario, id string }
+	groups := make(map[splitterKey][]int)
+	var order []splitterKey
+	for i, link := range links {
+		if link.SplitterID == "" {
+			continue
+		}
+		k := splitterKey{link.Scenario, link.SplitterID}
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], i)
+	}
+
+	for _, k := range order {
+		idx := groups[k]
+		ratio := 0
+		ports := make(map[int][]int)
+		for n, i := range idx {
+			link := links[i]
+			row := link.RowNumber(i)
+
+			// Ratio must agree across rows of the same splitter
+			if link.SplitRatio > 0 {
+				if ratio == 0 {
+					ratio = link.SplitRatio
+				} else if link.SplitRatio != ratio {
+					errs = append(errs, model.RowError{
+						Row: row, Line: link.Line, Field: "split_ratio", Value: strconv.Itoa(link.SplitRatio),
+						Message: "Split ratio differs from other rows of splitter " + strconv.Quote(k.id),
+						Refs:    rowsOf(links, idx[:n]),
+					})
+				}
+			}
+
+			// Port must exist and be used once
+			if link.SplitterPort != 0 {
+				if link.SplitterPort < 0 || (ratio > 0 && link.SplitterPort > ratio) {
+					errs = append(errs, model.RowError{
+						Row: row, Line: link.Line, Field: "splitter_port", Value: strconv.Itoa(link.SplitterPort),
+						Message: "Port outside splitter " + strconv.Quote(k.id) + " ratio 1:" + strconv.Itoa(ratio),
+					})
+				}
+				if prev := ports[link.SplitterPort]; len(prev) > 0 {
+					errs = append(errs, model.RowError{
+						Row: row, Line: link.Line, Field: "splitter_port", Value: strconv.Itoa(link.SplitterPort),
+						Message: "Port already used on splitter " + strconv.Quote(k.id),
+						Refs:    rowsOf(links, prev),
+					})
+				}
+				ports[link.SplitterPort] = append(ports[link.SplitterPort], i)
+			}
+
+			// Number of links must not exceed the ratio
+			if ratio > 0 && n+1 > ratio {
+				errs = append(errs, model.RowError{
+					Row: row, Line: link.Line, Field: "splitter_id", Value: k.id,
+					Message: "More links than splitter ratio 1:" + strconv.Itoa(ratio),
+					Refs:    rowsOf(links, idx[:n]),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// Define function to flag scenario names missing from the declared list
+func checkScenarios(links []model.LinkInput, scenarios []string) []model.RowError {
+	var errs []model.RowError
+	declared := make(map[string]bool, len(scenarios))
+	for _, s := range scenarios {
+		declared[s] = true
+	}
+	for i, link := range links {
+		if !declared[link.Scenario] {
+			errs = append(errs, model.RowError{
+				Row: link.RowNumber(i), Line: link.Line, Field: "scenario", Value: link.Scenario,
+				Message: "Scenario is not in the declared scenario list",
+			})
+		}
+	}
+	return errs
+}
+
+// Define helper to turn slice indices into the row numbers of those links
+func rowsOf(links []model.LinkInput, idx []int) []int {
+	rows := make([]int, len(idx))
+	for i, v := range idx {
+		rows[i] = links[v].RowNumber(v)
+	}
+	return rows
+}
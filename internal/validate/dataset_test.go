@@ -0,0 +1,31 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+func TestSplitterRefsUseSourceRows(t *testing.T) {
+	// Rows 1 and 3 of the file were skipped, so the links sit on rows 2, 4 and 5
+	links := []model.LinkInput{
+		{Row: 2, LinkID: "A", SplitterID: "S1", SplitterPort: 1, SplitRatio: 2},
+		{Row: 4, LinkID: "B", SplitterID: "S1", SplitterPort: 1, SplitRatio: 4},
+		{Row: 5, LinkID: "C", SplitterID: "S1", SplitterPort: 2, SplitRatio: 2},
+	}
+	want := map[string][]int{
+		"split_ratio":   {2},    // B disagrees with A
+		"splitter_port": {2},    // B reuses A's port
+		"splitter_id":   {2, 4}, // C is the third link on a 1:2 splitter
+	}
+	for _, e := range checkSplitterPorts(links) {
+		if refs, ok := want[e.Field]; !ok || !reflect.DeepEqual(e.Refs, refs) {
+			t.Errorf("%s on row %d refers to rows %v; want %v", e.Field, e.Row, e.Refs, refs)
+		}
+		delete(want, e.Field)
+	}
+	for field := range want {
+		t.Errorf("no %s error", field)
+	}
+}
@@ -0,0 +1,263 @@
+package validate
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define compiled rule expression evaluated against a link.
+// Booleans are represented as 1 (true) and 0 (false).
+type Expr interface {
+	Eval(link model.LinkInput) float64
+}
+
+// Define expression node types
+type numberExpr float64
+type fieldExpr string
+type unaryExpr struct {
+	op string
+	x  Expr
+}
+type binaryExpr struct {
+	op   string
+	l, r Expr
+}
+
+// Define evaluation for each node type
+func (n numberExpr) Eval(model.LinkInput) float64 { return float64(n) }
+
+func (f fieldExpr) Eval(link model.LinkInput) float64 {
+	v, _ := FieldValue(link, string(f))
+	return v
+}
+
+func (u unaryExpr) Eval(link model.LinkInput) float64 {
+	x := u.x.Eval(link)
+	if u.op == "!" {
+		return boolValue(x == 0)
+	}
+	return -x
+}
+
+func (b binaryExpr) Eval(link model.LinkInput) float64 {
+	l := b.l.Eval(link)
+	// Short-circuit logical operators
+	switch b.op {
+	case "&&":
+		return boolValue(l != 0 && b.r.Eval(link) != 0)
+	case "||":
+		return boolValue(l != 0 || b.r.Eval(link) != 0)
+	}
+	r := b.r.Eval(link)
+	switch b.op {
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case "/":
+		return l / r
+	case "<":
+		return boolValue(l < r)
+	case "<=":
+		return boolValue(l <= r)
+	case ">":
+		return boolValue(l > r)
+	case ">=":
+		return boolValue(l >= r)
+	case "==":
+		return boolValue(l == r)
+	case "!=":
+		return boolValue(l != r)
+	}
+	return 0
+}
+
+// Define helper to convert a bool into the expression number form
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Define function to compile an expression such as "n_splice >= fiber_length_km / 4".
+// Supported: numbers, link field names, + - * /, comparisons, && || ! (or and/or/not) and parentheses.
+func CompileExpr(src string) (Expr, error) {
+	toks, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{toks: toks}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, errors.New("Unexpected token " + strconv.Quote(p.toks[p.pos]) + " in expression: " + src)
+	}
+	return e, nil
+}
+
+// Define function to split an expression into tokens
+func tokenize(src string) ([]string, error) {
+	var toks []string
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		c := rs[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			j := i
+			for j < len(rs) && (unicode.IsDigit(rs[j]) || rs[j] == '.' || rs[j] == 'e' || rs[j] == 'E' ||
+				((rs[j] == '+' || rs[j] == '-') && j > i && (rs[j-1] == 'e' || rs[j-1] == 'E'))) {
+				j++
+			}
+			toks = append(toks, string(rs[i:j]))
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '_') {
+				j++
+			}
+			// Word operators map onto their symbol form
+			word := string(rs[i:j])
+			switch strings.ToLower(word) {
+			case "and":
+				word = "&&"
+			case "or":
+				word = "||"
+			case "not":
+				word = "!"
+			}
+			toks = append(toks, word)
+			i = j
+		default:
+			if i+1 < len(rs) {
+				two := string(rs[i : i+2])
+				switch two {
+				case "<=", ">=", "==", "!=", "&&", "||":
+					toks = append(toks, two)
+					i += 2
+					continue
+				}
+			}
+			if strings.ContainsRune("+-*/()<>!", c) {
+				toks = append(toks, string(c))
+				i++
+				continue
+			}
+			return nil, errors.New("Invalid character " + strconv.QuoteRune(c) + " in expression: " + src)
+		}
+	}
+	return toks, nil
+}
+
+// Define recursive-descent parser over tokens
+type exprParser struct {
+	toks []string
+	pos  int
+}
+
+// Define function to peek the current token
+func (p *exprParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+// Define function to parse a left-associative binary level
+func (p *exprParser) parseBinary(ops []string, next func() (Expr, error)) (Expr, error) {
+	l, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		matched := false
+		for _, o := range ops {
+			if op == o {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return l, nil
+		}
+		p.pos++
+		r, err := next()
+		if err != nil {
+			return nil, err
+		}
+		l = binaryExpr{op: op, l: l, r: r}
+	}
+}
+
+func (p *exprParser) parseOr() (Expr, error) {
+	return p.parseBinary([]string{"||"}, p.parseAnd)
+}
+
+func (p *exprParser) parseAnd() (Expr, error) {
+	return p.parseBinary([]string{"&&"}, p.parseCompare)
+}
+
+func (p *exprParser) parseCompare() (Expr, error) {
+	return p.parseBinary([]string{"<", "<=", ">", ">=", "==", "!="}, p.parseSum)
+}
+
+func (p *exprParser) parseSum() (Expr, error) {
+	return p.parseBinary([]string{"+", "-"}, p.parseProduct)
+}
+
+func (p *exprParser) parseProduct() (Expr, error) {
+	return p.parseBinary([]string{"*", "/"}, p.parseUnary)
+}
+
+func (p *exprParser) parseUnary() (Expr, error) {
+	switch op := p.peek(); op {
+	case "-", "!":
+		p.pos++
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryExpr{op: op, x: x}, nil
+	case "+":
+		p.pos++
+		return p.parseUnary()
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (Expr, error) {
+	tok := p.peek()
+	if tok == "" {
+		return nil, errors.New("Unexpected end of expression")
+	}
+	p.pos++
+	if tok == "(" {
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, errors.New("Missing closing parenthesis")
+		}
+		p.pos++
+		return e, nil
+	}
+	if v, err := strconv.ParseFloat(tok, 64); err == nil {
+		return numberExpr(v), nil
+	}
+	if _, ok := linkFields[tok]; ok {
+		return fieldExpr(tok), nil
+	}
+	return nil, errors.New("Unknown field " + strconv.Quote(tok) + " in expression")
+}
@@ -0,0 +1,30 @@
+package validate
+
+import "github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+
+// Define map of numeric link fields addressable by rules, keyed by CSV column name
+var linkFields = map[string]func(model.LinkInput) float64{
+	"tx_power_dbm":        func(l model.LinkInput) float64 { return l.TXPowerDbm },
+	"rx_sensitivity_dbm":  func(l model.LinkInput) float64 { return l.RXSensitivityDbm },
+	"system_margin_db":    func(l model.LinkInput) float64 { return l.SystemMarginDb },
+	"fiber_length_km":     func(l model.LinkInput) float64 { return l.FiberLengthKm },
+	"fiber_att_db_per_km": func(l model.LinkInput) float64 { return l.FiberAttDbPerKm },
+	"n_splice":            func(l model.LinkInput) float64 { return float64(l.NSplice) },
+	"splice_loss_db":      func(l model.LinkInput) float64 { return l.SpliceLossDb },
+	"n_connector":         func(l model.LinkInput) float64 { return float64(l.NConnectors) },
+	"connector_loss_db":   func(l model.LinkInput) float64 { return l.ConnectorLossDb },
+	"splitter_loss_db":    func(l model.LinkInput) float64 { return l.SplitterLossDb },
+	"other_loss_db":       func(l model.LinkInput) float64 { return l.OtherLossDb },
+	"wavelength_nm":       func(l model.LinkInput) float64 { return l.WavelengthNm },
+	"splitter_port":       func(l model.LinkInput) float64 { return float64(l.SplitterPort) },
+	"split_ratio":         func(l model.LinkInput) float64 { return float64(l.SplitRatio) },
+}
+
+// Define function to read a numeric link field by column name
+func FieldValue(link model.LinkInput, name string) (float64, bool) {
+	get, ok := linkFields[name]
+	if !ok {
+		return 0, false
+	}
+	return get(link), true
+}
@@ -0,0 +1,80 @@
+package validate
+
+import (
+	"strconv"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/coexist"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define nonlinear plausibility limits
+const (
+	MinPlausibleEffectiveAreaUm2 = 20.0    // Below dispersion-compensating fiber
+	MaxPlausibleEffectiveAreaUm2 = 200.0   // Above large-area submarine fiber
+	MaxPlausibleLinewidthMHz     = 50000.0 // Beyond SBS suppression dithering
+	MinUncheckedLaunchDbm        = 10.0    // Launch power worth a nonlinear check
+)
+
+// Define function to validate the nonlinear threshold inputs of a link
+func validateNonlinear(link model.LinkInput, row int) []model.RowError {
+	var errs []model.RowError
+	fail := func(field string, value float64, msg string) {
+		errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: field, Value: formatValue(value), Message: msg})
+	}
+	if link.SourceLinewidthMHz < 0 {
+		fail("source_linewidth_mhz", link.SourceLinewidthMHz, "Linewidth has to be zero or greater")
+	}
+	if link.EffectiveAreaUm2 < 0 {
+		fail("effective_area_um2", link.EffectiveAreaUm2, "Effective area has to be zero or greater")
+	}
+	return errs
+}
+
+// Define function to flag launch powers above the SBS and SPM thresholds and
+// nonlinear inputs that look suspicious
+func checkNonlinearPlausibility(link model.LinkInput, warn func(field string, value float64, msg string)) {
+	if link.EffectiveAreaUm2 > 0 && (link.EffectiveAreaUm2 < MinPlausibleEffectiveAreaUm2 || link.EffectiveAreaUm2 > MaxPlausibleEffectiveAreaUm2) {
+		warn("effective_area_um2", link.EffectiveAreaUm2, "Effective area outside 20–200 µm²")
+	}
+	if link.SourceLinewidthMHz > MaxPlausibleLinewidthMHz {
+		warn("source_linewidth_mhz", link.SourceLinewidthMHz, "Linewidth above 50 GHz")
+	}
+	if link.SourceLinewidthMHz <= 0 {
+		if link.TXPowerDbm >= MinUncheckedLaunchDbm && len(link.Services) == 0 {
+			warn("tx_power_dbm", link.TXPowerDbm, "Launch power of 10 dBm or more without source_linewidth_mhz; SBS and SPM not checked")
+		}
+		return
+	}
+
+	// Each service direction launches its own power into the fiber
+	links := []model.LinkInput{link}
+	if len(link.Services) > 0 {
+		links = nil
+		for _, entry := range link.Services {
+			svc, err := coexist.Resolve(entry)
+			if err != nil {
+				continue
+			}
+			for _, path := range svc.Paths {
+				links = append(links, calc.ServiceLink(link, svc, path))
+			}
+		}
+	}
+	for _, l := range links {
+		in, err := calc.LinkNonlinear(l)
+		if err != nil {
+			continue // Reported by the amplifier checks
+		}
+		nl, err := calc.CalculateNonlinear(in)
+		if err != nil {
+			continue
+		}
+		if nl.SBSPenaltyDb > 0 {
+			warn("tx_power_dbm", nl.LaunchDbm, "Launch power above the SBS threshold of "+strconv.FormatFloat(nl.SBSThresholdDbm, 'f', 1, 64)+" dBm")
+		}
+		if nl.SPMPenaltyDb > 0 {
+			warn("tx_power_dbm", nl.LaunchDbm, "Nonlinear phase of "+strconv.FormatFloat(nl.NonlinearPhaseRad, 'f', 2, 64)+" rad, above the SPM threshold of "+strconv.FormatFloat(nl.SPMThresholdDbm, 'f', 1, 64)+" dBm per span")
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define reflectance plausibility limits
+const MinPlausibleReflectanceDb = -20.0 // Above this an event reflects like an open end (-14.7 dB)
+
+// Define function to validate the reflectance inputs of a link
+func validateORL(link model.LinkInput, row int) []model.RowError {
+	var errs []model.RowError
+	fail := func(field string, value float64, msg string) {
+		errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: field, Value: formatValue(value), Message: msg})
+	}
+	if link.ConnectorType != "" {
+		if _, err := calc.ConnectorReflectanceDb(link.ConnectorType, 0); err != nil {
+			errs = append(errs, model.RowError{
+				Row: row, Line: link.Line, Field: "connector_type", Value: link.ConnectorType,
+				Message: "Unknown connector type (available: pc, upc, apc)",
+			})
+		}
+	}
+	if link.ConnectorReflectanceDb > 0 {
+		fail("connector_reflectance_db", link.ConnectorReflectanceDb, "Reflectance has to be negative")
+	}
+	if link.SpliceReflectanceDb > 0 {
+		fail("splice_reflectance_db", link.SpliceReflectanceDb, "Reflectance has to be negative")
+	}
+	if link.ORLToleranceDb < 0 {
+		fail("orl_tolerance_db", link.ORLToleranceDb, "ORL tolerance has to be zero or greater")
+	}
+	return errs
+}
+
+// Define function to flag reflectance inputs that look suspicious
+func checkORLPlausibility(link model.LinkInput, warn func(field string, value float64, msg string), warnText func(field, value, msg string)) {
+	if link.ConnectorReflectanceDb < 0 && link.ConnectorReflectanceDb > MinPlausibleReflectanceDb {
+		warn("connector_reflectance_db", link.ConnectorReflectanceDb, "Connector reflectance above -20 dB, close to an open end")
+	}
+	if link.SpliceReflectanceDb < 0 && link.SpliceReflectanceDb > MinPlausibleReflectanceDb {
+		warn("splice_reflectance_db", link.SpliceReflectanceDb, "Splice reflectance above -20 dB, close to an open end")
+	}
+
+	// Analog video is sensitive to reflections and needs angled connectors.
+	// Only declared connectors are checked: the UPC default is an assumption.
+	if link.ConnectorType == "" && link.ConnectorReflectanceDb == 0 {
+		return
+	}
+	for _, svc := range link.Services {
+		if !strings.EqualFold(svc.Name, "rf-video") {
+			continue
+		}
+		r, err := calc.ConnectorReflectanceDb(link.ConnectorType, link.ConnectorReflectanceDb)
+		if err != nil || r <= calc.ConnectorReflectance["apc"] {
+			continue
+		}
+		if link.ConnectorReflectanceDb != 0 {
+			warn("connector_reflectance_db", link.ConnectorReflectanceDb, "RF video overlay over connectors reflecting above -65 dB; APC is expected")
+		} else {
+			warnText("connector_type", link.ConnectorType, "RF video overlay over "+strings.ToUpper(link.ConnectorType)+" connectors ("+formatValue(r)+" dB reflectance); APC is expected")
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define penalty plausibility limits
+const (
+	MinPlausibleExtinctionRatioDb = 6.0    // Below the minimum of common PON and Ethernet optics
+	MaxPlausibleRINDbHz           = -110.0 // Noisier than a Fabry-Perot laser
+)
+
+// Define function to validate the power penalty inputs of a link
+func validatePenalties(link model.LinkInput, row int) []model.RowError {
+	var errs []model.RowError
+	fail := func(field string, value float64, msg string) {
+		errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: field, Value: formatValue(value), Message: msg})
+	}
+	supplied := []struct {
+		name  string
+		value float64
+	}{
+		{"dispersion_penalty_db", link.DispersionPenaltyDb},
+		{"er_penalty_db", link.ExtinctionPenaltyDb},
+		{"rin_penalty_db", link.RINPenaltyDb},
+		{"mpn_penalty_db", link.MPNPenaltyDb},
+		{"reflection_penalty_db", link.ReflectionPenaltyDb},
+		{"chirp_penalty_db", link.ChirpPenaltyDb},
+	}
+	for _, p := range supplied {
+		if p.value < 0 {
+			fail(p.name, p.value, "Penalty has to be zero or greater")
+		}
+	}
+	if link.SpectralWidthNm < 0 {
+		fail("spectral_width_nm", link.SpectralWidthNm, "Spectral width has to be zero or greater")
+	}
+	if link.ExtinctionRatioDb < 0 {
+		fail("extinction_ratio_db", link.ExtinctionRatioDb, "Extinction ratio has to be zero or greater")
+	}
+	if link.RINDbHz > 0 {
+		fail("rin_db_hz", link.RINDbHz, "RIN has to be negative (dB/Hz)")
+	}
+	if link.MPNFactor < 0 || link.MPNFactor > 1 {
+		fail("mpn_k", link.MPNFactor, "Mode partition coefficient must be between 0 and 1")
+	}
+	return errs
+}
+
+// Define function to flag penalty inputs that cannot take effect or look suspicious
+func checkPenaltyPlausibility(link model.LinkInput, warn func(field string, value float64, msg string)) {
+	if link.SpectralWidthNm > 0 && link.DispersionPsNmKm == 0 && link.WavelengthNm == 0 && len(link.Channels) == 0 {
+		warn("spectral_width_nm", link.SpectralWidthNm, "Spectral width without wavelength_nm or dispersion_ps_nm_km; dispersion penalty not computed")
+	}
+	if link.MPNFactor > 0 && link.SpectralWidthNm == 0 {
+		warn("mpn_k", link.MPNFactor, "Mode partition coefficient without spectral_width_nm; MPN penalty not computed")
+	}
+	if link.ExtinctionRatioDb > 0 && link.ExtinctionRatioDb < MinPlausibleExtinctionRatioDb {
+		warn("extinction_ratio_db", link.ExtinctionRatioDb, "Extinction ratio below 6 dB")
+	}
+	if link.RINDbHz < 0 && link.RINDbHz > MaxPlausibleRINDbHz {
+		warn("rin_db_hz", link.RINDbHz, "RIN above -110 dB/Hz")
+	}
+}
@@ -0,0 +1,42 @@
+package validate
+
+import (
+	"math"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/fiber"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/phy"
+)
+
+// Define PHY plausibility limits
+const MaxPHYWavelengthOffsetNm = 20.0 // Beyond the center wavelength range of an SR transmitter
+
+// Define function to validate the Ethernet PHY of a link
+func validatePHY(link model.LinkInput, row int) []model.RowError {
+	var errs []model.RowError
+	if link.PHY == "" {
+		return errs
+	}
+	fail := func(field, value, msg string) {
+		errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: field, Value: value, Message: msg})
+	}
+	if _, ok := phy.Lookup(link.PHY); !ok {
+		fail("phy", link.PHY, "Unknown PHY (available: "+strings.Join(phy.Names(), ", ")+")")
+	}
+	if t, ok := fiber.Lookup(link.FiberType); !ok || !t.Multimode {
+		fail("fiber_type", link.FiberType, "A multimode PHY needs a multimode fiber_type (OM1–OM5)")
+	}
+	return errs
+}
+
+// Define function to flag PHY inputs that look suspicious
+func checkPHYPlausibility(link model.LinkInput, warn func(field string, value float64, msg string)) {
+	p, ok := phy.Lookup(link.PHY)
+	if !ok {
+		return
+	}
+	if link.WavelengthNm > 0 && math.Abs(link.WavelengthNm-p.WavelengthNm) > MaxPHYWavelengthOffsetNm {
+		warn("wavelength_nm", link.WavelengthNm, p.Name+" transmits at "+formatValue(p.WavelengthNm)+" nm")
+	}
+}
@@ -0,0 +1,169 @@
+package validate
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/fiber"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define plausibility limits for common planning mistakes
+const (
+	MinPlausibleTxPowerDbm  = -10.0
+	MaxPlausibleTxPowerDbm  = 10.0
+	MaxPlausibleConnLossDb  = 0.75 // TIA-568 maximum per mated pair
+	MaxPlausiblePMDPsSqrtKm = 1.0  // Worse than legacy pre-1995 single-mode plant
+)
+
+// Define struct for a standard splitter ratio and its insertion loss window
+type splitRatio struct {
+	Ports int
+	MinDb float64 // Ideal split loss, 10*log10(N)
+	MaxDb float64 // Typical maximum including excess loss and uniformity
+}
+
+// Define standard 1:N splitter ratios
+var standardSplits = []splitRatio{
+	{2, 3.0, 4.2},
+	{4, 6.0, 7.8},
+	{8, 9.0, 11.0},
+	{16, 12.0, 14.2},
+	{32, 15.0, 17.8},
+	{64, 18.0, 21.5},
+	{128, 21.0, 25.0},
+}
+
+// Define function to flag physically suspicious inputs as warnings
+func CheckPlausibility(links []model.LinkInput) []model.RowError {
+	var errs []model.RowError
+	for i, link := range links {
+		row := link.RowNumber(i)
+		warnText := func(field, value, msg string) {
+			errs = append(errs, model.RowError{
+				Row: row, Line: link.Line, Field: field,
+				Value: value, Message: msg,
+				Severity: model.SeverityWarning,
+			})
+		}
+		warn := func(field string, value float64, msg string) {
+			warnText(field, formatValue(value), msg)
+		}
+
+		// Transmitter power and receiver sensitivity, taken from the services on coexistence links
+		if len(link.Services) == 0 {
+			if link.TXPowerDbm < MinPlausibleTxPowerDbm || link.TXPowerDbm > MaxPlausibleTxPowerDbm {
+				warn("tx_power_dbm", link.TXPowerDbm, "Tx power outside -10…+10 dBm")
+			}
+			if link.RXSensitivityDbm >= link.TXPowerDbm {
+				warn("rx_sensitivity_dbm", link.RXSensitivityDbm, "Rx sensitivity is not below Tx power")
+			}
+		}
+
+		// Per-connector loss
+		if link.ConnectorLossDb > MaxPlausibleConnLossDb {
+			warn("connector_loss_db", link.ConnectorLossDb, "Per-connector loss above 0.75 dB")
+		}
+
+		// Splitter loss against standard ratios; below the 1:2 split the value is
+		// a tap coupler, WDM filter or patch loss booked in the splitter column
+		if link.SplitterLossDb >= standardSplits[0].MinDb {
+			if ok, nearest := matchSplitRatio(link.SplitterLossDb); !ok {
+				warn("splitter_loss_db", link.SplitterLossDb,
+					"Splitter loss matches no standard split ratio (nearest 1:"+strconv.Itoa(nearest.Ports)+
+						", "+formatValue(nearest.MinDb)+"–"+formatValue(nearest.MaxDb)+" dB)")
+			}
+		}
+
+		// Amplifier parameters
+		checkAmplifierPlausibility(link, warn)
+
+		// Power penalty parameters
+		checkPenaltyPlausibility(link, warn)
+
+		// Coexistence services
+		checkServicePlausibility(link, warn)
+
+		// Connector and splice reflectance
+		checkORLPlausibility(link, warn, warnText)
+
+		// Nonlinear thresholds
+		checkNonlinearPlausibility(link, warn)
+
+		// Multimode Ethernet PHY
+		checkPHYPlausibility(link, warn)
+
+		// Margin allowances
+		checkAllowancePlausibility(link, warn)
+
+		// PMD coefficient
+		if link.PMDPsSqrtKm > MaxPlausiblePMDPsSqrtKm {
+			warn("pmd_ps_sqrt_km", link.PMDPsSqrtKm, "PMD coefficient above 1 ps/√km")
+		}
+
+		// Attenuation against declared fiber type and wavelength
+		ft := fiber.Default
+		if link.FiberType != "" {
+			t, ok := fiber.Lookup(link.FiberType)
+			if !ok {
+				errs = append(errs, model.RowError{
+					Row: row, Line: link.Line, Field: "fiber_type", Value: link.FiberType,
+					Message: "Unknown fiber type", Severity: model.SeverityWarning,
+				})
+				continue
+			}
+			ft = t
+		}
+
+		// WDM channel plan against the fiber's operating bands
+		checkWDMPlausibility(link, ft, warn)
+
+		if link.WavelengthNm > 0 {
+			band, ok := ft.BandFor(link.WavelengthNm)
+			if !ok {
+				warn("wavelength_nm", link.WavelengthNm, "Wavelength outside the operating bands of "+ft.Name)
+				continue
+			}
+			if link.FiberAttDbPerKm < band.MinAttDbPerKm || link.FiberAttDbPerKm > band.MaxAttDbPerKm {
+				warn("fiber_att_db_per_km", link.FiberAttDbPerKm,
+					"Attenuation implausible for "+ft.Name+" at "+formatValue(link.WavelengthNm)+" nm (expected "+
+						formatValue(band.MinAttDbPerKm)+"–"+formatValue(band.MaxAttDbPerKm)+" dB/km)")
+			}
+		} else if link.FiberType != "" {
+			// Without a wavelength only the whole operating range can be checked
+			lo, hi := math.Inf(1), math.Inf(-1)
+			for _, b := range ft.Bands {
+				lo = math.Min(lo, b.MinAttDbPerKm)
+				hi = math.Max(hi, b.MaxAttDbPerKm)
+			}
+			if link.FiberAttDbPerKm < lo || link.FiberAttDbPerKm > hi {
+				warn("fiber_att_db_per_km", link.FiberAttDbPerKm,
+					"Attenuation implausible for "+ft.Name+" (expected "+formatValue(lo)+"–"+formatValue(hi)+" dB/km)")
+			}
+		}
+	}
+	return errs
+}
+
+// Define function to match a splitter loss to a standard ratio
+func matchSplitRatio(lossDb float64) (bool, splitRatio) {
+	nearest := standardSplits[0]
+	best := math.Inf(1)
+	for _, s := range standardSplits {
+		if lossDb >= s.MinDb && lossDb <= s.MaxDb {
+			return true, s
+		}
+		// Distance to the window for the hint
+		d := math.Min(math.Abs(lossDb-s.MinDb), math.Abs(lossDb-s.MaxDb))
+		if d < best {
+			best = d
+			nearest = s
+		}
+	}
+	return false, nearest
+}
+
+// Define helper to format a value for messages
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,156 @@
+package validate
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Define struct for a single validation rule. A rule is either a range check
+// on one field (min/max) or a cross-field expression that must hold.
+type Rule struct {
+	Name     string   `yaml:"name"`
+	Field    string   `yaml:"field"`
+	Min      *float64 `yaml:"min"`
+	Max      *float64 `yaml:"max"`
+	Expr     string   `yaml:"expr"`
+	Severity string   `yaml:"severity"`
+	Message  string   `yaml:"message"`
+
+	compiled Expr
+}
+
+// Define struct for the rules file layout
+type ruleFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Define function to load validation rules from a YAML file
+func LoadRules(path string) ([]Rule, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("Failed to open rules file: " + err.Error())
+	}
+	var file ruleFile
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return nil, errors.New("Failed to parse rules file: " + err.Error())
+	}
+	for i := range file.Rules {
+		if err := file.Rules[i].compile(); err != nil {
+			return nil, errors.New("Rule " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+	}
+	return file.Rules, nil
+}
+
+// Define function to check and prepare a rule for evaluation
+func (r *Rule) compile() error {
+	// Normalize severity, defaulting to error
+	r.Severity = strings.ToLower(strings.TrimSpace(r.Severity))
+	switch r.Severity {
+	case "":
+		r.Severity = model.SeverityError
+	case model.SeverityError, model.SeverityWarning, model.SeverityInfo:
+	default:
+		return errors.New("Unknown severity " + strconv.Quote(r.Severity))
+	}
+
+	// Check rule form
+	hasRange := r.Min != nil || r.Max != nil
+	switch {
+	case hasRange && r.Expr != "":
+		return errors.New("Use either min/max or expr, not both")
+	case hasRange:
+		if _, ok := linkFields[r.Field]; !ok {
+			return errors.New("Unknown field " + strconv.Quote(r.Field))
+		}
+	case r.Expr != "":
+		e, err := CompileExpr(r.Expr)
+		if err != nil {
+			return err
+		}
+		r.compiled = e
+	default:
+		return errors.New("Rule needs min/max or expr")
+	}
+	return nil
+}
+
+// Define function to evaluate rules against each link
+func EvaluateRules(links []model.LinkInput, rules []Rule) []model.RowError {
+	var errs []model.RowError
+	for i, link := range links {
+		row := link.RowNumber(i)
+		for _, r := range rules {
+			if r.compiled == nil && r.Expr != "" {
+				// Rules built in code instead of loaded from a file
+				if err := r.compile(); err != nil {
+					errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: r.Field, Message: err.Error()})
+					continue
+				}
+			}
+			if e, failed := r.check(link); failed {
+				e.Row = row
+				e.Line = link.Line
+				errs = append(errs, e)
+			}
+		}
+	}
+	return errs
+}
+
+// Define function to check one rule against one link
+func (r Rule) check(link model.LinkInput) (model.RowError, bool) {
+	e := model.RowError{Field: r.Field, Severity: r.Severity}
+	if r.compiled != nil {
+		if r.compiled.Eval(link) != 0 {
+			return e, false
+		}
+		e.Message = r.describe("Rule failed: " + r.Expr)
+		return e, true
+	}
+
+	// Range check
+	value, _ := FieldValue(link, r.Field)
+	if (r.Min == nil || value >= *r.Min) && (r.Max == nil || value <= *r.Max) {
+		return e, false
+	}
+	e.Value = formatValue(value)
+	bounds := "["
+	if r.Min != nil {
+		bounds += formatValue(*r.Min)
+	}
+	bounds += ", "
+	if r.Max != nil {
+		bounds += formatValue(*r.Max)
+	}
+	bounds += "]"
+	e.Message = r.describe("Value outside range " + bounds)
+	return e, true
+}
+
+// Define function to build the message, preferring the rule's own text
+func (r Rule) describe(fallback string) string {
+	msg := fallback
+	if r.Message != "" {
+		msg = r.Message
+	}
+	if r.Name != "" {
+		msg = r.Name + ": " + msg
+	}
+	return msg
+}
+
+// Define function to check if any error blocks a run
+func HasBlocking(errs []model.RowError) bool {
+	for _, e := range errs {
+		if e.IsBlocking() {
+			return true
+		}
+	}
+	return false
+}
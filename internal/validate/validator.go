@@ -1,10 +1,20 @@
 package validate
 
-import "github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+import (
+	"math"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/cable"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/fiber"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
 
 // Define struct for options used in validation
 type ValidationOptions struct {
 	MaxFiberAttPerDbKm float64
+	Rules []Rule // Extra rules, usually loaded with LoadRules
+	SkipPlausibility bool // Disable physics plausibility warnings
+	Scenarios []string // Declared scenario names, empty accepts any
+	Cables map[string]cable.Allowances // Cable types from the run configuration
 }
 
 // Define function to validate link communication parameters
@@ -19,33 +29,66 @@ func ValidateLink(links []model.LinkInput, opt ValidationOptions) []model.RowErr
 
 	// Iterate over each link to validate
 	for i, link := range links {
-		row := i + 1 // Row number for error reporting
+		row := link.RowNumber(i) // Row number for error reporting
 
 		// Validate fiber attenuation
 		if link.LinkID == "" {
-			errs = append(errs, model.RowError{Row: row, Field: "link_id", Message: "Required"})
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "link_id", Message: "Required"})
 		}
 		if link.FiberLengthKm < 0 {
-			errs = append(errs, model.RowError{Row: row, Field: "fiber_length_km", Message: "Input value must be greater than zero"})
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "fiber_length_km", Message: "Input value must be greater than zero"})
+		}
+		// Multimode fiber is allowed up to its cabled limit at the wavelength
+		maxAtt := opt.MaxFiberAttPerDbKm
+		if t, ok := fiber.Lookup(link.FiberType); ok && t.Multimode {
+			maxAtt = math.Max(maxAtt, t.MaxAttenuationAt(link.WavelengthNm))
 		}
-		if link.FiberAttDbPerKm <= 0 || link.FiberAttDbPerKm > opt.MaxFiberAttPerDbKm {
-			errs = append(errs, model.RowError{Row: row, Field: "fiber_att_db_per_km", Message: "Input value must be greated than zero"})
+		if link.FiberAttDbPerKm <= 0 || link.FiberAttDbPerKm > maxAtt {
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "fiber_att_db_per_km", Message: "Input value must be greated than zero"})
 		}
 		if link.NSplice < 0 {
-			errs = append(errs, model.RowError{Row: row, Field: "n_splice", Message: "Input value must be zero or greater"})
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "n_splice", Message: "Input value must be zero or greater"})
 		}
 		if link.NConnectors < 0 {
-			errs = append(errs, model.RowError{Row: row, Field: "n_connector", Message: "Number of connector has to be greater than zero"})
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "n_connector", Message: "Number of connector has to be greater than zero"})
 		}
 		if link.ConnectorLossDb < 0 {
-			errs = append(errs, model.RowError{Row: row, Field: "connector_loss_db", Message: "Connector loss has to be zero or greater"})
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "connector_loss_db", Message: "Connector loss has to be zero or greater"})
 		}
 		if link.SplitterLossDb < 0 {
-			errs = append(errs, model.RowError{Row: row, Field: "splitter_loss_db", Message: "Splitter loss has to be zero or greater"})
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "splitter_loss_db", Message: "Splitter loss has to be zero or greater"})
 		}
 		if link.SpliceLossDb < 0 {
-			errs = append(errs, model.RowError{Row: row, Field: "splice_loss_db", Message: "Splice loss has to be zero or greater"})
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "splice_loss_db", Message: "Splice loss has to be zero or greater"})
+		}
+		errs = append(errs, validateAmplifiers(link, row)...)
+		errs = append(errs, validateBER(link, row)...)
+		errs = append(errs, validatePenalties(link, row)...)
+		errs = append(errs, validateWDM(link, row)...)
+		errs = append(errs, validateServices(link, row)...)
+		errs = append(errs, validateORL(link, row)...)
+		errs = append(errs, validateAllowances(link, row, opt.Cables)...)
+		errs = append(errs, validateNonlinear(link, row)...)
+		errs = append(errs, validatePHY(link, row)...)
+		if link.PMDPsSqrtKm < 0 {
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "pmd_ps_sqrt_km", Value: formatValue(link.PMDPsSqrtKm), Message: "PMD coefficient has to be zero or greater"})
 		}
+		if link.BitrateGbps < 0 {
+			errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: "bitrate_gbps", Value: formatValue(link.BitrateGbps), Message: "Bitrate has to be zero or greater"})
+		}
+	}
+
+	// Dataset-level checks across rows
+	errs = append(errs, ValidateDataset(links, opt)...)
+
+	// Physics plausibility warnings
+	if !opt.SkipPlausibility {
+		errs = append(errs, CheckPlausibility(links)...)
+	}
+
+	// Evaluate configured rules
+	if len(opt.Rules) > 0 {
+		errs = append(errs, EvaluateRules(links, opt.Rules)...)
 	}
 	return errs
 }
\ No newline at end of file
@@ -0,0 +1,72 @@
+package validate
+
+import (
+	"strconv"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/fiber"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+)
+
+// Define WDM plausibility limits
+const MaxPlausibleWDMPortLossDb = 6.0 // Above the insertion loss of a 40-channel DWDM mux
+
+// Define function to validate the WDM channel plan and port losses of a link
+func validateWDM(link model.LinkInput, row int) []model.RowError {
+	var errs []model.RowError
+	fail := func(field string, value float64, msg string) {
+		errs = append(errs, model.RowError{Row: row, Line: link.Line, Field: field, Value: formatValue(value), Message: msg})
+	}
+	losses := []struct {
+		name  string
+		value float64
+	}{
+		{"mux_loss_db", link.MuxLossDb},
+		{"demux_loss_db", link.DemuxLossDb},
+		{"oadm_loss_db", link.OADMLossDb},
+	}
+	for _, l := range losses {
+		if l.value < 0 {
+			fail(l.name, l.value, "Insertion loss has to be zero or greater")
+		}
+	}
+	if link.NOADM < 0 {
+		fail("n_oadm", float64(link.NOADM), "OADM count has to be zero or greater")
+	}
+
+	// Channels must be distinct
+	seen := make(map[float64]string, len(link.Channels))
+	for _, ch := range link.Channels {
+		if prev, ok := seen[ch.WavelengthNm]; ok {
+			errs = append(errs, model.RowError{
+				Row: row, Line: link.Line, Field: "channels", Value: ch.Label,
+				Message: "Channel repeats " + prev + " (" + formatValue(ch.WavelengthNm) + " nm)",
+			})
+			continue
+		}
+		seen[ch.WavelengthNm] = ch.Label
+	}
+	return errs
+}
+
+// Define function to flag WDM inputs that look suspicious
+func checkWDMPlausibility(link model.LinkInput, ft fiber.Type, warn func(field string, value float64, msg string)) {
+	for _, ch := range link.Channels {
+		if _, ok := ft.BandFor(ch.WavelengthNm); !ok {
+			warn("channels", ch.WavelengthNm, "Channel "+ch.Label+" outside the operating bands of "+ft.Name)
+		}
+	}
+	for _, l := range []struct {
+		name  string
+		value float64
+	}{{"mux_loss_db", link.MuxLossDb}, {"demux_loss_db", link.DemuxLossDb}, {"oadm_loss_db", link.OADMLossDb}} {
+		if l.value > MaxPlausibleWDMPortLossDb {
+			warn(l.name, l.value, "Insertion loss above "+strconv.FormatFloat(MaxPlausibleWDMPortLossDb, 'g', -1, 64)+" dB per port")
+		}
+	}
+	if link.NOADM > 0 && link.OADMLossDb == 0 {
+		warn("n_oadm", float64(link.NOADM), "OADMs without an oadm_loss_db")
+	}
+	if len(link.Channels) == 0 && (link.MuxLossDb > 0 || link.DemuxLossDb > 0) && link.WavelengthNm == 0 {
+		warn("mux_loss_db", link.MuxLossDb, "Mux/demux loss on a link without channels or wavelength_nm")
+	}
+}
@@ -0,0 +1,48 @@
+package wdm
+
+import (
+	"math"
+	"strconv"
+)
+
+// Define grid constants
+const (
+	SpeedOfLightNmTHz = 299792.458 // c in nm·THz
+
+	// ITU-T G.694.2 CWDM grid: 18 channels from 1271 to 1611 nm every 20 nm
+	CWDMFirstNm   = 1271.0
+	CWDMLastNm    = 1611.0
+	CWDMSpacingNm = 20.0
+
+	// ITU-T G.694.1 DWDM channel numbering: channel n sits at 190 + n/10 THz
+	DWDMBaseTHz    = 190.0
+	DWDMChannelTHz = 0.1
+)
+
+// Define function to list the CWDM grid wavelengths between two bounds (inclusive)
+func CWDM(fromNm, toNm float64) []float64 {
+	var out []float64
+	for nm := CWDMFirstNm; nm <= CWDMLastNm; nm += CWDMSpacingNm {
+		if nm >= fromNm && nm <= toNm {
+			out = append(out, nm)
+		}
+	}
+	return out
+}
+
+// Define function to check whether a wavelength is on the CWDM grid
+func IsCWDM(nm float64) bool {
+	k := (nm - CWDMFirstNm) / CWDMSpacingNm
+	return nm >= CWDMFirstNm && nm <= CWDMLastNm && math.Abs(k-math.Round(k)) < 1e-9
+}
+
+// Define function to convert an ITU DWDM channel number (e.g. 21 for C21,
+// 21.5 for the 50 GHz channel above it) into a wavelength in nm
+func DWDMWavelength(channel float64) float64 {
+	return SpeedOfLightNmTHz / (DWDMBaseTHz + channel*DWDMChannelTHz)
+}
+
+// Define function to name an ITU DWDM channel (e.g. "C21", "C21.5")
+func DWDMLabel(channel float64) string {
+	return "C" + strconv.FormatFloat(channel, 'f', -1, 64)
+}
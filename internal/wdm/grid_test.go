@@ -0,0 +1,46 @@
+package wdm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCWDM(t *testing.T) {
+	if got := CWDM(CWDMFirstNm, CWDMLastNm); len(got) != 18 || got[0] != 1271 || got[17] != 1611 {
+		t.Errorf("full grid %v; want 18 channels from 1271 to 1611 nm", got)
+	}
+	if got := CWDM(1471, 1531); len(got) != 4 || got[0] != 1471 || got[3] != 1531 {
+		t.Errorf("1471-1531 %v; want 1471, 1491, 1511, 1531", got)
+	}
+	if got := CWDM(1472, 1490); len(got) != 0 {
+		t.Errorf("1472-1490 %v; want none", got)
+	}
+	for nm, want := range map[float64]bool{1271: true, 1551: true, 1611: true, 1550: false, 1251: false, 1631: false} {
+		if IsCWDM(nm) != want {
+			t.Errorf("IsCWDM(%g) = %v", nm, !want)
+		}
+	}
+}
+
+func TestDWDM(t *testing.T) {
+	// λ = 299792.458 / (190 + n/10) nm
+	tests := []struct {
+		channel float64
+		label   string
+		nm      float64
+	}{
+		{0, "C0", 1577.8550},
+		{21, "C21", 1560.6062},
+		{21.5, "C21.5", 1560.2001},
+		{34, "C34", 1550.1162},
+		{60, "C60", 1529.5533},
+	}
+	for _, tt := range tests {
+		if got := DWDMLabel(tt.channel); got != tt.label {
+			t.Errorf("label of %g: %s; want %s", tt.channel, got, tt.label)
+		}
+		if got := DWDMWavelength(tt.channel); math.Abs(got-tt.nm) > 1e-4 {
+			t.Errorf("%s: %.4f nm; want %.4f", tt.label, got, tt.nm)
+		}
+	}
+}
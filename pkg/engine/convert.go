@@ -0,0 +1,338 @@
+package engine
+
+import "github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+
+// Define function to convert a public link into the internal contract
+func (l Link) toModel() model.LinkInput {
+	return model.LinkInput{
+		LinkID:                 l.LinkID,
+		Scenario:               l.Scenario,
+		TXPowerDbm:             l.TXPowerDbm,
+		RXSensitivityDbm:       l.RXSensitivityDbm,
+		SystemMarginDb:         l.SystemMarginDb,
+		FiberLengthKm:          l.FiberLengthKm,
+		FiberAttDbPerKm:        l.FiberAttDbPerKm,
+		NSplice:                l.NSplice,
+		SpliceLossDb:           l.SpliceLossDb,
+		NConnectors:            l.NConnectors,
+		ConnectorLossDb:        l.ConnectorLossDb,
+		SplitterLossDb:         l.SplitterLossDb,
+		OtherLossDb:            l.OtherLossDb,
+		WavelengthNm:           l.WavelengthNm,
+		FiberType:              l.FiberType,
+		SplitterID:             l.SplitterID,
+		SplitterPort:           l.SplitterPort,
+		SplitRatio:             l.SplitRatio,
+		Amplifiers:             amplifiersToModel(l.Amplifiers),
+		RequiredOSNRDb:         l.RequiredOSNRDb,
+		SensitivityBER:         l.SensitivityBER,
+		QSlope:                 l.QSlope,
+		FEC:                    l.FEC,
+		TargetBER:              l.TargetBER,
+		DispersionPsNmKm:       l.DispersionPsNmKm,
+		SpectralWidthNm:        l.SpectralWidthNm,
+		ExtinctionRatioDb:      l.ExtinctionRatioDb,
+		RINDbHz:                l.RINDbHz,
+		MPNFactor:              l.MPNFactor,
+		DispersionPenaltyDb:    l.DispersionPenaltyDb,
+		ExtinctionPenaltyDb:    l.ExtinctionPenaltyDb,
+		RINPenaltyDb:           l.RINPenaltyDb,
+		MPNPenaltyDb:           l.MPNPenaltyDb,
+		ReflectionPenaltyDb:    l.ReflectionPenaltyDb,
+		ChirpPenaltyDb:         l.ChirpPenaltyDb,
+		PMDPsSqrtKm:            l.PMDPsSqrtKm,
+		BitrateGbps:            l.BitrateGbps,
+		Channels:               channelsToModel(l.Channels),
+		MuxLossDb:              l.MuxLossDb,
+		DemuxLossDb:            l.DemuxLossDb,
+		NOADM:                  l.NOADM,
+		OADMLossDb:             l.OADMLossDb,
+		Services:               servicesToModel(l.Services),
+		ConnectorType:          l.ConnectorType,
+		ConnectorReflectanceDb: l.ConnectorReflectanceDb,
+		SpliceReflectanceDb:    l.SpliceReflectanceDb,
+		ORLToleranceDb:         l.ORLToleranceDb,
+		CableType:              l.CableType,
+		RepairSplicesPerKm:     l.RepairSplicesPerKm,
+		TempDriftDbPerKm:       l.TempDriftDbPerKm,
+		AgingMarginDb:          l.AgingMarginDb,
+		CableCutMarginDb:       l.CableCutMarginDb,
+		SourceLinewidthMHz:     l.SourceLinewidthMHz,
+		EffectiveAreaUm2:       l.EffectiveAreaUm2,
+		PHY:                    l.PHY,
+	}
+}
+
+// Define function to convert an internal link into the public type
+func linkFromModel(m model.LinkInput) Link {
+	return Link{
+		LinkID:                 m.LinkID,
+		Scenario:               m.Scenario,
+		TXPowerDbm:             m.TXPowerDbm,
+		RXSensitivityDbm:       m.RXSensitivityDbm,
+		SystemMarginDb:         m.SystemMarginDb,
+		FiberLengthKm:          m.FiberLengthKm,
+		FiberAttDbPerKm:        m.FiberAttDbPerKm,
+		NSplice:                m.NSplice,
+		SpliceLossDb:           m.SpliceLossDb,
+		NConnectors:            m.NConnectors,
+		ConnectorLossDb:        m.ConnectorLossDb,
+		SplitterLossDb:         m.SplitterLossDb,
+		OtherLossDb:            m.OtherLossDb,
+		WavelengthNm:           m.WavelengthNm,
+		FiberType:              m.FiberType,
+		SplitterID:             m.SplitterID,
+		SplitterPort:           m.SplitterPort,
+		SplitRatio:             m.SplitRatio,
+		Amplifiers:             amplifiersFromModel(m.Amplifiers),
+		RequiredOSNRDb:         m.RequiredOSNRDb,
+		SensitivityBER:         m.SensitivityBER,
+		QSlope:                 m.QSlope,
+		FEC:                    m.FEC,
+		TargetBER:              m.TargetBER,
+		DispersionPsNmKm:       m.DispersionPsNmKm,
+		SpectralWidthNm:        m.SpectralWidthNm,
+		ExtinctionRatioDb:      m.ExtinctionRatioDb,
+		RINDbHz:                m.RINDbHz,
+		MPNFactor:              m.MPNFactor,
+		DispersionPenaltyDb:    m.DispersionPenaltyDb,
+		ExtinctionPenaltyDb:    m.ExtinctionPenaltyDb,
+		RINPenaltyDb:           m.RINPenaltyDb,
+		MPNPenaltyDb:           m.MPNPenaltyDb,
+		ReflectionPenaltyDb:    m.ReflectionPenaltyDb,
+		ChirpPenaltyDb:         m.ChirpPenaltyDb,
+		PMDPsSqrtKm:            m.PMDPsSqrtKm,
+		BitrateGbps:            m.BitrateGbps,
+		Channels:               channelsFromModel(m.Channels),
+		MuxLossDb:              m.MuxLossDb,
+		DemuxLossDb:            m.DemuxLossDb,
+		NOADM:                  m.NOADM,
+		OADMLossDb:             m.OADMLossDb,
+		Services:               servicesFromModel(m.Services),
+		ConnectorType:          m.ConnectorType,
+		ConnectorReflectanceDb: m.ConnectorReflectanceDb,
+		SpliceReflectanceDb:    m.SpliceReflectanceDb,
+		ORLToleranceDb:         m.ORLToleranceDb,
+		CableType:              m.CableType,
+		RepairSplicesPerKm:     m.RepairSplicesPerKm,
+		TempDriftDbPerKm:       m.TempDriftDbPerKm,
+		AgingMarginDb:          m.AgingMarginDb,
+		CableCutMarginDb:       m.CableCutMarginDb,
+		SourceLinewidthMHz:     m.SourceLinewidthMHz,
+		EffectiveAreaUm2:       m.EffectiveAreaUm2,
+		PHY:                    m.PHY,
+	}
+}
+
+// Define function to convert an internal result into the public type.
+// rtbEvaluated tells whether RTBStatus carries a real verdict.
+func resultFromModel(o model.LinkOutput, rtbEvaluated bool) Result {
+	r := Result{
+		LinkID:                 o.LinkID,
+		Scenario:               o.Scenario,
+		FiberLossDb:            o.FiberLossDb,
+		SpliceTotalDb:          o.SpliceTotalDb,
+		ConnectorTotalDb:       o.ConnectorTotalDb,
+		TotalLossDb:            o.TotalLossDb,
+		RxPowerDbm:             o.RxPowerDbm,
+		MarginDb:               o.MarginDb,
+		LPBStatus:              lpbStatusOf(o.LPBStatus),
+		SystemRiseTimeNs:       o.SystemRiseTimeNs,
+		AllowedRiseTimeNs:      o.AllowedRiseTimeNs,
+		TopContributors:        []string{o.TopContributor1, o.TopContributor2, o.TopContributor3},
+		ComplianceClause:       o.ComplianceClause,
+		AmplifierGainDb:        o.AmplifierGainDb,
+		OSNRDb:                 o.OSNRDb,
+		QFactor:                o.QFactor,
+		BER:                    o.BER,
+		PostFECBER:             o.PostFECBER,
+		DispersionPenaltyDb:    o.DispersionPenaltyDb,
+		ExtinctionPenaltyDb:    o.ExtinctionPenaltyDb,
+		RINPenaltyDb:           o.RINPenaltyDb,
+		MPNPenaltyDb:           o.MPNPenaltyDb,
+		ReflectionPenaltyDb:    o.ReflectionPenaltyDb,
+		ChirpPenaltyDb:         o.ChirpPenaltyDb,
+		PenaltyTotalDb:         o.PenaltyTotalDb,
+		UnboundedPenalties:     o.UnboundedPenalties,
+		DGDPs:                  o.DGDPs,
+		MaxDGDPs:               o.MaxDGDPs,
+		WDMLossDb:              o.WDMLossDb,
+		WorstChannel:           o.WorstChannel,
+		WorstChannelNm:         o.WorstChannelNm,
+		LimitingService:        o.LimitingService,
+		ORLDb:                  o.ORLDb,
+		WorstReflectionEvent:   o.WorstReflectionEvent,
+		WorstReflectanceDb:     o.WorstReflectanceDb,
+		RepairAllowanceDb:      o.RepairAllowanceDb,
+		TemperatureAllowanceDb: o.TemperatureAllowanceDb,
+		AgingAllowanceDb:       o.AgingAllowanceDb,
+		CableCutAllowanceDb:    o.CableCutAllowanceDb,
+		AllowanceTotalDb:       o.AllowanceTotalDb,
+		SBSPenaltyDb:           o.SBSPenaltyDb,
+		SPMPenaltyDb:           o.SPMPenaltyDb,
+		EffectiveLengthKm:      o.EffectiveLengthKm,
+		SBSThresholdDbm:        o.SBSThresholdDbm,
+		SPMThresholdDbm:        o.SPMThresholdDbm,
+		NonlinearPhaseRad:      o.NonlinearPhaseRad,
+		ModalRiseTimeNs:        o.ModalRiseTimeNs,
+		PHYReachM:              o.PHYReachM,
+		PHYMaxChannelLossDb:    o.PHYMaxChannelLossDb,
+		PHYLimit:               o.PHYLimit,
+	}
+	for _, s := range o.Services {
+		r.Services = append(r.Services, ServiceResult{
+			Service:          s.Service,
+			Direction:        s.Direction,
+			WavelengthNm:     s.WavelengthNm,
+			FilterLossDb:     s.FilterLossDb,
+			TxPowerDbm:       s.TxPowerDbm,
+			RxSensitivityDbm: s.RxSensitivityDbm,
+			RxPowerDbm:       s.RxPowerDbm,
+			MarginDb:         s.MarginDb,
+			OverloadMarginDb: s.OverloadMarginDb,
+			Status:           lpbStatusOf(s.Status),
+		})
+	}
+	for _, ch := range o.Channels {
+		r.Channels = append(r.Channels, ChannelResult{
+			Label:            ch.Label,
+			WavelengthNm:     ch.WavelengthNm,
+			FiberAttDbPerKm:  ch.FiberAttDbPerKm,
+			DispersionPsNmKm: ch.DispersionPsNmKm,
+			TotalLossDb:      ch.TotalLossDb,
+			PenaltyTotalDb:   ch.PenaltyTotalDb,
+			RxPowerDbm:       ch.RxPowerDbm,
+			MarginDb:         ch.MarginDb,
+			LPBStatus:        lpbStatusOf(ch.LPBStatus),
+		})
+	}
+	if o.BERStatus != "" {
+		r.BERStatus = statusOf(o.BERStatus == "PASS")
+		r.PostFECStatus = statusOf(o.PostFECStatus == "PASS")
+	}
+	if o.OSNRStatus == "PASS" || o.OSNRStatus == "FAIL" {
+		r.OSNRStatus = statusOf(o.OSNRStatus == "PASS")
+	}
+	if o.ReflectionStatus == "PASS" || o.ReflectionStatus == "FAIL" {
+		r.ReflectionStatus = statusOf(o.ReflectionStatus == "PASS")
+	}
+	if o.PHYStatus != "" {
+		r.PHYStatus = statusOf(o.PHYStatus == "PASS")
+	}
+	if o.NonlinearStatus != "" {
+		r.NonlinearStatus = statusOf(o.NonlinearStatus == "PASS")
+	}
+	if o.PMDStatus != "" {
+		r.PMDStatus = statusOf(o.PMDStatus == "PASS")
+	}
+	if rtbEvaluated {
+		r.RTBStatus = statusOf(o.RTBStatus)
+	}
+	if o.ComplianceStatus != "" {
+		r.ComplianceStatus = statusOf(o.ComplianceStatus == "PASS")
+	}
+	return r
+}
+
+// Define functions to convert amplifier chains
+func amplifiersToModel(amps []Amplifier) []model.Amplifier {
+	if amps == nil {
+		return nil
+	}
+	out := make([]model.Amplifier, len(amps))
+	for i, a := range amps {
+		out[i] = model.Amplifier(a)
+	}
+	return out
+}
+
+func amplifiersFromModel(amps []model.Amplifier) []Amplifier {
+	if amps == nil {
+		return nil
+	}
+	out := make([]Amplifier, len(amps))
+	for i, a := range amps {
+		out[i] = Amplifier(a)
+	}
+	return out
+}
+
+// Define functions to convert WDM channel plans
+func channelsToModel(channels []Channel) []model.Channel {
+	if channels == nil {
+		return nil
+	}
+	out := make([]model.Channel, len(channels))
+	for i, c := range channels {
+		out[i] = model.Channel(c)
+	}
+	return out
+}
+
+func channelsFromModel(channels []model.Channel) []Channel {
+	if channels == nil {
+		return nil
+	}
+	out := make([]Channel, len(channels))
+	for i, c := range channels {
+		out[i] = Channel(c)
+	}
+	return out
+}
+
+// Define functions to convert PON services
+func servicesToModel(svcs []Service) []model.Service {
+	if svcs == nil {
+		return nil
+	}
+	out := make([]model.Service, len(svcs))
+	for i, s := range svcs {
+		out[i] = model.Service(s)
+	}
+	return out
+}
+
+func servicesFromModel(svcs []model.Service) []Service {
+	if svcs == nil {
+		return nil
+	}
+	out := make([]Service, len(svcs))
+	for i, s := range svcs {
+		out[i] = Service(s)
+	}
+	return out
+}
+
+// Define function to convert internal row errors into issues
+func issuesFromModel(errs []model.RowError) []Issue {
+	out := make([]Issue, 0, len(errs))
+	for _, e := range errs {
+		sev := SeverityError
+		switch e.Severity {
+		case model.SeverityWarning:
+			sev = SeverityWarning
+		case model.SeverityInfo:
+			sev = SeverityInfo
+		}
+		out = append(out, Issue{
+			Row: e.Row, Field: e.Field, Value: e.Value,
+			Message: e.Message, Severity: sev, Refs: e.Refs,
+		})
+	}
+	return out
+}
+
+// Define function to convert an issue back into a row error
+func toRowError(i Issue) model.RowError {
+	sev := model.SeverityError
+	switch i.Severity {
+	case SeverityWarning:
+		sev = model.SeverityWarning
+	case SeverityInfo:
+		sev = model.SeverityInfo
+	}
+	return model.RowError{
+		Row: i.Row, Field: i.Field, Value: i.Value,
+		Message: i.Message, Severity: sev, Refs: i.Refs,
+	}
+}
@@ -0,0 +1,18 @@
+// Package engine is the public Go API of the fiber optic performance engine.
+//
+// It wraps the link power budget (LPB), rise time budget (RTB), validation,
+// compliance and sweep logic used by the fo CLI behind a stable Engine type:
+//
+//	eng, err := engine.New(
+//		engine.WithRiseTimeBudget(10, 0.05, 0.05),
+//		engine.WithProfile("gpon-b+"),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	results, issues, err := eng.Run(ctx, links)
+//
+// Statuses are typed (StatusPass, StatusFail, StatusNotEvaluated, and
+// StatusUnbounded for a power budget with an unbounded penalty) so callers
+// never compare strings. See examples/embed for a complete program.
+package engine
@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/compliance"
+	foio "github.com/fadeldnswr/fo-performance-engine.git/internal/io"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/model"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/sweep"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/validate"
+)
+
+// Define struct for a configured engine. An Engine is safe for concurrent use.
+type Engine struct {
+	runner     calc.RunnerOptions
+	validation validate.ValidationOptions
+	profile    *compliance.Profile
+}
+
+// Define function to create an engine with the given options
+func New(opts ...Option) (*Engine, error) {
+	e := &Engine{}
+	for _, opt := range opts {
+		if err := opt(e); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Define function to validate links, including dataset-level checks
+func (e *Engine) Validate(ctx context.Context, links []Link) ([]Issue, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ms := toModels(links)
+	errs := validate.ValidateLink(ms, e.validation)
+	if e.profile != nil && !validate.HasBlocking(errs) {
+		verdicts, err := compliance.Check(ms, *e.profile)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, compliance.ToRowErrors(ms, verdicts)...)
+	}
+	return issuesFromModel(errs), nil
+}
+
+// Define function to compute a single link without validation
+func (e *Engine) Compute(ctx context.Context, link Link) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	out, err := calc.Compute(link.toModel(), e.runner)
+	if err != nil {
+		return Result{}, err
+	}
+	return resultFromModel(out, e.runner.EnableRTB), nil
+}
+
+// Define function to validate and compute links. Blocking issues stop the run
+// with ErrInvalidInput; warnings are returned next to the results.
+func (e *Engine) Run(ctx context.Context, links []Link) ([]Result, []Issue, error) {
+	ms := toModels(links)
+	errs := validate.ValidateLink(ms, e.validation)
+	if validate.HasBlocking(errs) {
+		return nil, issuesFromModel(errs), ErrInvalidInput
+	}
+
+	outs := make([]model.LinkOutput, 0, len(ms))
+	for _, m := range ms {
+		if err := ctx.Err(); err != nil {
+			return nil, issuesFromModel(errs), err
+		}
+		out, err := calc.Compute(m, e.runner)
+		if err != nil {
+			return nil, issuesFromModel(errs), err
+		}
+		outs = append(outs, out)
+	}
+
+	// Attach compliance verdicts
+	if e.profile != nil {
+		verdicts, err := compliance.Check(ms, *e.profile)
+		if err != nil {
+			return nil, issuesFromModel(errs), err
+		}
+		for i, v := range verdicts {
+			outs[i].ComplianceProfile = v.Profile
+			outs[i].ComplianceStatus = v.Status()
+			outs[i].ComplianceClause = v.Clause()
+		}
+	}
+	return e.results(outs), issuesFromModel(errs), nil
+}
+
+// Define function to sweep links over the cartesian product of variations.
+// The context is checked before each link, and the first link that fails to
+// compute stops the sweep with its error.
+func (e *Engine) Sweep(ctx context.Context, links []Link, vars ...Variation) ([]Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sv := make([]sweep.Variation, len(vars))
+	for i, v := range vars {
+		sv[i] = sweep.Variation{Field: v.Field, Values: v.Values}
+		if _, err := sweep.ApplyVariations(model.LinkInput{}, sv[i], 0); err != nil {
+			return nil, err
+		}
+	}
+	var outs []model.LinkOutput
+	err := sweep.WalkSweep(toModels(links), sv, func(m model.LinkInput) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		out, err := calc.Compute(m, e.runner)
+		if err != nil {
+			return errors.New("engine: link " + m.LinkID + " in " + m.Scenario + ": " + err.Error())
+		}
+		outs = append(outs, out)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e.results(outs), nil
+}
+
+// Define function to find the largest value of a field that keeps the link passing
+func (e *Engine) Solve(ctx context.Context, link Link, field string) (Solution, error) {
+	if err := ctx.Err(); err != nil {
+		return Solution{}, err
+	}
+	sol, err := sweep.Solve(link.toModel(), field, e.runner)
+	if err != nil {
+		return Solution{}, err
+	}
+	return Solution{Field: sol.Field, Current: sol.Current, Limit: sol.Limit, Headroom: sol.Headroom}, nil
+}
+
+// Define function to read links from CSV using the CLI column names
+func ReadCSV(r io.Reader) ([]Link, []Issue, error) {
+	ms, rowErrs, err := foio.ReadLinks(r, foio.CSVReadOptions{})
+	if err != nil {
+		return nil, issuesFromModel(rowErrs), err
+	}
+	links := make([]Link, len(ms))
+	for i, m := range ms {
+		links[i] = linkFromModel(m)
+	}
+	return links, issuesFromModel(rowErrs), nil
+}
+
+// Define helper to convert results
+func (e *Engine) results(outs []model.LinkOutput) []Result {
+	res := make([]Result, len(outs))
+	for i, o := range outs {
+		res[i] = resultFromModel(o, e.runner.EnableRTB)
+	}
+	return res
+}
+
+// Define helper to convert links
+func toModels(links []Link) []model.LinkInput {
+	ms := make([]model.LinkInput, len(links))
+	for i, l := range links {
+		ms[i] = l.toModel()
+	}
+	return ms
+}
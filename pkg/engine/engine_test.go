@@ -0,0 +1,84 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/pkg/engine"
+)
+
+// Define context that reports cancellation after a number of Err calls
+type countdownCtx struct {
+	context.Context
+	left int
+}
+
+func (c *countdownCtx) Err() error {
+	if c.left <= 0 {
+		return context.Canceled
+	}
+	c.left--
+	return nil
+}
+
+// Define helper for a passing 20 km link
+func sweepLink(id string) engine.Link {
+	return engine.Link{
+		LinkID: id, TXPowerDbm: 3, RXSensitivityDbm: -28, SystemMarginDb: 3,
+		FiberLengthKm: 20, FiberAttDbPerKm: 0.35,
+	}
+}
+
+func TestSweepStopsWhenCancelledMidway(t *testing.T) {
+	eng, err := engine.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One check on entry, then one per link: cancel after three of six links
+	ctx := &countdownCtx{Context: context.Background(), left: 4}
+	res, err := eng.Sweep(ctx, []engine.Link{sweepLink("L1"), sweepLink("L2")},
+		engine.Variation{Field: "fiber_length_km", Values: []float64{10, 20, 30}})
+	if !errors.Is(err, context.Canceled) || res != nil {
+		t.Errorf("got %d results, error %v; want none and context.Canceled", len(res), err)
+	}
+	if ctx.left != 0 {
+		t.Errorf("sweep stopped with %d checks left", ctx.left)
+	}
+}
+
+func TestSweepReturnsComputeErrors(t *testing.T) {
+	eng, err := engine.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad := sweepLink("L2")
+	bad.CableType = "no-such-cable"
+	res, err := eng.Sweep(context.Background(), []engine.Link{sweepLink("L1"), bad},
+		engine.Variation{Field: "system_margin_db", Values: []float64{3}})
+	if err == nil || !strings.Contains(err.Error(), "L2") || !strings.Contains(err.Error(), "no-such-cable") {
+		t.Errorf("got %d results, error %v; want the L2 cable type error", len(res), err)
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	for _, s := range []engine.Status{engine.StatusNotEvaluated, engine.StatusPass, engine.StatusFail, engine.StatusUnbounded} {
+		b, _ := s.MarshalText()
+		var got engine.Status
+		if err := got.UnmarshalText(b); err != nil || got != s {
+			t.Errorf("status %v decoded as %v (%v)", s, got, err)
+		}
+	}
+	for _, s := range []engine.Severity{engine.SeverityError, engine.SeverityWarning, engine.SeverityInfo} {
+		b, _ := s.MarshalText()
+		var got engine.Severity
+		if err := got.UnmarshalText(b); err != nil || got != s {
+			t.Errorf("severity %v decoded as %v (%v)", s, got, err)
+		}
+	}
+	var sev engine.Severity
+	if err := sev.UnmarshalText([]byte("fatal")); err == nil {
+		t.Error("unknown severity decoded without error")
+	}
+}
@@ -0,0 +1,154 @@
+package engine_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/pkg/engine"
+)
+
+func ExampleNew() {
+	// Configure once, reuse for every request
+	eng, err := engine.New(
+		engine.WithRiseTimeBudget(2.5, 0.2, 0.2),
+		engine.WithProfile("gpon-b+"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(eng != nil)
+
+	// Invalid options are rejected up front
+	_, err = engine.New(engine.WithProfile("no-such-profile"))
+	fmt.Println(err)
+	// Output:
+	// true
+	// engine: unknown compliance profile no-such-profile
+}
+
+func ExampleEngine_Compute() {
+	eng, err := engine.New()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 20 km at 0.35 dB/km, 4 splices of 0.1 dB and 2 connectors of 0.5 dB
+	res, err := eng.Compute(context.Background(), engine.Link{
+		LinkID: "L01", TXPowerDbm: 3, RXSensitivityDbm: -28, SystemMarginDb: 3,
+		FiberLengthKm: 20, FiberAttDbPerKm: 0.35,
+		NSplice: 4, SpliceLossDb: 0.1,
+		NConnectors: 2, ConnectorLossDb: 0.5,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("loss %.1f dB, margin %.1f dB, LPB %s\n", res.TotalLossDb, res.MarginDb, res.LPBStatus)
+	fmt.Println("top contributor:", res.TopContributors[0])
+	// Output:
+	// loss 8.4 dB, margin 19.6 dB, LPB PASS
+	// top contributor: fiber_loss_db
+}
+
+func ExampleEngine_Sweep() {
+	eng, err := engine.New()
+	if err != nil {
+		log.Fatal(err)
+	}
+	links := []engine.Link{{
+		LinkID: "L01", Scenario: "base", TXPowerDbm: 3, RXSensitivityDbm: -28, SystemMarginDb: 3,
+		FiberLengthKm: 20, FiberAttDbPerKm: 0.35,
+		NSplice: 4, SpliceLossDb: 0.1,
+		NConnectors: 2, ConnectorLossDb: 0.5,
+	}}
+
+	// One result per link and value, named after the scenario and value
+	results, err := eng.Sweep(context.Background(), links, engine.Variation{
+		Field:  "fiber_length_km",
+		Values: []float64{20, 60, 80},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, r := range results {
+		fmt.Printf("%s margin %.1f dB %s\n", r.Scenario, r.MarginDb, r.LPBStatus)
+	}
+	// Output:
+	// base_fiber_length_km=20.00 margin 19.6 dB PASS
+	// base_fiber_length_km=60.00 margin 5.6 dB PASS
+	// base_fiber_length_km=80.00 margin -1.4 dB FAIL
+}
+
+func ExampleWithRiseTimeBudget() {
+	// 10 Gbit/s with Tx and Rx rise times of 0.2 ns
+	eng, err := engine.New(engine.WithRiseTimeBudget(10, 0.2, 0.2))
+	if err != nil {
+		log.Fatal(err)
+	}
+	res, err := eng.Compute(context.Background(), engine.Link{
+		LinkID: "L01", TXPowerDbm: 3, RXSensitivityDbm: -28, SystemMarginDb: 3,
+		FiberLengthKm: 20, FiberAttDbPerKm: 0.35,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("system %.1f ns, allowed %.1f ns, RTB %s\n", res.SystemRiseTimeNs, res.AllowedRiseTimeNs, res.RTBStatus)
+	// Output:
+	// system 0.4 ns, allowed 70.0 ns, RTB PASS
+}
+
+func ExampleWithCableType() {
+	// Replace the built-in aerial allowances
+	eng, err := engine.New(engine.WithCableType("aerial", engine.CableAllowances{
+		RepairSplicesPerKm: 0.4, TempDriftDbPerKm: 0.03, AgingDb: 0.5, CableCutDb: 1,
+	}))
+	if err != nil {
+		log.Fatal(err)
+	}
+	res, err := eng.Compute(context.Background(), engine.Link{
+		LinkID: "A1", TXPowerDbm: 3, RXSensitivityDbm: -28, SystemMarginDb: 3,
+		FiberLengthKm: 20, FiberAttDbPerKm: 0.35,
+		NSplice: 4, SpliceLossDb: 0.1,
+		CableType: "aerial",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("allowances %.1f dB, margin %.1f dB\n", res.AllowanceTotalDb, res.MarginDb)
+	// Output:
+	// allowances 2.9 dB, margin 17.7 dB
+}
+
+func ExampleStatus() {
+	eng, err := engine.New(engine.WithBitrate(2.5))
+	if err != nil {
+		log.Fatal(err)
+	}
+	link := engine.Link{
+		LinkID: "P4", TXPowerDbm: 3, RXSensitivityDbm: -30, SystemMarginDb: 3,
+		FiberLengthKm: 20, FiberAttDbPerKm: 0.22,
+		WavelengthNm: 1550, FiberType: "G.652",
+	}
+
+	// Checks without inputs are not evaluated
+	res, err := eng.Compute(context.Background(), link)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("LPB:", res.LPBStatus, "RTB:", res.RTBStatus, "BER:", res.BERStatus)
+
+	// A 1 nm source over 80 km has no finite dispersion penalty
+	link.FiberLengthKm, link.SpectralWidthNm = 80, 1
+	res, err = eng.Compute(context.Background(), link)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("LPB:", res.LPBStatus, res.UnboundedPenalties)
+	if res.LPBStatus != engine.StatusPass {
+		fmt.Println("link does not close")
+	}
+	// Output:
+	// LPB: PASS RTB: NOT_EVALUATED BER: NOT_EVALUATED
+	// LPB: UNBOUNDED [dispersion_penalty_db]
+	// link does not close
+}
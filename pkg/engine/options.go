@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"errors"
+
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/cable"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/calc"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/compliance"
+	"github.com/fadeldnswr/fo-performance-engine.git/internal/validate"
+)
+
+// Define functional option for New
+type Option func(*Engine) error
+
+// Define option to enable the rise time budget
+func WithRiseTimeBudget(bitrateGbps, txRiseTimeNs, rxRiseTimeNs float64) Option {
+	return func(e *Engine) error {
+		if bitrateGbps <= 0 {
+			return errors.New("engine: bitrate must be greater than zero")
+		}
+		e.runner.EnableRTB = true
+		e.runner.BitrateGbps = bitrateGbps
+		e.runner.TxRiseTimeNs = txRiseTimeNs
+		e.runner.RxRiseTimeNs = rxRiseTimeNs
+		return nil
+	}
+}
+
+// Define option to set the bitrate used by the penalty and PMD checks without
+// enabling the rise time budget
+func WithBitrate(bitrateGbps float64) Option {
+	return func(e *Engine) error {
+		if bitrateGbps <= 0 {
+			return errors.New("engine: bitrate must be greater than zero")
+		}
+		e.runner.BitrateGbps = bitrateGbps
+		return nil
+	}
+}
+
+// Define option to set the PMD limit as a share of the bit period (default 0.1)
+func WithPMDLimit(bitFraction float64) Option {
+	return func(e *Engine) error {
+		if bitFraction <= 0 || bitFraction > 1 {
+			return errors.New("engine: PMD bit fraction must be between 0 and 1")
+		}
+		e.runner.PMDBitFraction = bitFraction
+		return nil
+	}
+}
+
+// Define option to add a cable type for margin allowances, or replace the
+// values of a built-in one
+func WithCableType(name string, a CableAllowances) Option {
+	return func(e *Engine) error {
+		if a.RepairSplicesPerKm < 0 || a.TempDriftDbPerKm < 0 || a.AgingDb < 0 || a.CableCutDb < 0 {
+			return errors.New("engine: cable allowances must be zero or greater")
+		}
+		if e.runner.Cables == nil {
+			e.runner.Cables = make(map[string]cable.Allowances)
+		}
+		e.runner.Cables[name] = cable.Allowances(a)
+		e.validation.Cables = e.runner.Cables
+		return nil
+	}
+}
+
+// Define option to set the fiber dispersion used by the rise time budget
+func WithDispersion(nsPerKm float64) Option {
+	return func(e *Engine) error {
+		e.runner.DispersionPerKm = nsPerKm
+		return nil
+	}
+}
+
+// Define option to set the default FEC code and post-FEC BER target for links
+// with a BER model that leave them empty (targetBER 0 keeps 1e-12)
+func WithFEC(code string, targetBER float64) Option {
+	return func(e *Engine) error {
+		if _, ok := calc.LookupFEC(code); !ok {
+			return errors.New("engine: unknown FEC code " + code)
+		}
+		e.runner.FEC = code
+		e.runner.TargetBER = targetBER
+		return nil
+	}
+}
+
+// Define option to attach a standards compliance profile (e.g. "gpon-b+")
+func WithProfile(name string) Option {
+	return func(e *Engine) error {
+		p, ok := compliance.Lookup(name)
+		if !ok {
+			return errors.New("engine: unknown compliance profile " + name)
+		}
+		e.profile = &p
+		return nil
+	}
+}
+
+// Define option to load validation rules from a YAML file
+func WithRulesFile(path string) Option {
+	return func(e *Engine) error {
+		rules, err := validate.LoadRules(path)
+		if err != nil {
+			return err
+		}
+		e.validation.Rules = append(e.validation.Rules, rules...)
+		return nil
+	}
+}
+
+// Define option to restrict scenario names to a declared list
+func WithScenarios(names ...string) Option {
+	return func(e *Engine) error {
+		e.validation.Scenarios = append(e.validation.Scenarios, names...)
+		return nil
+	}
+}
+
+// Define option to set the maximum accepted fiber attenuation in dB/km.
+// Multimode fiber types are always accepted up to their cabled limit.
+func WithMaxFiberAttenuation(dbPerKm float64) Option {
+	return func(e *Engine) error {
+		e.validation.MaxFiberAttPerDbKm = dbPerKm
+		return nil
+	}
+}
+
+// Define option to turn off physics plausibility warnings
+func WithoutPlausibilityChecks() Option {
+	return func(e *Engine) error {
+		e.validation.SkipPlausibility = true
+		return nil
+	}
+}
@@ -0,0 +1,385 @@
+package engine
+
+import "errors"
+
+// Define typed evaluation status
+type Status int
+
+const (
+	StatusNotEvaluated Status = iota // Check disabled or not applicable
+	StatusPass
+	StatusFail
+	StatusUnbounded // LPB only: a penalty term has no finite value, so the link cannot close
+)
+
+// Define function to render a status as text
+func (s Status) String() string {
+	switch s {
+	case StatusPass:
+		return "PASS"
+	case StatusFail:
+		return "FAIL"
+	case StatusUnbounded:
+		return "UNBOUNDED"
+	}
+	return "NOT_EVALUATED"
+}
+
+// Define function to encode a status as text (JSON, YAML, ...)
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Define function to decode a status from text
+func (s *Status) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "PASS":
+		*s = StatusPass
+	case "FAIL":
+		*s = StatusFail
+	case "UNBOUNDED":
+		*s = StatusUnbounded
+	case "NOT_EVALUATED", "":
+		*s = StatusNotEvaluated
+	default:
+		return errors.New("engine: unknown status " + string(b))
+	}
+	return nil
+}
+
+// Define helper to build a status from a pass flag
+func statusOf(pass bool) Status {
+	if pass {
+		return StatusPass
+	}
+	return StatusFail
+}
+
+// Define helper to build a power budget status, which may also be unbounded
+func lpbStatusOf(s string) Status {
+	if s == "UNBOUNDED" {
+		return StatusUnbounded
+	}
+	return statusOf(s == "PASS")
+}
+
+// Define typed issue severity
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+// Define function to render a severity as text
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return "error"
+}
+
+// Define function to encode a severity as text
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Define function to decode a severity from text
+func (s *Severity) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "error":
+		*s = SeverityError
+	case "warning":
+		*s = SeverityWarning
+	case "info":
+		*s = SeverityInfo
+	default:
+		return errors.New("engine: unknown severity " + string(b))
+	}
+	return nil
+}
+
+// Define struct for a link to evaluate
+type Link struct {
+	LinkID   string `json:"link_id"`
+	Scenario string `json:"scenario"`
+
+	// Transmitter and receiver parameters
+	TXPowerDbm       float64 `json:"tx_power_dbm"`
+	RXSensitivityDbm float64 `json:"rx_sensitivity_dbm"`
+	SystemMarginDb   float64 `json:"system_margin_db"`
+
+	// Fiber parameters
+	FiberLengthKm   float64 `json:"fiber_length_km"`
+	FiberAttDbPerKm float64 `json:"fiber_att_db_per_km"`
+	WavelengthNm    float64 `json:"wavelength_nm,omitempty"`
+	FiberType       string  `json:"fiber_type,omitempty"`
+
+	// Component losses and counts
+	NSplice         int     `json:"n_splice"`
+	SpliceLossDb    float64 `json:"splice_loss_db"`
+	NConnectors     int     `json:"n_connector"`
+	ConnectorLossDb float64 `json:"connector_loss_db"`
+	SplitterLossDb  float64 `json:"splitter_loss_db"`
+	OtherLossDb     float64 `json:"other_loss_db"`
+
+	// Splitter assignment
+	SplitterID   string `json:"splitter_id,omitempty"`
+	SplitterPort int    `json:"splitter_port,omitempty"`
+	SplitRatio   int    `json:"split_ratio,omitempty"`
+
+	// Amplifier chain and the OSNR the receiver needs
+	Amplifiers     []Amplifier `json:"amplifiers,omitempty"`
+	RequiredOSNRDb float64     `json:"required_osnr_db,omitempty"`
+
+	// Receiver BER model: sensitivity holds at SensitivityBER, Q scales with power at QSlope
+	SensitivityBER float64 `json:"sensitivity_ber,omitempty"`
+	QSlope         float64 `json:"q_slope,omitempty"`
+	FEC            string  `json:"fec,omitempty"`
+	TargetBER      float64 `json:"target_ber,omitempty"`
+
+	// Power penalty parameters; a *PenaltyDb value greater than zero is used as given
+	DispersionPsNmKm    float64 `json:"dispersion_ps_nm_km,omitempty"` // 0 uses the fiber type at WavelengthNm
+	SpectralWidthNm     float64 `json:"spectral_width_nm,omitempty"`
+	ExtinctionRatioDb   float64 `json:"extinction_ratio_db,omitempty"`
+	RINDbHz             float64 `json:"rin_db_hz,omitempty"`
+	MPNFactor           float64 `json:"mpn_k,omitempty"`
+	DispersionPenaltyDb float64 `json:"dispersion_penalty_db,omitempty"`
+	ExtinctionPenaltyDb float64 `json:"er_penalty_db,omitempty"`
+	RINPenaltyDb        float64 `json:"rin_penalty_db,omitempty"`
+	MPNPenaltyDb        float64 `json:"mpn_penalty_db,omitempty"`
+	ReflectionPenaltyDb float64 `json:"reflection_penalty_db,omitempty"`
+	ChirpPenaltyDb      float64 `json:"chirp_penalty_db,omitempty"`
+
+	// PMD coefficient in ps/√km, 0 uses the declared fiber type
+	PMDPsSqrtKm float64 `json:"pmd_ps_sqrt_km,omitempty"`
+
+	// Line rate in Gbps, 0 uses the lane rate of PHY, else the WithBitrate value
+	BitrateGbps float64 `json:"bitrate_gbps,omitempty"`
+
+	// WDM channel plan with per-port mux, demux and OADM insertion losses
+	Channels    []Channel `json:"channels,omitempty"`
+	MuxLossDb   float64   `json:"mux_loss_db,omitempty"`
+	DemuxLossDb float64   `json:"demux_loss_db,omitempty"`
+	NOADM       int       `json:"n_oadm,omitempty"`
+	OADMLossDb  float64   `json:"oadm_loss_db,omitempty"`
+
+	// PON services sharing the ODN; Tx power and Rx sensitivity then come from each service
+	Services []Service `json:"services,omitempty"`
+
+	// Reflectance budget: connector polish ("pc", "upc", "apc") or explicit
+	// reflectances in dB, and the minimum ORL the transmitter tolerates
+	ConnectorType          string  `json:"connector_type,omitempty"`
+	ConnectorReflectanceDb float64 `json:"connector_reflectance_db,omitempty"`
+	SpliceReflectanceDb    float64 `json:"splice_reflectance_db,omitempty"`
+	ORLToleranceDb         float64 `json:"orl_tolerance_db,omitempty"`
+
+	// Margin allowances: a cable type ("duct", "direct-buried", "aerial",
+	// "indoor" or one added with WithCableType) and per-link overrides
+	CableType          string  `json:"cable_type,omitempty"`
+	RepairSplicesPerKm float64 `json:"repair_splices_per_km,omitempty"`
+	TempDriftDbPerKm   float64 `json:"temp_drift_db_per_km,omitempty"`
+	AgingMarginDb      float64 `json:"aging_margin_db,omitempty"`
+	CableCutMarginDb   float64 `json:"cable_cut_margin_db,omitempty"`
+
+	// Source linewidth in MHz enables the SBS and SPM threshold check; the
+	// effective area in µm² defaults to the declared fiber type
+	SourceLinewidthMHz float64 `json:"source_linewidth_mhz,omitempty"`
+	EffectiveAreaUm2   float64 `json:"effective_area_um2,omitempty"`
+
+	// Multimode Ethernet PHY ("1000base-sx", "10gbase-sr", "25gbase-sr",
+	// "40gbase-sr4", "100gbase-sr4") checked over an OM1–OM5 fiber_type
+	PHY string `json:"phy,omitempty"`
+}
+
+// Define struct for the lifetime margin allowances of a cable type
+type CableAllowances struct {
+	RepairSplicesPerKm float64 `json:"repair_splices_per_km"`
+	TempDriftDbPerKm   float64 `json:"temp_drift_db_per_km"`
+	AgingDb            float64 `json:"aging_db"`
+	CableCutDb         float64 `json:"cable_cut_db"`
+}
+
+// Define struct for a PON service ("gpon", "xgs-pon", "rf-video"). Params
+// override catalog values: cex, wdm1r, down_tx, down_rx, down_overload,
+// up_tx, up_rx, up_overload.
+type Service struct {
+	Name   string             `json:"name"`
+	Params map[string]float64 `json:"params,omitempty"`
+}
+
+// Define struct for the evaluation of one direction of a service
+type ServiceResult struct {
+	Service          string  `json:"service"`
+	Direction        string  `json:"direction"`
+	WavelengthNm     float64 `json:"wavelength_nm"`
+	FilterLossDb     float64 `json:"filter_loss_db"`
+	TxPowerDbm       float64 `json:"tx_power_dbm"`
+	RxSensitivityDbm float64 `json:"rx_sensitivity_dbm"`
+	RxPowerDbm       float64 `json:"rx_power_dbm"`
+	MarginDb         float64 `json:"margin_db"`
+	OverloadMarginDb float64 `json:"overload_margin_db"`
+	Status           Status  `json:"status"`
+}
+
+// Define struct for one WDM channel
+type Channel struct {
+	Label        string  `json:"label"` // e.g. "C21" or "1471"
+	WavelengthNm float64 `json:"wavelength_nm"`
+}
+
+// Define struct for the evaluation of one WDM channel
+type ChannelResult struct {
+	Label            string  `json:"label"`
+	WavelengthNm     float64 `json:"wavelength_nm"`
+	FiberAttDbPerKm  float64 `json:"fiber_att_db_per_km"`
+	DispersionPsNmKm float64 `json:"dispersion_ps_nm_km"`
+	TotalLossDb      float64 `json:"total_loss_db"`
+	PenaltyTotalDb   float64 `json:"penalty_total_db"`
+	RxPowerDbm       float64 `json:"rx_power_dbm"`
+	MarginDb         float64 `json:"margin_db"`
+	LPBStatus        Status  `json:"lpb_status"`
+}
+
+// Define struct for an inline optical amplifier (Type "edfa" or "soa")
+type Amplifier struct {
+	Type          string  `json:"type"`
+	PositionKm    float64 `json:"position_km"`
+	GainDb        float64 `json:"gain_db"`
+	NoiseFigureDb float64 `json:"noise_figure_db"`
+	PsatDbm       float64 `json:"psat_dbm"` // 0 means unlimited
+}
+
+// Define struct for the evaluation result of a link
+type Result struct {
+	LinkID   string `json:"link_id"`
+	Scenario string `json:"scenario"`
+
+	// Loss breakdown
+	FiberLossDb      float64 `json:"fiber_loss_db"`
+	SpliceTotalDb    float64 `json:"splice_total_db"`
+	ConnectorTotalDb float64 `json:"connector_total_db"`
+	WDMLossDb        float64 `json:"wdm_loss_db,omitempty"`
+	TotalLossDb      float64 `json:"total_loss_db"`
+
+	// Power penalties, subtracted from the margin
+	DispersionPenaltyDb float64 `json:"dispersion_penalty_db"`
+	ExtinctionPenaltyDb float64 `json:"er_penalty_db"`
+	RINPenaltyDb        float64 `json:"rin_penalty_db"`
+	MPNPenaltyDb        float64 `json:"mpn_penalty_db"`
+	ReflectionPenaltyDb float64 `json:"reflection_penalty_db"`
+	ChirpPenaltyDb      float64 `json:"chirp_penalty_db"`
+	SBSPenaltyDb        float64 `json:"sbs_penalty_db"`
+	SPMPenaltyDb        float64 `json:"spm_penalty_db"`
+	PenaltyTotalDb      float64 `json:"penalty_total_db"`
+	// Penalty terms without a finite value, reported as 0 and left out of the
+	// margin; LPBStatus is StatusUnbounded when any is listed
+	UnboundedPenalties []string `json:"unbounded_penalties,omitempty"`
+
+	// Margin allowances, subtracted from the margin next to the system margin
+	RepairAllowanceDb      float64 `json:"repair_allowance_db,omitempty"`
+	TemperatureAllowanceDb float64 `json:"temperature_allowance_db,omitempty"`
+	AgingAllowanceDb       float64 `json:"aging_allowance_db,omitempty"`
+	CableCutAllowanceDb    float64 `json:"cable_cut_allowance_db,omitempty"`
+	AllowanceTotalDb       float64 `json:"allowance_total_db,omitempty"`
+
+	// Link power budget
+	RxPowerDbm float64 `json:"rx_power_dbm"`
+	MarginDb   float64 `json:"margin_db"`
+	LPBStatus  Status  `json:"lpb_status"`
+
+	// Rise time budget, StatusNotEvaluated unless WithRiseTimeBudget is set
+	SystemRiseTimeNs  float64 `json:"system_rise_time_ns"`
+	AllowedRiseTimeNs float64 `json:"allowed_rise_time_ns"`
+	RTBStatus         Status  `json:"rtb_status"`
+
+	// Multimode Ethernet PHY, StatusNotEvaluated without a PHY. PHYLimit names
+	// what the link exceeds: fiber, reach, channel_loss.
+	ModalRiseTimeNs     float64 `json:"modal_rise_time_ns,omitempty"`
+	PHYReachM           float64 `json:"phy_reach_m,omitempty"`
+	PHYMaxChannelLossDb float64 `json:"phy_max_channel_loss_db,omitempty"`
+	PHYLimit            string  `json:"phy_limit,omitempty"`
+	PHYStatus           Status  `json:"phy_status"`
+
+	// Polarization mode dispersion, StatusNotEvaluated without a PMD coefficient or bitrate
+	DGDPs     float64 `json:"dgd_ps,omitempty"`
+	MaxDGDPs  float64 `json:"max_dgd_ps,omitempty"`
+	PMDStatus Status  `json:"pmd_status"`
+
+	// Nonlinear thresholds, StatusNotEvaluated without a source linewidth
+	EffectiveLengthKm float64 `json:"effective_length_km,omitempty"`
+	SBSThresholdDbm   float64 `json:"sbs_threshold_dbm,omitempty"`
+	SPMThresholdDbm   float64 `json:"spm_threshold_dbm,omitempty"`
+	NonlinearPhaseRad float64 `json:"nonlinear_phase_rad,omitempty"`
+	NonlinearStatus   Status  `json:"nonlinear_status"`
+
+	// Optical return loss at the transmitter, StatusNotEvaluated without an ORL tolerance
+	ORLDb                float64 `json:"orl_db,omitempty"`
+	ReflectionStatus     Status  `json:"reflection_status"`
+	WorstReflectionEvent string  `json:"worst_reflection_event,omitempty"`
+	WorstReflectanceDb   float64 `json:"worst_reflectance_db,omitempty"`
+
+	// Amplified links, StatusNotEvaluated without amplifiers or a required OSNR
+	AmplifierGainDb float64 `json:"amplifier_gain_db,omitempty"`
+	OSNRDb          float64 `json:"osnr_db,omitempty"`
+	OSNRStatus      Status  `json:"osnr_status"`
+
+	// Bit error rate, StatusNotEvaluated without a BER model
+	QFactor       float64 `json:"q_factor,omitempty"`
+	BER           float64 `json:"ber,omitempty"`
+	BERStatus     Status  `json:"ber_status"`
+	PostFECBER    float64 `json:"post_fec_ber,omitempty"`
+	PostFECStatus Status  `json:"post_fec_status"`
+
+	// WDM links: the fields above describe the worst channel
+	WorstChannel   string          `json:"worst_channel,omitempty"`
+	WorstChannelNm float64         `json:"worst_channel_nm,omitempty"`
+	Channels       []ChannelResult `json:"channels,omitempty"`
+
+	// Coexistence links: the fields above describe the limiting service
+	LimitingService string          `json:"limiting_service,omitempty"`
+	Services        []ServiceResult `json:"services,omitempty"`
+
+	// Largest loss contributors, highest first
+	TopContributors []string `json:"top_contributors"`
+
+	// Standards compliance, StatusNotEvaluated unless WithProfile is set
+	ComplianceStatus Status `json:"compliance_status"`
+	ComplianceClause string `json:"compliance_clause,omitempty"`
+}
+
+// Define struct for a validation finding
+type Issue struct {
+	Row      int      `json:"row"`
+	Field    string   `json:"field,omitempty"`
+	Value    string   `json:"value,omitempty"`
+	Message  string   `json:"message"`
+	Severity Severity `json:"severity"`
+	Refs     []int    `json:"refs,omitempty"`
+}
+
+// Define function to render an issue as text
+func (i Issue) Error() string {
+	return toRowError(i).Error()
+}
+
+// Define struct for a sweep variation
+type Variation struct {
+	Field  string    `json:"field"`
+	Values []float64 `json:"values"`
+}
+
+// Define struct for a break-even solution
+type Solution struct {
+	Field    string  `json:"field"`
+	Current  float64 `json:"current"`
+	Limit    float64 `json:"limit"`
+	Headroom float64 `json:"headroom"`
+}
+
+// Define error returned when validation finds blocking issues
+var ErrInvalidInput = errors.New("engine: input has blocking validation errors")
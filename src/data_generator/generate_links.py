@@ -13,7 +13,7 @@ from pathlib import Path
 class LinkGenerator:
   # Define CSV header columns
   _HEADERS = [
-    "link_id", "scenario", "tx_power_dbm", "rx_sensitivity_dbm", "engineering_margin_db",
+    "link_id", "scenario", "tx_power_dbm", "rx_sensitivity_dbm", "system_margin_db",
     "fiber_length_km", "fiber_att_db_per_km", "n_splice", "splice_loss_db",
     "n_connector", "connector_loss_db", "splitter_loss_db", "other_loss_db",
   ]